@@ -3,11 +3,16 @@ package api
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"context"
 
 	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/datastore/suggest"
+	"github.com/ONSdigital/dp-code-list-api/events"
+	"github.com/ONSdigital/dp-code-list-api/webhooks"
 	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	dphandlers "github.com/ONSdigital/dp-net/handlers"
 	"github.com/ONSdigital/log.go/log"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
@@ -17,58 +22,213 @@ const (
 	internalServerErr = "internal server error"
 	contentTypeHeader = "Content-Type"
 	contentTypeJSON   = "application/json"
+
+	defaultSuggestIndexTTL  = 5 * time.Minute
+	defaultSuggestIndexSize = 100
 )
 
 // CodeListAPI holds all endpoints which are used to access the code list resources
 type CodeListAPI struct {
 	router        *mux.Router
 	store         datastore.DataStore
-	writeBody     func(w http.ResponseWriter, bytes []byte) error
+	cache         datastore.Cache
+	coalescer     datastore.Coalescer
+	suggestions   *suggest.Indexes
 	apiURL        string
 	datasetAPIURL string
 	defaultOffset int
 	defaultLimit  int
 	maxLimit      int
+
+	// identity authenticates the callers of the private endpoints, which are only enabled if it is set
+	identity func(http.Handler) http.Handler
+	// publisher publishes the changes made through the private endpoints, if set
+	publisher events.Publisher
+	// subscriptions holds the webhook subscriptions managed through the private endpoints, if set
+	subscriptions *webhooks.Store
+	// sdmxAgencyID and sdmxVersion identify the SDMX codelists editions are exported as, which are only
+	// available if the agency is set
+	sdmxAgencyID string
+	sdmxVersion  string
+}
+
+// Option configures an optional feature of the code list api
+type Option func(*CodeListAPI)
+
+// WithSuggestIndexes sets how many editions are indexed to suggest codes, and how long the prefix index of an
+// edition is used before it is rebuilt
+func WithSuggestIndexes(size int, ttl time.Duration) Option {
+	return func(api *CodeListAPI) {
+		api.suggestions = suggest.NewIndexes(size, ttl)
+	}
+}
+
+// WithPrivateEndpoints enables the endpoints changing code lists. Their requests go through the provided
+// identity middleware (e.g. dphandlers.Identity), and are only served for an authenticated caller.
+func WithPrivateEndpoints(identity func(http.Handler) http.Handler) Option {
+	return func(api *CodeListAPI) {
+		api.identity = identity
+	}
+}
+
+// WithPublisher publishes an event for each change made to code lists through the private endpoints
+func WithPublisher(publisher events.Publisher) Option {
+	return func(api *CodeListAPI) {
+		api.publisher = publisher
+	}
+}
+
+// WithSubscriptions enables the private endpoints registering webhook subscriptions, which are added to the
+// store used by the webhook dispatcher
+func WithSubscriptions(subscriptions *webhooks.Store) Option {
+	return func(api *CodeListAPI) {
+		api.subscriptions = subscriptions
+	}
+}
+
+// WithSDMX enables the export of editions as SDMX codelists, maintained by the given agency and with the
+// given version
+func WithSDMX(agencyID, version string) Option {
+	return func(api *CodeListAPI) {
+		api.sdmxAgencyID = agencyID
+		api.sdmxVersion = version
+	}
 }
 
 // CreateCodeListAPI returns a constructed code list api
-func CreateCodeListAPI(route *mux.Router, store datastore.DataStore, apiURL, datasetAPIURL string, defaultOffset, defaultLimit, maxLimit int) *CodeListAPI {
+func CreateCodeListAPI(route *mux.Router, store datastore.DataStore, apiURL, datasetAPIURL string, defaultOffset, defaultLimit, maxLimit int, options ...Option) *CodeListAPI {
 	api := CodeListAPI{
 		router:        route,
 		store:         store,
 		apiURL:        apiURL,
 		datasetAPIURL: datasetAPIURL,
-		writeBody: func(w http.ResponseWriter, bytes []byte) error {
-			w.Header().Set(contentTypeHeader, contentTypeJSON)
-			if _, err := w.Write(bytes); err != nil {
-				return err
-			}
-			return nil
-		},
 		defaultOffset: defaultOffset,
 		defaultLimit:  defaultLimit,
 		maxLimit:      maxLimit,
+		suggestions:   suggest.NewIndexes(defaultSuggestIndexSize, defaultSuggestIndexTTL),
+	}
+	for _, option := range options {
+		option(&api)
 	}
 
+	api.router.Use(api.resolveLatestEdition)
+
 	api.router.HandleFunc("/code-lists", api.getCodeLists).Methods("GET")
 	api.router.HandleFunc("/code-lists/{id}", api.getCodeList).Methods("GET")
 	api.router.HandleFunc("/code-lists/{id}/editions", api.getEditions).Methods("GET")
 	api.router.HandleFunc("/code-lists/{id}/editions/{edition}", api.getEdition).Methods("GET")
 	api.router.HandleFunc("/code-lists/{id}/editions/{edition}/codes", api.getCodes).Methods("GET")
+	api.router.HandleFunc("/code-lists/{id}/editions/{edition}/diff", api.getEditionDiff).Methods("GET")
+	api.router.HandleFunc("/code-lists/{id}/editions/{edition}/codes/validate", api.validateCodes).Methods("POST")
+	api.router.HandleFunc("/code-lists/{id}/editions/{edition}/codes/lookup", api.getLookupCodes).Methods("GET")
+	api.router.HandleFunc("/code-lists/{id}/editions/{edition}/codes/lookup", api.postLookupCodes).Methods("POST")
 	api.router.HandleFunc("/code-lists/{id}/editions/{edition}/codes/{code}", api.getCode).Methods("GET")
 	api.router.HandleFunc("/code-lists/{id}/editions/{edition}/codes/{code}/datasets", api.getCodeDatasets).Methods("GET")
+	api.router.HandleFunc("/code-lists/{id}/editions/{edition}/codes/{code}/parent", api.getParentCode).Methods("GET")
+	api.router.HandleFunc("/code-lists/{id}/editions/{edition}/codes/{code}/children", api.getChildCodes).Methods("GET")
+	api.router.HandleFunc("/code-lists/{id}/editions/{edition}/codes/{code}/ancestors", api.getAncestorCodes).Methods("GET")
+	api.router.HandleFunc("/code-lists/{id}/editions/{edition}/codes/{code}/mappings", api.getCodeMappings).Methods("GET")
+	api.router.HandleFunc("/code-lists/{id}/editions/{edition}/hierarchy", api.getHierarchy).Methods("GET")
+	api.router.HandleFunc("/code-lists/{id}/editions/{edition}/suggest", api.getSuggestions).Methods("GET")
+	api.router.HandleFunc("/code-lists/{id}/editions/{edition}/match", api.postMatch).Methods("POST")
+	api.router.HandleFunc("/codes/{code}", api.getCodeEditions).Methods("GET")
+	api.router.HandleFunc("/mappings/resolve", api.postResolveMappings).Methods("POST")
+
+	// Private endpoints require an authenticated caller, set in the request context by the identity middleware
+	if api.identity != nil {
+		api.router.Handle("/code-lists", api.private(api.postCodeList)).Methods("POST")
+		api.router.Handle("/code-lists/{id}/editions/{edition}", api.private(api.putEdition)).Methods("PUT")
+		api.router.Handle("/code-lists/{id}/editions/{edition}"+importPath, api.private(api.importEdition)).Methods("POST")
+		api.router.Handle("/code-lists/{id}/editions/{edition}/codes/{code}", api.private(api.putCode)).Methods("PUT")
+		api.router.Handle("/code-lists/{id}/editions/{edition}/codes/{code}", api.private(api.deleteCode)).Methods("DELETE")
+		if api.subscriptions != nil {
+			api.router.Handle("/subscriptions", api.private(api.postSubscription)).Methods("POST")
+			api.router.Handle("/subscriptions/{id}", api.private(api.getSubscription)).Methods("GET")
+			api.router.Handle("/subscriptions/{id}", api.private(api.deleteSubscription)).Methods("DELETE")
+			api.router.Handle("/subscriptions/{id}/failures", api.private(api.getSubscriptionFailures)).Methods("GET")
+		}
+	}
+
+	// Administration endpoints are only available to authenticated callers, when the store, or a store it
+	// decorates, supports them
+	for _, layer := range datastore.Layers(store) {
+		if cache, ok := layer.(datastore.Cache); ok && api.cache == nil {
+			api.cache = cache
+		}
+		if coalescer, ok := layer.(datastore.Coalescer); ok && api.coalescer == nil {
+			api.coalescer = coalescer
+		}
+	}
+	if api.cache != nil && api.identity != nil {
+		api.router.Handle("/admin/cache", api.private(api.getCacheStats)).Methods("GET")
+		api.router.Handle("/admin/cache/code-lists/{id}", api.private(api.invalidateCache)).Methods("DELETE")
+	}
+	if api.coalescer != nil && api.identity != nil {
+		api.router.Handle("/admin/coalescing", api.private(api.getCoalesceStats)).Methods("GET")
+	}
 	return &api
 }
 
+// private returns a handler only serving the requests whose caller is authenticated by the identity middleware
+func (c *CodeListAPI) private(handle func(http.ResponseWriter, *http.Request)) http.Handler {
+	return c.identity(dphandlers.CheckIdentity(handle))
+}
+
 func handleError(ctx context.Context, logMsg string, logData log.Data, err error, w http.ResponseWriter) {
 	log.Event(ctx, logMsg, log.ERROR, log.Error(err), logData)
 	if err == driver.ErrNotFound {
 		http.Error(w, err.Error(), http.StatusNotFound)
+	} else if err == datastore.ErrNotSupported {
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+	} else if err == datastore.ErrAlreadyExists {
+		http.Error(w, err.Error(), http.StatusConflict)
+	} else if _, ok := err.(*datastore.InvalidError); ok {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 	} else {
 		http.Error(w, internalServerErr, http.StatusInternalServerError)
 	}
 }
 
+// getPage returns the offset and limit query parameters of a request, or their default values. If either
+// of them is invalid, a 400 response is written and ok is false.
+func (c *CodeListAPI) getPage(ctx context.Context, w http.ResponseWriter, r *http.Request, logData log.Data) (offset, limit int, ok bool) {
+	offsetParameter := r.URL.Query().Get("offset")
+	limitParameter := r.URL.Query().Get("limit")
+	offset = c.defaultOffset
+	limit = c.defaultLimit
+	var err error
+
+	if offsetParameter != "" {
+		logData["offset"] = offsetParameter
+		offset, err = ValidatePositiveInt(offsetParameter)
+		if err != nil {
+			log.Event(ctx, "invalid query parameter: offset", log.ERROR, log.Error(err), logData)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return 0, 0, false
+		}
+	}
+
+	if limitParameter != "" {
+		logData["limit"] = limitParameter
+		limit, err = ValidatePositiveInt(limitParameter)
+		if err != nil {
+			log.Event(ctx, "invalid query parameter: limit", log.ERROR, log.Error(err), logData)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return 0, 0, false
+		}
+	}
+
+	if limit > c.maxLimit {
+		logData["max_limit"] = c.maxLimit
+		err = errors.New("limit is greater than the maximum allowed")
+		log.Event(ctx, "invalid query parameter: limit, maximum limit reached", log.ERROR, log.Error(err), logData)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return 0, 0, false
+	}
+
+	return offset, limit, true
+}
+
 // ValidatePositiveInt obtains the positive int value of query var defined by the provided varKey
 func ValidatePositiveInt(parameter string) (val int, err error) {
 	val, err = strconv.Atoi(parameter)
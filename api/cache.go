@@ -0,0 +1,36 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+func (c *CodeListAPI) getCacheStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	stats := newCacheStats(c.cache.Stats())
+
+	if err := c.writeBody(w, r, stats); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "getCacheStats endpoint: failed to write bytes to response")))
+		return
+	}
+
+	log.Event(ctx, "getCacheStats endpoint: request successful", log.INFO, log.Data{"entries": stats.Entries})
+}
+
+func (c *CodeListAPI) invalidateCache(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	data := log.Data{"codelist_id": id}
+
+	invalidated := c.cache.Invalidate(ctx, id)
+	data["invalidated"] = invalidated
+	data["invalidated_suggest_indexes"] = c.suggestions.Invalidate(id)
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Event(ctx, "invalidateCache endpoint: cached results dropped for code list", log.INFO, data)
+}
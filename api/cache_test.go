@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCacheEndpoints(t *testing.T) {
+	Convey("Given a store that caches its results", t, func() {
+		mockCache := &storetest.CacheMock{
+			StatsFunc: func() datastore.CacheStats {
+				return datastore.CacheStats{Entries: 3, Hits: 10, Misses: 4, Evictions: 1}
+			},
+			InvalidateFunc: func(ctx context.Context, codeListID string) int {
+				return 2
+			},
+		}
+		store := struct {
+			*storetest.DataStoreMock
+			*storetest.CacheMock
+		}{&storetest.DataStoreMock{}, mockCache}
+		api := CreateCodeListAPI(mux.NewRouter(), store, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit, WithPrivateEndpoints(testIdentity))
+
+		Convey("When the cache stats are requested, then they are returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("GET", codeListURL+"/admin/cache", ""))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.CacheStats{}, &models.CacheStats{Entries: 3, Hits: 10, Misses: 4, Evictions: 1})
+		})
+
+		Convey("When the cache of a code list is invalidated, then 204 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("DELETE", codeListURL+"/admin/cache/code-lists/"+codeListID1, ""))
+
+			So(w.Code, ShouldEqual, http.StatusNoContent)
+			So(mockCache.InvalidateCalls(), ShouldHaveLength, 1)
+			So(mockCache.InvalidateCalls()[0].CodeListID, ShouldEqual, codeListID1)
+		})
+
+		Convey("When the cache of a code list is invalidated by an unauthenticated caller, then 401 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("DELETE", codeListURL+"/admin/cache/code-lists/"+codeListID1, nil))
+
+			So(w.Code, ShouldEqual, http.StatusUnauthorized)
+			So(mockCache.InvalidateCalls(), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a store that caches its results, without private endpoints", t, func() {
+		store := struct {
+			*storetest.DataStoreMock
+			*storetest.CacheMock
+		}{&storetest.DataStoreMock{}, &storetest.CacheMock{}}
+		api := CreateCodeListAPI(mux.NewRouter(), store, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When the cache of a code list is invalidated, then 404 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("DELETE", codeListURL+"/admin/cache/code-lists/"+codeListID1, nil))
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+
+	Convey("Given a store that does not cache its results", t, func() {
+		api := CreateCodeListAPI(mux.NewRouter(), &storetest.DataStoreMock{}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit, WithPrivateEndpoints(testIdentity))
+
+		Convey("When the cache stats are requested, then 404 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("GET", codeListURL+"/admin/cache", ""))
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ONSdigital/log.go/log"
+	"github.com/pkg/errors"
+)
+
+func (c *CodeListAPI) getCoalesceStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	stats := newCoalesceStats(c.coalescer.CoalesceStats())
+
+	if err := c.writeBody(w, r, stats); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "getCoalesceStats endpoint: failed to write bytes to response")))
+		return
+	}
+
+	log.Event(ctx, "getCoalesceStats endpoint: request successful", log.INFO, log.Data{"calls": stats.Calls, "coalesced": stats.Coalesced})
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// wrapper is a store decorating another store, to check that the api finds the capabilities of decorated stores
+type wrapper struct {
+	*storetest.DataStoreMock
+	store datastore.DataStore
+}
+
+func (w wrapper) Unwrap() datastore.DataStore {
+	return w.store
+}
+
+func TestGetCoalesceStats(t *testing.T) {
+	Convey("Given a store decorating a store that coalesces its calls", t, func() {
+		store := wrapper{&storetest.DataStoreMock{}, struct {
+			*storetest.DataStoreMock
+			*storetest.CoalescerMock
+		}{&storetest.DataStoreMock{}, &storetest.CoalescerMock{
+			CoalesceStatsFunc: func() datastore.CoalesceStats {
+				return datastore.CoalesceStats{Calls: 10, Coalesced: 7}
+			},
+		}}}
+		api := CreateCodeListAPI(mux.NewRouter(), store, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit, WithPrivateEndpoints(testIdentity))
+
+		Convey("When the coalescing stats are requested, then they are returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("GET", codeListURL+"/admin/coalescing", ""))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.CoalesceStats{}, &models.CoalesceStats{Calls: 10, Coalesced: 7})
+		})
+
+		Convey("When the coalescing stats are requested by an unauthenticated caller, then 401 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", codeListURL+"/admin/coalescing", nil))
+			So(w.Code, ShouldEqual, http.StatusUnauthorized)
+		})
+	})
+
+	Convey("Given a store that does not coalesce its calls", t, func() {
+		api := CreateCodeListAPI(mux.NewRouter(), &storetest.DataStoreMock{}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit, WithPrivateEndpoints(testIdentity))
+
+		Convey("When the coalescing stats are requested, then 404 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("GET", codeListURL+"/admin/coalescing", ""))
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
@@ -1,12 +1,11 @@
 package api
 
 import (
-	"encoding/json"
+	"math"
 	"net/http"
-	"sort"
 
+	"github.com/ONSdigital/dp-code-list-api/datastore"
 	"github.com/ONSdigital/dp-code-list-api/models"
-	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
 	"github.com/ONSdigital/log.go/log"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
@@ -16,59 +15,45 @@ func (c *CodeListAPI) getCodeLists(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	filterBy := r.URL.Query().Get("type")
 	logData := log.Data{}
-	offsetParameter := r.URL.Query().Get("offset")
-	limitParameter := r.URL.Query().Get("limit")
-	offset := c.defaultOffset
-	limit := c.defaultLimit
-	var err error
 
 	log.Event(ctx, "attempting to get code lists", log.INFO, log.Data{"type": filterBy})
 
-	if offsetParameter != "" {
-		logData["offset"] = offsetParameter
-		offset, err = ValidatePositiveInt(offsetParameter)
-		if err != nil {
-			log.Event(ctx, "invalid query parameter: offset", log.ERROR, log.Error(err), logData)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
+	offset, limit, ok := c.getPage(ctx, w, r, logData)
+	if !ok {
+		return
 	}
 
-	if limitParameter != "" {
-		logData["limit"] = limitParameter
-		limit, err = ValidatePositiveInt(limitParameter)
-		if err != nil {
-			log.Event(ctx, "invalid query parameter: limit", log.ERROR, log.Error(err), logData)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
+	// every item is written as CSV or linked data, so that every code list can be downloaded at once
+	if wantsEveryItem(r, &models.CodeListResults{}) {
+		offset, limit = 0, math.MaxInt32
 	}
 
-	if limit > c.maxLimit {
-		logData["max_limit"] = c.maxLimit
-		err = errors.New("limit is greater than the maximum allowed")
-		log.Event(ctx, "invalid query parameter: limit, maximum limit reached", log.ERROR, log.Error(err), logData)
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	page := datastore.Page{Offset: offset, Limit: limit, Order: datastore.OrderByID}
+	dbCodeLists, totalCount, err := datastore.GetCodeListsPage(ctx, c.store, filterBy, page)
+	if err != nil {
+		handleError(ctx, "failed to get code lists from store", log.Data{"type": filterBy}, err, w)
 		return
 	}
 
-	dbCodeLists, err := c.store.GetCodeLists(r.Context(), filterBy)
+	allMetadata, err := datastore.GetCodeListsMetadata(ctx, c.store)
 	if err != nil {
-		handleError(ctx, "failed to get code lists from graph", log.Data{"type": filterBy}, err, w)
+		handleError(ctx, "getCodeLists endpoint: failed to get code lists metadata from store", log.Data{"type": filterBy}, err, w)
 		return
 	}
 
-	totalCount := len(dbCodeLists.Items)
-
-	sort.Slice(dbCodeLists.Items, func(i, j int) bool {
-		return dbCodeLists.Items[i].ID < dbCodeLists.Items[j].ID
-	})
-
-	slicedResults := codelistsSlice(dbCodeLists.Items, offset, limit)
-
-	codeLists := models.NewCodeListResults(slicedResults)
+	codeLists := models.NewCodeListResults(dbCodeLists.Items)
 
 	for i, item := range codeLists.Items {
+		metadata, ok := allMetadata[item.ID]
+		if !ok {
+			metadata = datastore.CodeListMetadata{ID: item.ID}
+		}
+		// the graph store does not hold the type of code lists, but it is known when filtering by type
+		if metadata.Type == "" {
+			metadata.Type = filterBy
+		}
+		updateCodeListMetadata(&item, &metadata)
+
 		if err := item.UpdateLinks(c.apiURL); err != nil {
 			log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "getCodeLists endpoint: links could not be created")))
 			http.Error(w, internalServerErr, http.StatusInternalServerError)
@@ -77,19 +62,13 @@ func (c *CodeListAPI) getCodeLists(w http.ResponseWriter, r *http.Request) {
 		codeLists.Items[i] = item
 	}
 
-	count := len(slicedResults)
+	count := len(dbCodeLists.Items)
 	codeLists.Count = count
 	codeLists.Offset = offset
 	codeLists.Limit = limit
 	codeLists.TotalCount = totalCount
 
-	b, err := json.Marshal(codeLists)
-	if err != nil {
-		handleError(ctx, "failed to marshal code lists", log.Data{}, err, w)
-		return
-	}
-
-	if err := c.writeBody(w, b); err != nil {
+	if err := c.writeBody(w, r, codeLists); err != nil {
 		return
 	}
 	log.Event(ctx, "retrieved all codelists", log.INFO)
@@ -109,34 +88,23 @@ func (c *CodeListAPI) getCodeList(w http.ResponseWriter, r *http.Request) {
 
 	codeList := models.NewCodeList(dbCodeList)
 
-	if err := codeList.UpdateLinks(c.apiURL); err != nil {
-		log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "getCodeList endpoint: links could not be created")))
-		http.Error(w, internalServerErr, http.StatusInternalServerError)
+	metadata, err := datastore.GetCodeListMetadata(ctx, c.store, id)
+	if err != nil {
+		handleError(ctx, "getCodeList endpoint: failed to get code list metadata from store", data, err, w)
 		return
 	}
+	updateCodeListMetadata(codeList, metadata)
 
-	b, err := json.Marshal(codeList)
-	if err != nil {
-		handleError(ctx, "failed to marshal code list", log.Data{}, err, w)
+	if err := codeList.UpdateLinks(c.apiURL); err != nil {
+		log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "getCodeList endpoint: links could not be created")))
+		http.Error(w, internalServerErr, http.StatusInternalServerError)
 		return
 	}
 
-	if err := c.writeBody(w, b); err != nil {
+	if err := c.writeBody(w, r, codeList); err != nil {
 		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "getCodeList endpoint: failed to write bytes to response")), data)
 		return
 	}
 
 	log.Event(ctx, "getCodeList endpoint: request successful", log.INFO, data)
 }
-
-func codelistsSlice(full []dbmodels.CodeList, offset, limit int) (sliced []dbmodels.CodeList) {
-	end := offset + limit
-	if end > len(full) {
-		end = len(full)
-	}
-
-	if offset > len(full) || limit == 0 {
-		return []dbmodels.CodeList{}
-	}
-	return full[offset:end]
-}
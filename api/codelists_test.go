@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/ONSdigital/dp-code-list-api/datastore"
 	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
 	"github.com/ONSdigital/dp-code-list-api/models"
 	"github.com/pkg/errors"
@@ -25,16 +26,20 @@ var (
 	dbCodeListResults = dbmodels.CodeListResults{Items: []dbmodels.CodeList{dbCodeList1, dbCodeList2}}
 
 	expectedCodeList1 = models.CodeList{
+		ID: codeListID1,
 		Links: &models.CodeListLink{
-			Self:     &models.Link{ID: codeListID1, Href: fmt.Sprintf("%s/code-lists/%s", codeListURL, codeListID1)},
-			Editions: &models.Link{ID: "", Href: fmt.Sprintf("%s/code-lists/%s/editions", codeListURL, codeListID1)},
+			Self:          &models.Link{ID: codeListID1, Href: fmt.Sprintf("%s/code-lists/%s", codeListURL, codeListID1)},
+			Editions:      &models.Link{ID: "", Href: fmt.Sprintf("%s/code-lists/%s/editions", codeListURL, codeListID1)},
+			LatestEdition: &models.Link{Href: fmt.Sprintf("%s/code-lists/%s/editions/latest", codeListURL, codeListID1)},
 		},
 	}
 
 	expectedCodeList2 = models.CodeList{
+		ID: codeListID2,
 		Links: &models.CodeListLink{
-			Self:     &models.Link{ID: codeListID2, Href: fmt.Sprintf("%s/code-lists/%s", codeListURL, codeListID2)},
-			Editions: &models.Link{ID: "", Href: fmt.Sprintf("%s/code-lists/%s/editions", codeListURL, codeListID2)},
+			Self:          &models.Link{ID: codeListID2, Href: fmt.Sprintf("%s/code-lists/%s", codeListURL, codeListID2)},
+			Editions:      &models.Link{ID: "", Href: fmt.Sprintf("%s/code-lists/%s/editions", codeListURL, codeListID2)},
+			LatestEdition: &models.Link{Href: fmt.Sprintf("%s/code-lists/%s/editions/latest", codeListURL, codeListID2)},
 		},
 	}
 
@@ -91,6 +96,60 @@ func TestGetCodeLists(t *testing.T) {
 		validateBody(w.Body, &models.CodeListResults{}, &expectedCodeListResults)
 	})
 
+	Convey("Get code lists filtered by type returns the type of each code list", t, func() {
+		r := httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists?type=geography", codeListURL), nil)
+		w := httptest.NewRecorder()
+
+		mockDatastore := &storetest.DataStoreMock{
+			GetCodeListsFunc: func(ctx context.Context, filterBy string) (*dbmodels.CodeListResults, error) {
+				return &dbmodels.CodeListResults{Items: []dbmodels.CodeList{dbCodeList1}}, nil
+			},
+		}
+
+		api := CreateCodeListAPI(mux.NewRouter(), mockDatastore, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+		api.router.ServeHTTP(w, r)
+		So(w.Code, ShouldEqual, http.StatusOK)
+
+		expected := expectedCodeList1
+		expected.Type = "geography"
+		validateBody(w.Body, &models.CodeListResults{}, &models.CodeListResults{
+			Items:      []models.CodeList{expected},
+			Count:      1,
+			Limit:      defaultLimit,
+			TotalCount: 1,
+		})
+	})
+
+	Convey("Get code lists returns the metadata of every code list, fetched with a single call", t, func() {
+		r := httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists", codeListURL), nil)
+		w := httptest.NewRecorder()
+
+		mockDatastore := &storetest.DataStoreMock{
+			GetCodeListsFunc: func(ctx context.Context, filterBy string) (*dbmodels.CodeListResults, error) {
+				return &dbCodeListResults, nil
+			},
+		}
+		mockMetadataGetter := &storetest.MetadataGetterMock{
+			GetCodeListsMetadataFunc: func(ctx context.Context) ([]datastore.CodeListMetadata, error) {
+				return []datastore.CodeListMetadata{{ID: codeListID1, Label: "Local authority", Type: "geography"}}, nil
+			},
+		}
+
+		api := CreateCodeListAPI(mux.NewRouter(), struct {
+			*storetest.DataStoreMock
+			*storetest.MetadataGetterMock
+		}{mockDatastore, mockMetadataGetter}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+		api.router.ServeHTTP(w, r)
+		So(w.Code, ShouldEqual, http.StatusOK)
+		So(mockMetadataGetter.GetCodeListsMetadataCalls(), ShouldHaveLength, 1)
+
+		expected := expectedCodeListResults
+		expected.Items = append([]models.CodeList{}, expectedCodeListResults.Items...)
+		expected.Items[0].Label = "Local authority"
+		expected.Items[0].Type = "geography"
+		validateBody(w.Body, &models.CodeListResults{}, &expected)
+	})
+
 	Convey("When valid limit and offset query parameters are provided, then return codelist information according to the offset and limit", t, func() {
 		r := httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists?offset=0&limit=1", codeListURL), nil)
 		w := httptest.NewRecorder()
@@ -219,6 +278,44 @@ func TestGetCodeList(t *testing.T) {
 		validateBody(w.Body, &models.CodeList{}, &expectedCodeList1)
 	})
 
+	Convey("Get a code list returns its metadata when the store holds it", t, func() {
+		r := httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s", codeListURL, codeListID1), nil)
+		w := httptest.NewRecorder()
+
+		mockDatastore := &storetest.DataStoreMock{
+			GetCodeListFunc: func(ctx context.Context, id string) (*dbmodels.CodeList, error) {
+				return &dbCodeList1, nil
+			},
+		}
+		mockMetadataGetter := &storetest.MetadataGetterMock{
+			GetCodeListMetadataFunc: func(ctx context.Context, codeListID string) (*datastore.CodeListMetadata, error) {
+				return &datastore.CodeListMetadata{
+					ID:          codeListID,
+					Label:       "Local authority",
+					Description: "Local authorities of the UK",
+					Type:        "geography",
+					Licence:     "Open Government Licence v3.0",
+					Contact:     &datastore.Contact{Name: "Geography", Email: "geography@ons.gov.uk"},
+				}, nil
+			},
+		}
+
+		api := CreateCodeListAPI(mux.NewRouter(), struct {
+			*storetest.DataStoreMock
+			*storetest.MetadataGetterMock
+		}{mockDatastore, mockMetadataGetter}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+		api.router.ServeHTTP(w, r)
+		So(w.Code, ShouldEqual, http.StatusOK)
+
+		expected := expectedCodeList1
+		expected.Label = "Local authority"
+		expected.Description = "Local authorities of the UK"
+		expected.Type = "geography"
+		expected.Licence = "Open Government Licence v3.0"
+		expected.Contact = &models.Contact{Name: "Geography", Email: "geography@ons.gov.uk"}
+		validateBody(w.Body, &models.CodeList{}, &expected)
+	})
+
 	Convey("Get a code list returns a status of not found", t, func() {
 		r := httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s", codeListURL, codeListID1), nil)
 		w := httptest.NewRecorder()
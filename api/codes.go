@@ -1,9 +1,11 @@
 package api
 
 import (
-	"encoding/json"
+	"math"
 	"net/http"
+	"strings"
 
+	"github.com/ONSdigital/dp-code-list-api/datastore"
 	"github.com/ONSdigital/dp-code-list-api/models"
 	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
 	"github.com/ONSdigital/log.go/log"
@@ -18,61 +20,37 @@ func (c *CodeListAPI) getCodes(w http.ResponseWriter, r *http.Request) {
 	edition := vars["edition"]
 	data := log.Data{"codelist_id": id, "edition": edition}
 	logData := log.Data{}
-	offsetParameter := r.URL.Query().Get("offset")
-	limitParameter := r.URL.Query().Get("limit")
-	offset := c.defaultOffset
-	limit := c.defaultLimit
-	var err error
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
 
 	log.Event(ctx, "getCodes endpoint: attempting to get edition codes", log.INFO, data)
 
-	if offsetParameter != "" {
-		logData["offset"] = offsetParameter
-		offset, err = ValidatePositiveInt(offsetParameter)
-		if err != nil {
-			log.Event(ctx, "invalid query parameter: offset", log.ERROR, log.Error(err), logData)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
+	offset, limit, ok := c.getPage(ctx, w, r, logData)
+	if !ok {
+		return
 	}
 
-	if limitParameter != "" {
-		logData["limit"] = limitParameter
-		limit, err = ValidatePositiveInt(limitParameter)
-		if err != nil {
-			log.Event(ctx, "invalid query parameter: limit", log.ERROR, log.Error(err), logData)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
+	// every item is written as CSV or linked data, so that a whole edition can be downloaded into a spreadsheet
+	// or a triple store
+	if wantsEveryItem(r, &models.CodeResults{}) {
+		offset, limit = 0, math.MaxInt32
 	}
 
-	if limit > c.maxLimit {
-		logData["max_limit"] = c.maxLimit
-		err = errors.New("limit is greater than the maximum allowed")
-		log.Event(ctx, "invalid query parameter: limit, maximum limit reached", log.ERROR, log.Error(err), logData)
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+	page := datastore.Page{Offset: offset, Limit: limit}
+	var dbCodes *dbmodels.CodeResults
+	var totalCount int
+	var err error
+	if query != "" {
+		data["q"] = query
+		dbCodes, totalCount, err = datastore.SearchCodes(ctx, c.store, id, edition, query, page)
+	} else {
+		dbCodes, totalCount, err = datastore.GetCodesPage(ctx, c.store, id, edition, page)
 	}
-
-	totalCount, err := c.store.CountCodes(ctx, id, edition)
 	if err != nil {
-		handleError(ctx, "getCodes endpoint: store.CountCodes returned an error", data, err, w)
+		handleError(ctx, "getCodes endpoint: failed to get page of codes from store", data, err, w)
 		return
 	}
 
-	dbCodes := &dbmodels.CodeResults{}
-
-	if limit > 0 && totalCount > 0 {
-		dbCodes, err = c.store.GetCodes(ctx, id, edition)
-		if err != nil {
-			handleError(ctx, "getCodes endpoint: store.GetCodes returned an error", data, err, w)
-			return
-		}
-	}
-
-	slicedResults := codesSlice(dbCodes.Items, offset, limit)
-
-	codes := models.NewCodeResults(slicedResults)
+	codes := models.NewCodeResults(dbCodes.Items)
 
 	for i, item := range codes.Items {
 		if err := item.UpdateLinks(c.apiURL, id, edition); err != nil {
@@ -83,19 +61,21 @@ func (c *CodeListAPI) getCodes(w http.ResponseWriter, r *http.Request) {
 		codes.Items[i] = item
 	}
 
-	count := len(slicedResults)
+	count := len(dbCodes.Items)
 	codes.Count = count
 	codes.Offset = offset
 	codes.Limit = limit
-	codes.TotalCount = int(totalCount)
+	codes.TotalCount = totalCount
 
-	b, err := json.Marshal(codes)
-	if err != nil {
-		handleError(ctx, "failed to marshal codes", data, err, w)
-		return
+	// the parent of a code described as linked data is its broader concept
+	if wantsSKOS(r, codes) {
+		if err := c.updateBroaderLinks(ctx, codes, id, edition); err != nil {
+			handleError(ctx, "getCodes endpoint: failed to get parent codes from store", data, err, w)
+			return
+		}
 	}
 
-	if err := c.writeBody(w, b); err != nil {
+	if err := c.writeBody(w, r, codes); err != nil {
 		return
 	}
 
@@ -125,28 +105,28 @@ func (c *CodeListAPI) getCode(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	b, err := json.Marshal(apiCode)
-	if err != nil {
-		handleError(ctx, "failed to marshal code", data, err, w)
+	// codes of stores holding hierarchies also link to their parent and children
+	parent, err := datastore.GetParentCode(ctx, c.store, id, edition, code)
+	if err != nil && err != datastore.ErrNotSupported {
+		handleError(ctx, "getCode endpoint: failed to get parent code from store", data, err, w)
 		return
 	}
+	if err == nil {
+		parentID := ""
+		if parent != nil {
+			parentID = parent.Code
+		}
+		if err := apiCode.UpdateHierarchyLinks(c.apiURL, id, edition, parentID); err != nil {
+			log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "getCode endpoint: hierarchy links could not be created")))
+			http.Error(w, internalServerErr, http.StatusInternalServerError)
+			return
+		}
+	}
 
-	if err := c.writeBody(w, b); err != nil {
+	if err := c.writeBody(w, r, apiCode); err != nil {
 		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "getCode endpoint: failed to write bytes to response")))
 		return
 	}
 
 	log.Event(ctx, "getCode endpoint: request successful", log.INFO, data)
 }
-
-func codesSlice(full []dbmodels.Code, offset, limit int) (sliced []dbmodels.Code) {
-	end := offset + limit
-	if end > len(full) {
-		end = len(full)
-	}
-
-	if offset > len(full) || limit == 0 {
-		return []dbmodels.Code{}
-	}
-	return full[offset:end]
-}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ONSdigital/dp-code-list-api/datastore"
 	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
 	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
 
@@ -412,6 +413,107 @@ func TestGetCodes_Pagination(t *testing.T) {
 	})
 }
 
+func TestGetCodes_PaginatedStore(t *testing.T) {
+	Convey("Given a store that supports pagination", t, func() {
+		mockDatastore := &storetest.DataStoreMock{}
+		mockPaginator := &storetest.PaginatorMock{
+			GetCodesPageFunc: func(ctx context.Context, codeListID string, editionID string, page datastore.Page) (*dbmodels.CodeResults, int, error) {
+				return &dbmodels.CodeResults{Items: []dbmodels.Code{dbCode1}}, 2, nil
+			},
+		}
+		store := struct {
+			*storetest.DataStoreMock
+			*storetest.PaginatorMock
+		}{mockDatastore, mockPaginator}
+
+		Convey("when getCodes is called", func() {
+			r := httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/codes?offset=0&limit=1", codeListURL, codeListID1, editionID1), nil)
+			w := httptest.NewRecorder()
+
+			api := CreateCodeListAPI(mux.NewRouter(), store, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+			api.router.ServeHTTP(w, r)
+
+			Convey("then only the requested page is fetched from the store", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				validateBody(w.Body, &models.CodeResults{}, &codePaginationTestOne)
+
+				So(mockPaginator.GetCodesPageCalls(), ShouldHaveLength, 1)
+				So(mockPaginator.GetCodesPageCalls()[0].CodeListID, ShouldEqual, codeListID1)
+				So(mockPaginator.GetCodesPageCalls()[0].EditionID, ShouldEqual, editionID1)
+				So(mockPaginator.GetCodesPageCalls()[0].Page, ShouldResemble, datastore.Page{Offset: 0, Limit: 1})
+				So(mockDatastore.CountCodesCalls(), ShouldBeEmpty)
+				So(mockDatastore.GetCodesCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetCodes_Search(t *testing.T) {
+	Convey("Given a store that does not support searching", t, func() {
+		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: func(ctx context.Context, codeListID string, editionID string) (int64, error) {
+				return 2, nil
+			},
+			GetCodesFunc: func(ctx context.Context, codeListID string, editionID string) (*dbmodels.CodeResults, error) {
+				return &dbmodels.CodeResults{Items: []dbmodels.Code{dbCode1, dbCode2}}, nil
+			},
+		}
+		api := CreateCodeListAPI(mux.NewRouter(), mockDatastore, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("when getCodes is called with a query, then the codes matching it are returned, ignoring case", func() {
+			r := httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/codes?q=TWO", codeListURL, codeListID1, editionID1), nil)
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.CodeResults{}, &models.CodeResults{
+				Items:      []models.Code{expectedCode2},
+				Count:      1,
+				Limit:      defaultLimit,
+				TotalCount: 1,
+			})
+		})
+
+		Convey("when getCodes is called with a query matching no code, then an empty page is returned", func() {
+			r := httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/codes?q=three", codeListURL, codeListID1, editionID1), nil)
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.CodeResults{}, &models.CodeResults{
+				Items: nil,
+				Limit: defaultLimit,
+			})
+		})
+	})
+
+	Convey("Given a store that supports searching", t, func() {
+		mockSearcher := &storetest.SearcherMock{
+			SearchCodesFunc: func(ctx context.Context, codeListID string, editionID string, query string, page datastore.Page) (*dbmodels.CodeResults, int, error) {
+				return &dbmodels.CodeResults{Items: []dbmodels.Code{dbCode1}}, 2, nil
+			},
+		}
+		store := struct {
+			*storetest.DataStoreMock
+			*storetest.SearcherMock
+		}{&storetest.DataStoreMock{}, mockSearcher}
+		api := CreateCodeListAPI(mux.NewRouter(), store, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("when getCodes is called with a query, then the store is searched for the requested page", func() {
+			r := httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/codes?q=+test+&offset=0&limit=1", codeListURL, codeListID1, editionID1), nil)
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.CodeResults{}, &codePaginationTestOne)
+
+			So(mockSearcher.SearchCodesCalls(), ShouldHaveLength, 1)
+			So(mockSearcher.SearchCodesCalls()[0].Query, ShouldEqual, "test")
+			So(mockSearcher.SearchCodesCalls()[0].Page, ShouldResemble, datastore.Page{Offset: 0, Limit: 1})
+		})
+	})
+}
+
 func TestGetCode_Success(t *testing.T) {
 	Convey("Given a valid request", t, func() {
 		mockDatastore := &storetest.DataStoreMock{
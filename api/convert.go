@@ -0,0 +1,324 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/ONSdigital/dp-code-list-api/csvimport"
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/diff"
+	"github.com/ONSdigital/dp-code-list-api/events"
+	"github.com/ONSdigital/dp-code-list-api/match"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/dp-code-list-api/sdmx"
+	"github.com/ONSdigital/dp-code-list-api/webhooks"
+	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/pkg/errors"
+)
+
+// The conversions between the types of the csvimport, datastore, diff, events, match and webhooks packages and the
+// API models are kept here, so that the models do not depend on them.
+
+// updateCodeListMetadata sets the label, description, type, licence and contact details of a code list
+func updateCodeListMetadata(c *models.CodeList, metadata *datastore.CodeListMetadata) {
+	if metadata == nil {
+		return
+	}
+
+	c.Label = metadata.Label
+	c.Description = metadata.Description
+	c.Type = metadata.Type
+	c.Licence = metadata.Licence
+	c.Contact = nil
+	if metadata.Contact != nil {
+		c.Contact = &models.Contact{
+			Name:      metadata.Contact.Name,
+			Email:     metadata.Contact.Email,
+			Telephone: metadata.Contact.Telephone,
+		}
+	}
+}
+
+// updateEditionMetadata sets the release date, state and code count of an edition, and links to the editions
+// it supersedes and is superseded by
+func updateEditionMetadata(e *models.Edition, codeListID, url string, metadata datastore.EditionMetadata) {
+	e.ReleaseDate = metadata.ReleaseDate
+	e.State = string(metadata.State)
+	e.CodeCount = metadata.CodeCount
+	e.UpdateSupersessionLinks(codeListID, url, metadata.Supersedes, metadata.SupersededBy)
+}
+
+// codeListMetadata returns the metadata of the code list to create
+func codeListMetadata(r *models.CodeListRequest) datastore.CodeListMetadata {
+	metadata := datastore.CodeListMetadata{
+		ID:          r.ID,
+		Label:       strings.TrimSpace(r.Label),
+		Description: r.Description,
+		Type:        r.Type,
+		Licence:     r.Licence,
+	}
+	if r.Contact != nil {
+		metadata.Contact = &datastore.Contact{
+			Name:      r.Contact.Name,
+			Email:     r.Contact.Email,
+			Telephone: r.Contact.Telephone,
+		}
+	}
+	return metadata
+}
+
+// editionUpdate returns the edition to create or replace, or an error if its state is unknown
+func editionUpdate(r *models.EditionRequest, editionID string) (datastore.EditionUpdate, error) {
+	state := datastore.EditionState(r.State)
+	if state != "" && !state.Valid() {
+		return datastore.EditionUpdate{}, errors.New("invalid edition state, it must be draft, published or retired")
+	}
+
+	return datastore.EditionUpdate{
+		ID:          editionID,
+		Label:       strings.TrimSpace(r.Label),
+		ReleaseDate: r.ReleaseDate,
+		State:       state,
+		Supersedes:  r.Supersedes,
+	}, nil
+}
+
+// codeUpdate returns the code to create or replace
+func codeUpdate(r *models.CodeRequest, codeID string) datastore.CodeUpdate {
+	return datastore.CodeUpdate{
+		Code:   codeID,
+		Label:  strings.TrimSpace(r.Label),
+		Parent: strings.TrimSpace(r.Parent),
+	}
+}
+
+// newCodeUpdates returns the codes of a file, to be created in a new edition
+func newCodeUpdates(codes []csvimport.Code) []datastore.CodeUpdate {
+	updates := make([]datastore.CodeUpdate, 0, len(codes))
+	for _, code := range codes {
+		updates = append(updates, datastore.CodeUpdate{Code: code.Code, Label: code.Label, Parent: code.Parent})
+	}
+	return updates
+}
+
+// newImportReport creates an ImportReport struct from the codes and problems found in a file, listing no more
+// than maxProblems problems
+func newImportReport(codes []csvimport.Code, problems []csvimport.Problem, maxProblems int) *models.ImportReport {
+	report := &models.ImportReport{
+		Valid:        len(problems) == 0,
+		CodeCount:    len(codes),
+		ProblemCount: len(problems),
+		Problems:     []models.ImportProblem{},
+	}
+	for i, problem := range problems {
+		if i == maxProblems {
+			break
+		}
+		report.Problems = append(report.Problems, models.ImportProblem{Line: problem.Line, Code: problem.Code, Message: problem.Message})
+	}
+	return report
+}
+
+// newCodeEditions creates a CodeEditions struct from the code editions found in a datastore
+func newCodeEditions(codeEditions []datastore.CodeEdition) *models.CodeEditions {
+	if codeEditions == nil {
+		return &models.CodeEditions{}
+	}
+	items := []models.CodeEdition{}
+	for _, codeEdition := range codeEditions {
+		items = append(items, models.CodeEdition{
+			ID:         codeEdition.Code.Code,
+			Label:      codeEdition.Code.Label,
+			CodeListID: codeEdition.CodeListID,
+			Edition:    codeEdition.EditionID,
+		})
+	}
+	return &models.CodeEditions{
+		Items: items,
+	}
+}
+
+// newCodeChanges creates a CodeChanges struct from the changes between two editions
+func newCodeChanges(changes []diff.Change) *models.CodeChanges {
+	results := &models.CodeChanges{Items: []models.CodeChange{}}
+	for _, change := range changes {
+		results.Items = append(results.Items, models.CodeChange{
+			Type:          string(change.Type),
+			ID:            change.Code,
+			Label:         change.Label,
+			PreviousLabel: change.PreviousLabel,
+		})
+	}
+	return results
+}
+
+// newMatch creates a Match struct from the candidates matching a label
+func newMatch(label string, candidates []match.Candidate) *models.Match {
+	items := []models.MatchCandidate{}
+	for _, candidate := range candidates {
+		items = append(items, models.MatchCandidate{
+			ID:    candidate.Code.Code,
+			Label: candidate.Code.Label,
+			Score: candidate.Score,
+		})
+	}
+	return &models.Match{
+		Label:      label,
+		Candidates: items,
+	}
+}
+
+// newHierarchyCode creates a HierarchyCode struct from a code of the tree of an edition
+func newHierarchyCode(treeCode datastore.TreeCode) *models.HierarchyCode {
+	return &models.HierarchyCode{
+		ID:     treeCode.Code.Code,
+		Label:  treeCode.Code.Label,
+		Parent: treeCode.Parent,
+		Depth:  treeCode.Depth,
+	}
+}
+
+// codeRef returns the datastore reference of a mapped code
+func codeRef(c models.MappedCode) datastore.CodeRef {
+	return datastore.CodeRef{CodeListID: c.CodeListID, EditionID: c.EditionID, Code: c.ID}
+}
+
+// newMappedCode creates a MappedCode struct from the datastore reference of a code
+func newMappedCode(ref datastore.CodeRef) models.MappedCode {
+	return models.MappedCode{
+		CodeListID: ref.CodeListID,
+		EditionID:  ref.EditionID,
+		ID:         ref.Code,
+	}
+}
+
+// newMappings creates a Mappings struct from the mappings of the datastore
+func newMappings(mappings []datastore.Mapping) *models.Mappings {
+	results := &models.Mappings{Items: []models.Mapping{}}
+	for _, mapping := range mappings {
+		results.Items = append(results.Items, models.Mapping{
+			Type:   string(mapping.Type),
+			Source: newMappedCode(mapping.Source),
+			Target: newMappedCode(mapping.Target),
+		})
+	}
+	return results
+}
+
+// newResolution creates a Resolution struct from a code and the codes it resolves to
+func newResolution(source datastore.CodeRef, codes []datastore.CodeRef) *models.Resolution {
+	resolution := &models.Resolution{
+		Source: newMappedCode(source),
+		Codes:  []models.MappedCode{},
+	}
+	for _, code := range codes {
+		resolution.Codes = append(resolution.Codes, newMappedCode(code))
+	}
+	return resolution
+}
+
+// newCacheStats creates a CacheStats struct from the stats of a datastore cache
+func newCacheStats(stats datastore.CacheStats) *models.CacheStats {
+	return &models.CacheStats{
+		Entries:   stats.Entries,
+		Hits:      stats.Hits,
+		Misses:    stats.Misses,
+		Evictions: stats.Evictions,
+	}
+}
+
+// newCoalesceStats creates a CoalesceStats struct from the stats of a coalescing datastore
+func newCoalesceStats(stats datastore.CoalesceStats) *models.CoalesceStats {
+	return &models.CoalesceStats{
+		Calls:     stats.Calls,
+		Coalesced: stats.Coalesced,
+	}
+}
+
+// newSDMXCodelist returns an edition as an SDMX codelist maintained by an agency, identified by
+// <code list ID>_<edition ID>, with the codes of the edition and the ID of their parent, for code lists that are
+// hierarchies. Only published editions are final, as the others may still change or are no longer in use.
+func newSDMXCodelist(e *models.Edition, codeListID, agencyID, version string, codes []dbmodels.Code, parents map[string]string) *sdmx.Codelist {
+	codelist := &sdmx.Codelist{
+		ID:        codeListID + "_" + e.ID,
+		AgencyID:  agencyID,
+		Version:   version,
+		Name:      e.Label,
+		Edition:   e.ID,
+		IsFinal:   datastore.Published(datastore.EditionState(e.State), e.ReleaseDate),
+		ValidFrom: e.ReleaseDate,
+		Codes:     make([]sdmx.Code, 0, len(codes)),
+	}
+	if codelist.Name == "" {
+		codelist.Name = e.ID
+	}
+	if e.Links != nil && e.Links.Self != nil {
+		codelist.URI = e.Links.Self.Href
+	}
+	for _, code := range codes {
+		codelist.Codes = append(codelist.Codes, sdmx.Code{ID: code.Code, Name: code.Label, Parent: parents[code.Code]})
+	}
+	return codelist
+}
+
+// newWebhookSubscription returns the subscription to add, or an error if its URL is on a forbidden address or
+// an event type is unknown
+func newWebhookSubscription(r *models.SubscriptionRequest) (webhooks.Subscription, error) {
+	if err := webhooks.CheckURL(r.URL); err != nil {
+		return webhooks.Subscription{}, err
+	}
+
+	var eventTypes []events.Type
+	for _, t := range r.EventTypes {
+		eventType := events.Type(t)
+		if !eventType.Valid() {
+			return webhooks.Subscription{}, errors.Errorf("invalid event type %q, it must be code-list-created, edition-published or codes-changed", t)
+		}
+		eventTypes = append(eventTypes, eventType)
+	}
+
+	return webhooks.Subscription{
+		URL:           r.URL,
+		CodeLists:     r.CodeLists,
+		CodeListTypes: r.CodeListTypes,
+		EventTypes:    eventTypes,
+		Secret:        r.Secret,
+	}, nil
+}
+
+// newSubscription returns the subscription model of a subscription, without its secret
+func newSubscription(subscription webhooks.Subscription) *models.Subscription {
+	var eventTypes []string
+	for _, t := range subscription.EventTypes {
+		eventTypes = append(eventTypes, string(t))
+	}
+
+	return &models.Subscription{
+		ID:            subscription.ID,
+		URL:           subscription.URL,
+		CodeLists:     subscription.CodeLists,
+		CodeListTypes: subscription.CodeListTypes,
+		EventTypes:    eventTypes,
+		Created:       subscription.Created,
+	}
+}
+
+// newSubscriptionFailures creates a SubscriptionFailures struct from the dead-letter list of a subscription
+func newSubscriptionFailures(failures []webhooks.Failure) *models.SubscriptionFailures {
+	results := &models.SubscriptionFailures{Items: []models.SubscriptionFailure{}}
+	for _, failure := range failures {
+		results.Items = append(results.Items, models.SubscriptionFailure{
+			Event: models.ChangeEvent{
+				Type:       string(failure.Event.Type),
+				CodeListID: failure.Event.CodeListID,
+				EditionID:  failure.Event.EditionID,
+				Codes:      failure.Event.Codes,
+				Time:       failure.Event.Time,
+			},
+			Attempts: failure.Attempts,
+			Error:    failure.Error,
+			Time:     failure.Time,
+		})
+	}
+	results.Count = len(results.Items)
+	return results
+}
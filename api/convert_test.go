@@ -0,0 +1,225 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/csvimport"
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/events"
+	"github.com/ONSdigital/dp-code-list-api/match"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/dp-code-list-api/sdmx"
+	"github.com/ONSdigital/dp-code-list-api/webhooks"
+	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUpdateCodeListMetadata(t *testing.T) {
+	Convey("Given a CodeList struct and the metadata of the code list", t, func() {
+		codeList := &models.CodeList{ID: "codelistID"}
+		metadata := &datastore.CodeListMetadata{
+			ID:          "codelistID",
+			Label:       "Local authority",
+			Description: "Local authorities of the UK",
+			Type:        "geography",
+			Licence:     "Open Government Licence v3.0",
+			Contact:     &datastore.Contact{Name: "Geography", Email: "geography@ons.gov.uk", Telephone: "01329 444971"},
+		}
+
+		Convey("updateCodeListMetadata sets the metadata of the code list", func() {
+			updateCodeListMetadata(codeList, metadata)
+			So(codeList, ShouldResemble, &models.CodeList{
+				ID:          "codelistID",
+				Label:       "Local authority",
+				Description: "Local authorities of the UK",
+				Type:        "geography",
+				Licence:     "Open Government Licence v3.0",
+				Contact:     &models.Contact{Name: "Geography", Email: "geography@ons.gov.uk", Telephone: "01329 444971"},
+			})
+		})
+	})
+}
+
+func TestUpdateEditionMetadata(t *testing.T) {
+	Convey("Given an Edition struct and the metadata of a superseded edition", t, func() {
+		releaseDate := time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC)
+		edition := &models.Edition{ID: "2019"}
+		metadata := datastore.EditionMetadata{ID: "2019", ReleaseDate: &releaseDate, State: datastore.EditionRetired, SupersededBy: "2020"}
+
+		Convey("updateEditionMetadata sets the release date, state and superseded by link", func() {
+			updateEditionMetadata(edition, "codelistID", "testURL", metadata)
+			So(edition, ShouldResemble, &models.Edition{
+				ID:          "2019",
+				ReleaseDate: &releaseDate,
+				State:       "retired",
+				Links: &models.EditionLinks{
+					SupersededBy: &models.Link{ID: "2020", Href: "testURL/code-lists/codelistID/editions/2020"},
+				},
+			})
+		})
+	})
+}
+
+func TestRequestUpdates(t *testing.T) {
+	Convey("The metadata of a code list request is trimmed", t, func() {
+		request := &models.CodeListRequest{ID: "local-authority", Label: " Local authority ", Contact: &models.Contact{Name: "Geography"}}
+		So(codeListMetadata(request), ShouldResemble, datastore.CodeListMetadata{
+			ID:      "local-authority",
+			Label:   "Local authority",
+			Contact: &datastore.Contact{Name: "Geography"},
+		})
+	})
+
+	Convey("An edition request with a known state is the edition to put", t, func() {
+		request := &models.EditionRequest{Label: "2019 ", State: "published", Supersedes: "2018"}
+		update, err := editionUpdate(request, "2019")
+		So(err, ShouldBeNil)
+		So(update, ShouldResemble, datastore.EditionUpdate{ID: "2019", Label: "2019", State: datastore.EditionPublished, Supersedes: "2018"})
+	})
+
+	Convey("An edition request with an unknown state is invalid", t, func() {
+		request := &models.EditionRequest{Label: "2019", State: "archived"}
+		_, err := editionUpdate(request, "2019")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("A code request is the code to put", t, func() {
+		request := &models.CodeRequest{Label: " Hartlepool", Parent: "E92000001"}
+		So(codeUpdate(request, "E06000001"), ShouldResemble, datastore.CodeUpdate{Code: "E06000001", Label: "Hartlepool", Parent: "E92000001"})
+	})
+}
+
+func TestNewImportReport(t *testing.T) {
+	Convey("A file without problems is valid", t, func() {
+		report := newImportReport([]csvimport.Code{{Code: "E06000001", Label: "Hartlepool", Line: 2}}, nil, 10)
+		So(report.Valid, ShouldBeTrue)
+		So(report.CodeCount, ShouldEqual, 1)
+		So(report.Problems, ShouldBeEmpty)
+	})
+
+	Convey("The listed problems are limited, but all of them are counted", t, func() {
+		problems := []csvimport.Problem{
+			{Line: 2, Message: "empty code"},
+			{Line: 3, Code: "E06000002", Message: "empty label"},
+		}
+		report := newImportReport(nil, problems, 1)
+		So(report.Valid, ShouldBeFalse)
+		So(report.ProblemCount, ShouldEqual, 2)
+		So(report.Problems, ShouldResemble, []models.ImportProblem{{Line: 2, Message: "empty code"}})
+	})
+}
+
+func TestNewCodeEditions(t *testing.T) {
+	Convey("newCodeEditions called with a nil argument results in an empty API CodeEditions model", t, func() {
+		So(newCodeEditions(nil), ShouldResemble, &models.CodeEditions{})
+	})
+
+	Convey("newCodeEditions returns the corresponding API CodeEditions model", t, func() {
+		codeEditions := newCodeEditions([]datastore.CodeEdition{
+			{CodeListID: "testCodeList", EditionID: "testEdition", Code: dbmodels.Code{ID: "testID", Code: "testCode", Label: "testLabel"}},
+		})
+		So(codeEditions, ShouldResemble, &models.CodeEditions{
+			Items: []models.CodeEdition{
+				{ID: "testCode", Label: "testLabel", CodeListID: "testCodeList", Edition: "testEdition"},
+			},
+		})
+	})
+}
+
+func TestNewMatch(t *testing.T) {
+	Convey("newMatch returns the candidates of a label, in the order they are found", t, func() {
+		m := newMatch("Hartlepool", []match.Candidate{{Code: dbmodels.Code{Code: "E06000001", Label: "Hartlepool"}, Score: 1}})
+		So(m, ShouldResemble, &models.Match{
+			Label:      "Hartlepool",
+			Candidates: []models.MatchCandidate{{ID: "E06000001", Label: "Hartlepool", Score: 1}},
+		})
+	})
+
+	Convey("A label without candidates has an empty list of candidates", t, func() {
+		So(newMatch("Atlantis", nil).Candidates, ShouldBeEmpty)
+	})
+}
+
+func TestNewSDMXCodelist(t *testing.T) {
+	Convey("A published edition is a final codelist of its own, with its codes and their parent", t, func() {
+		releaseDate := time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)
+		edition := &models.Edition{ID: "2020", Label: "Local authorities, 2020"}
+		So(edition.UpdateLinks("local-authority", "http://localhost:22400"), ShouldBeNil)
+		updateEditionMetadata(edition, "local-authority", "http://localhost:22400", datastore.EditionMetadata{ReleaseDate: &releaseDate, State: datastore.EditionPublished})
+
+		codes := []dbmodels.Code{{Code: "E12000001", Label: "North East"}, {Code: "E06000001", Label: "Hartlepool"}}
+		codelist := newSDMXCodelist(edition, "local-authority", "ONS", "1.0", codes, map[string]string{"E06000001": "E12000001"})
+		So(codelist, ShouldResemble, &sdmx.Codelist{
+			ID:        "local-authority_2020",
+			AgencyID:  "ONS",
+			Version:   "1.0",
+			Name:      "Local authorities, 2020",
+			Edition:   "2020",
+			URI:       "http://localhost:22400/code-lists/local-authority/editions/2020",
+			IsFinal:   true,
+			ValidFrom: &releaseDate,
+			Codes: []sdmx.Code{
+				{ID: "E12000001", Name: "North East"},
+				{ID: "E06000001", Name: "Hartlepool", Parent: "E12000001"},
+			},
+		})
+	})
+
+	Convey("A draft edition without a label is not final, and is named after its ID", t, func() {
+		edition := &models.Edition{ID: "2021", State: string(datastore.EditionDraft)}
+		codelist := newSDMXCodelist(edition, "local-authority", "ONS", "1.0", nil, nil)
+		So(codelist.IsFinal, ShouldBeFalse)
+		So(codelist.Name, ShouldEqual, "2021")
+		So(codelist.URI, ShouldBeEmpty)
+		So(codelist.Codes, ShouldBeEmpty)
+	})
+
+	Convey("Each edition is a codelist identified by the code list and edition, so that their URNs differ", t, func() {
+		codelist2020 := newSDMXCodelist(&models.Edition{ID: "2020"}, "local-authority", "ONS", "1.0", nil, nil)
+		codelist2021 := newSDMXCodelist(&models.Edition{ID: "2021"}, "local-authority", "ONS", "1.0", nil, nil)
+		So(codelist2020.URN(), ShouldEqual, "urn:sdmx:org.sdmx.infomodel.codelist.Codelist=ONS:local-authority_2020(1.0)")
+		So(codelist2021.URN(), ShouldNotEqual, codelist2020.URN())
+	})
+
+	Convey("Retired editions and editions without a state or release date are not final", t, func() {
+		So(newSDMXCodelist(&models.Edition{ID: "2019", State: string(datastore.EditionRetired)}, "local-authority", "ONS", "1.0", nil, nil).IsFinal, ShouldBeFalse)
+		So(newSDMXCodelist(&models.Edition{ID: "2019"}, "local-authority", "ONS", "1.0", nil, nil).IsFinal, ShouldBeFalse)
+	})
+
+	Convey("Editions without a state are final if they have a release date, as for the latest edition", t, func() {
+		releaseDate := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+		So(newSDMXCodelist(&models.Edition{ID: "2019", ReleaseDate: &releaseDate}, "local-authority", "ONS", "1.0", nil, nil).IsFinal, ShouldBeTrue)
+	})
+}
+
+func TestSubscriptionConversions(t *testing.T) {
+	Convey("A subscription request with known event types is the subscription to add", t, func() {
+		request := &models.SubscriptionRequest{URL: "https://example.com/callback", CodeLists: []string{"local-authority"}, CodeListTypes: []string{"geography"}, EventTypes: []string{"codes-changed"}}
+		subscription, err := newWebhookSubscription(request)
+		So(err, ShouldBeNil)
+		So(subscription, ShouldResemble, webhooks.Subscription{
+			URL:           "https://example.com/callback",
+			CodeLists:     []string{"local-authority"},
+			CodeListTypes: []string{"geography"},
+			EventTypes:    []events.Type{events.CodesChanged},
+		})
+	})
+
+	Convey("A subscription request with a callback on a private address is invalid", t, func() {
+		request := &models.SubscriptionRequest{URL: "http://169.254.169.254/latest/meta-data"}
+		_, err := newWebhookSubscription(request)
+		So(err, ShouldEqual, webhooks.ErrForbiddenAddress)
+	})
+
+	Convey("A subscription request with an unknown event type is invalid", t, func() {
+		request := &models.SubscriptionRequest{URL: "https://example.com/callback", EventTypes: []string{"code-list-deleted"}}
+		_, err := newWebhookSubscription(request)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("A subscription is returned without its secret", t, func() {
+		subscription := newSubscription(webhooks.Subscription{ID: "123", URL: "https://example.com/callback", EventTypes: []events.Type{events.CodesChanged}, Secret: "secret"})
+		So(subscription, ShouldResemble, &models.Subscription{ID: "123", URL: "https://example.com/callback", EventTypes: []string{"codes-changed"}})
+	})
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/diff"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// getEditionDiff returns the codes added, removed or relabelled from the edition provided by the from query
+// parameter to the requested edition. The changes are paginated, unless they are requested as CSV (with
+// ?format=csv or an Accept: text/csv header), in which case every change is returned.
+func (c *CodeListAPI) getEditionDiff(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	edition := vars["edition"]
+	from := r.URL.Query().Get("from")
+	data := log.Data{"codelist_id": id, "edition": edition, "from": from}
+
+	log.Event(ctx, "getEditionDiff endpoint: attempting to compare editions", log.INFO, data)
+
+	if from == "" {
+		err := errors.New("missing query parameter: from")
+		log.Event(ctx, "getEditionDiff endpoint: missing query parameter: from", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	offset, limit, ok := c.getPage(ctx, w, r, data)
+	if !ok {
+		return
+	}
+
+	// stores may return an empty list of codes for an unknown edition, so both editions are checked first
+	for _, editionID := range []string{from, edition} {
+		if _, err := c.store.GetEdition(ctx, id, editionID); err != nil {
+			handleError(ctx, "getEditionDiff endpoint: failed to get edition from store", data, err, w)
+			return
+		}
+	}
+
+	previous, err := datastore.GetAllCodes(ctx, c.store, id, from)
+	if err != nil {
+		handleError(ctx, "getEditionDiff endpoint: failed to get codes of previous edition from store", data, err, w)
+		return
+	}
+	codes, err := datastore.GetAllCodes(ctx, c.store, id, edition)
+	if err != nil {
+		handleError(ctx, "getEditionDiff endpoint: failed to get codes of edition from store", data, err, w)
+		return
+	}
+
+	changes := diff.Codes(previous, codes)
+	data["total_count"] = len(changes)
+
+	if wantsCSV(r) {
+		offset, limit = 0, len(changes)
+	}
+
+	start, end := datastore.Page{Offset: offset, Limit: limit}.Bounds(len(changes))
+	results := newCodeChanges(changes[start:end])
+
+	for i, item := range results.Items {
+		if err := item.UpdateLinks(c.apiURL, id, edition, from); err != nil {
+			log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "getEditionDiff endpoint: links could not be created")))
+			http.Error(w, internalServerErr, http.StatusInternalServerError)
+			return
+		}
+		results.Items[i] = item
+	}
+
+	results.Count = len(results.Items)
+	results.Offset = offset
+	results.Limit = limit
+	results.TotalCount = len(changes)
+
+	if err := c.writeBody(w, r, results); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "getEditionDiff endpoint: failed to write bytes to response")))
+		return
+	}
+
+	log.Event(ctx, "getEditionDiff endpoint: request successful", log.INFO, data)
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-code-list-api/diff"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// newDiffMock returns a store where the second edition relabels the first code of the first edition, removes
+// its second code and adds a third code
+func newDiffMock() *storetest.DataStoreMock {
+	codes := map[string][]dbmodels.Code{
+		editionID1: {dbCode1, dbCode2},
+		editionID2: {
+			{Code: codeID1, Label: "test one renamed"},
+			{Code: "testCode3", Label: "test three"},
+		},
+	}
+	return &storetest.DataStoreMock{
+		GetEditionFunc: func(ctx context.Context, codeListID string, editionID string) (*dbmodels.Edition, error) {
+			if _, ok := codes[editionID]; !ok {
+				return nil, driver.ErrNotFound
+			}
+			return &dbmodels.Edition{ID: editionID}, nil
+		},
+		CountCodesFunc: func(ctx context.Context, codeListID string, editionID string) (int64, error) {
+			return int64(len(codes[editionID])), nil
+		},
+		GetCodesFunc: func(ctx context.Context, codeListID string, editionID string) (*dbmodels.CodeResults, error) {
+			return &dbmodels.CodeResults{Items: codes[editionID]}, nil
+		},
+	}
+}
+
+func TestGetEditionDiff(t *testing.T) {
+	diffURL := fmt.Sprintf("%s/code-lists/%s/editions/%s/diff", codeListURL, codeListID1, editionID2)
+	codeURL := func(edition, code string) *models.Link {
+		return &models.Link{ID: code, Href: fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s", codeListURL, codeListID1, edition, code)}
+	}
+
+	Convey("Given two editions of a code list", t, func() {
+		api := CreateCodeListAPI(mux.NewRouter(), newDiffMock(), codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When the changes from the first edition are requested, then the changed codes are returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", diffURL+"?from="+editionID1, nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.CodeChanges{}, &models.CodeChanges{
+				Items: []models.CodeChange{
+					{
+						Type:          string(diff.Relabelled),
+						ID:            codeID1,
+						Label:         "test one renamed",
+						PreviousLabel: "test one",
+						Links:         &models.CodeChangeLinks{Code: codeURL(editionID2, codeID1), PreviousCode: codeURL(editionID1, codeID1)},
+					},
+					{
+						Type:  string(diff.Removed),
+						ID:    codeID2,
+						Label: "test two",
+						Links: &models.CodeChangeLinks{PreviousCode: codeURL(editionID1, codeID2)},
+					},
+					{
+						Type:  string(diff.Added),
+						ID:    "testCode3",
+						Label: "test three",
+						Links: &models.CodeChangeLinks{Code: codeURL(editionID2, "testCode3")},
+					},
+				},
+				Count:      3,
+				Limit:      defaultLimit,
+				TotalCount: 3,
+			})
+		})
+
+		Convey("When a page of the changes is requested, then only the changes of the page are returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", diffURL+"?from="+editionID1+"&offset=1&limit=1", nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.CodeChanges{}, &models.CodeChanges{
+				Items: []models.CodeChange{
+					{
+						Type:  string(diff.Removed),
+						ID:    codeID2,
+						Label: "test two",
+						Links: &models.CodeChangeLinks{PreviousCode: codeURL(editionID1, codeID2)},
+					},
+				},
+				Count:      1,
+				Offset:     1,
+				Limit:      1,
+				TotalCount: 3,
+			})
+		})
+
+		Convey("When the changes are requested as CSV, then every change is returned as a row", func() {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", diffURL+"?from="+editionID1+"&limit=1", nil)
+			r.Header.Set("Accept", "text/csv")
+			api.router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(contentTypeHeader), ShouldStartWith, contentTypeCSV)
+			So(w.Body.String(), ShouldEqual, "change,code,label,previous_label\n"+
+				"relabelled,testCode1,test one renamed,test one\n"+
+				"removed,testCode2,test two,\n"+
+				"added,testCode3,test three,\n")
+		})
+
+		Convey("When the changes are requested with ?format=csv, then they are returned as CSV", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", diffURL+"?from="+editionID1+"&format=csv", nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(contentTypeHeader), ShouldStartWith, contentTypeCSV)
+		})
+
+		Convey("When the previous edition is not provided, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", diffURL, nil))
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("When the previous edition does not exist, then 404 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", diffURL+"?from=unknown", nil))
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
@@ -1,73 +1,75 @@
 package api
 
 import (
-	"encoding/json"
+	"context"
+	"math"
 	"net/http"
-	"sort"
 
+	"github.com/ONSdigital/dp-code-list-api/datastore"
 	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/dp-code-list-api/sdmx"
 	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
 	"github.com/ONSdigital/log.go/log"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 )
 
+// Possible values of the order query parameter of getEditions
+const (
+	orderByID              = "id"
+	orderByReleaseDate     = "release_date"
+	orderByReleaseDateDesc = "-release_date"
+)
+
 func (c *CodeListAPI) getEditions(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
 	id := vars["id"]
 	logData := log.Data{"codelist_id": id}
-	offsetParameter := r.URL.Query().Get("offset")
-	limitParameter := r.URL.Query().Get("limit")
-	offset := c.defaultOffset
-	limit := c.defaultLimit
-	var err error
 
 	log.Event(ctx, "getEditions endpoint: attempting to find editions", log.INFO, logData)
 
-	if offsetParameter != "" {
-		logData["offset"] = offsetParameter
-		offset, err = ValidatePositiveInt(offsetParameter)
-		if err != nil {
-			log.Event(ctx, "invalid query parameter: offset", log.ERROR, log.Error(err), logData)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
+	offset, limit, ok := c.getPage(ctx, w, r, logData)
+	if !ok {
+		return
 	}
 
-	if limitParameter != "" {
-		logData["limit"] = limitParameter
-		limit, err = ValidatePositiveInt(limitParameter)
-		if err != nil {
-			log.Event(ctx, "invalid query parameter: limit", log.ERROR, log.Error(err), logData)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
+	// every item is written as CSV or linked data, so that every edition can be downloaded at once
+	if wantsEveryItem(r, &models.Editions{}) {
+		offset, limit = 0, math.MaxInt32
 	}
 
-	if limit > c.maxLimit {
-		logData["max_limit"] = c.maxLimit
-		err = errors.New("limit is greater than the maximum allowed")
-		log.Event(ctx, "invalid query parameter: limit, maximum limit reached", log.ERROR, log.Error(err), logData)
+	order := r.URL.Query().Get("order")
+	if order != "" {
+		logData["order"] = order
+	}
+	if order != "" && order != orderByID && order != orderByReleaseDate && order != orderByReleaseDateDesc {
+		err := errors.Errorf("order must be one of %s, %s or %s", orderByID, orderByReleaseDate, orderByReleaseDateDesc)
+		log.Event(ctx, "invalid query parameter: order", log.ERROR, log.Error(err), logData)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	dbEditions, err := c.store.GetEditions(r.Context(), id)
+	metadata, err := datastore.GetEditionsMetadata(ctx, c.store, id)
 	if err != nil {
-		handleError(ctx, "failed to get editions", logData, err, w)
+		handleError(ctx, "failed to get editions metadata", logData, err, w)
 		return
 	}
 
-	totalCount := len(dbEditions.Items)
-
-	sort.Slice(dbEditions.Items, func(i, j int) bool {
-		return dbEditions.Items[i].ID < dbEditions.Items[j].ID
-	})
-
-	slicedResults := editionsSlice(dbEditions.Items, offset, limit)
+	var dbEditions *dbmodels.Editions
+	var totalCount int
+	if order == orderByReleaseDate || order == orderByReleaseDateDesc {
+		dbEditions, totalCount, err = getEditionsPageByReleaseDate(ctx, c.store, id, metadata, datastore.Page{Offset: offset, Limit: limit}, order == orderByReleaseDateDesc)
+	} else {
+		page := datastore.Page{Offset: offset, Limit: limit, Order: datastore.OrderByID}
+		dbEditions, totalCount, err = datastore.GetEditionsPage(ctx, c.store, id, page)
+	}
+	if err != nil {
+		handleError(ctx, "failed to get editions", logData, err, w)
+		return
+	}
 
-	editions := models.NewEditions(slicedResults)
+	editions := models.NewEditions(dbEditions.Items)
 
 	for i, item := range editions.Items {
 		if err := item.UpdateLinks(id, c.apiURL); err != nil {
@@ -75,22 +77,19 @@ func (c *CodeListAPI) getEditions(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, internalServerErr, http.StatusInternalServerError)
 			return
 		}
+		// the codes of listed editions are not counted one edition at a time, only stores holding the metadata
+		// of editions return their code count
+		updateEditionMetadata(&item, id, c.apiURL, metadata[item.ID])
 		editions.Items[i] = item
 	}
 
-	count := len(slicedResults)
+	count := len(dbEditions.Items)
 	editions.Count = count
 	editions.Offset = offset
 	editions.Limit = limit
 	editions.TotalCount = totalCount
 
-	b, err := json.Marshal(editions)
-	if err != nil {
-		handleError(ctx, "failed to marshal editions", logData, err, w)
-		return
-	}
-
-	if err := c.writeBody(w, b); err != nil {
+	if err := c.writeBody(w, r, editions); err != nil {
 		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "getEditions endpoint: failed to write bytes to response")), logData)
 		return
 	}
@@ -119,27 +118,53 @@ func (c *CodeListAPI) getEdition(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	b, err := json.Marshal(editionModel)
+	metadata, err := datastore.GetEditionsMetadata(ctx, c.store, id)
 	if err != nil {
-		handleError(ctx, "failed to marshal editions", data, err, w)
+		handleError(ctx, "failed to get editions metadata", data, err, w)
 		return
 	}
+	updateEditionMetadata(editionModel, id, c.apiURL, metadata[editionModel.ID])
 
-	if err := c.writeBody(w, b); err != nil {
+	codeCount, err := c.store.CountCodes(ctx, id, edition)
+	if err != nil {
+		handleError(ctx, "failed to count edition codes", data, err, w)
+		return
+	}
+	editionModel.CodeCount = int(codeCount)
+
+	// the codes of an edition requested as SDMX are part of its codelist
+	var body interface{} = editionModel
+	if c.wantsSDMX(r, editionModel) {
+		body, err = c.sdmxEdition(ctx, editionModel, id)
+		if _, ok := err.(*sdmx.InvalidIDError); ok {
+			log.Event(ctx, "edition cannot be written as SDMX", log.WARN, log.Error(err), data)
+			w.Header().Add("Vary", "Accept")
+			http.Error(w, err.Error(), http.StatusNotAcceptable)
+			return
+		}
+		if err != nil {
+			handleError(ctx, "failed to get edition codes", data, err, w)
+			return
+		}
+	}
+
+	if err := c.writeBody(w, r, body); err != nil {
 		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "getEdition endpoint: failed to write bytes to response")), data)
 		return
 	}
 	log.Event(r.Context(), "retrieved codelist edition", log.INFO, log.Data{"code_list_id": id, edition: edition})
 }
 
-func editionsSlice(full []dbmodels.Edition, offset, limit int) (sliced []dbmodels.Edition) {
-	end := offset + limit
-	if end > len(full) {
-		end = len(full)
+// getEditionsPageByReleaseDate returns a page of the editions of a code list sorted by release date, and the
+// total number of editions. Every edition is fetched, as stores can only sort editions by ID or label.
+func getEditionsPageByReleaseDate(ctx context.Context, store datastore.DataStore, codeListID string, metadata map[string]datastore.EditionMetadata, page datastore.Page, newestFirst bool) (*dbmodels.Editions, int, error) {
+	dbEditions, err := store.GetEditions(ctx, codeListID)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	if offset > len(full) || limit == 0 {
-		return []dbmodels.Edition{}
-	}
-	return full[offset:end]
+	items := datastore.CopyEditions(dbEditions.Items)
+	datastore.SortEditionsByReleaseDate(items, metadata, newestFirst)
+	start, end := page.Bounds(len(items))
+	return &dbmodels.Editions{Items: items[start:end]}, len(items), nil
 }
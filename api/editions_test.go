@@ -2,11 +2,14 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/ONSdigital/dp-code-list-api/datastore"
 	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
 	"github.com/ONSdigital/dp-code-list-api/models"
 
@@ -36,8 +39,9 @@ var (
 	}
 
 	expectedEdition1 = models.Edition{
-		ID:    editionID1,
-		Label: "label1",
+		ID:        editionID1,
+		Label:     "label1",
+		CodeCount: 2,
 		Links: &models.EditionLinks{
 			Self:     &models.Link{ID: editionID1, Href: fmt.Sprintf("%s/code-lists/%s/editions/%s", codeListURL, codeListID1, editionID1)},
 			Editions: &models.Link{ID: "", Href: fmt.Sprintf("%s/code-lists/%s/editions", codeListURL, codeListID1)},
@@ -46,8 +50,9 @@ var (
 	}
 
 	expectedEdition2 = models.Edition{
-		ID:    editionID2,
-		Label: "label2",
+		ID:        editionID2,
+		Label:     "label2",
+		CodeCount: 2,
 		Links: &models.EditionLinks{
 			Self:     &models.Link{ID: editionID2, Href: fmt.Sprintf("%s/code-lists/%s/editions/%s", codeListURL, codeListID1, editionID2)},
 			Editions: &models.Link{ID: "", Href: fmt.Sprintf("%s/code-lists/%s/editions", codeListURL, codeListID1)},
@@ -55,8 +60,12 @@ var (
 		},
 	}
 
+	// the editions listed by stores without edition metadata have no code count, as it is not counted per edition
+	listedEdition1 = withoutCodeCount(expectedEdition1)
+	listedEdition2 = withoutCodeCount(expectedEdition2)
+
 	expectedEditions = models.Editions{
-		Items:      []models.Edition{expectedEdition1, expectedEdition2},
+		Items:      []models.Edition{listedEdition1, listedEdition2},
 		Count:      2,
 		Offset:     0,
 		Limit:      20,
@@ -64,7 +73,7 @@ var (
 	}
 
 	editionsPaginationTestOne = models.Editions{
-		Items:      []models.Edition{expectedEdition1},
+		Items:      []models.Edition{listedEdition1},
 		Count:      1,
 		Offset:     0,
 		Limit:      1,
@@ -72,7 +81,7 @@ var (
 	}
 
 	editionsPaginationTestTwo = models.Editions{
-		Items:      []models.Edition{expectedEdition2},
+		Items:      []models.Edition{listedEdition2},
 		Count:      1,
 		Offset:     1,
 		Limit:      7,
@@ -88,7 +97,7 @@ var (
 	}
 
 	editionsPaginationTestFour = models.Editions{
-		Items:      []models.Edition{expectedEdition1},
+		Items:      []models.Edition{listedEdition1},
 		Count:      1,
 		Offset:     0,
 		Limit:      20,
@@ -96,6 +105,48 @@ var (
 	}
 )
 
+// withoutCodeCount returns a copy of an edition without its code count
+func withoutCodeCount(edition models.Edition) models.Edition {
+	edition.CodeCount = 0
+	return edition
+}
+
+// countEditionCodes is the CountCodes function of the mocked stores, for which every edition has two codes
+func countEditionCodes(ctx context.Context, codeListID string, editionID string) (int64, error) {
+	return 2, nil
+}
+
+var (
+	releaseDate1 = time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	releaseDate2 = time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	expectedEditionWithMetadata1 = models.Edition{
+		ID:          editionID1,
+		Label:       "label1",
+		ReleaseDate: &releaseDate1,
+		State:       "published",
+		CodeCount:   2,
+		Links: &models.EditionLinks{
+			Self:       expectedEdition1.Links.Self,
+			Editions:   expectedEdition1.Links.Editions,
+			Codes:      expectedEdition1.Links.Codes,
+			Supersedes: &models.Link{ID: editionID2, Href: fmt.Sprintf("%s/code-lists/%s/editions/%s", codeListURL, codeListID1, editionID2)},
+		},
+	}
+)
+
+// newEditionMetadataGetterMock returns the metadata of two editions, where the first supersedes the second
+func newEditionMetadataGetterMock() *storetest.EditionMetadataGetterMock {
+	return &storetest.EditionMetadataGetterMock{
+		GetEditionsMetadataFunc: func(ctx context.Context, codeListID string) ([]datastore.EditionMetadata, error) {
+			return []datastore.EditionMetadata{
+				{ID: editionID1, ReleaseDate: &releaseDate1, State: datastore.EditionPublished, Supersedes: editionID2, CodeCount: 2},
+				{ID: editionID2, ReleaseDate: &releaseDate2, State: datastore.EditionRetired, SupersededBy: editionID1, CodeCount: 2},
+			}, nil
+		},
+	}
+}
+
 func TestGetEditions(t *testing.T) {
 
 	Convey("Get code list editions returns a status of http ok", t, func() {
@@ -113,6 +164,7 @@ func TestGetEditions(t *testing.T) {
 		So(w.Code, ShouldEqual, http.StatusOK)
 
 		validateBody(w.Body, &models.Editions{}, &expectedEditions)
+		So(mockDatastore.CountCodesCalls(), ShouldBeEmpty)
 	})
 
 	Convey("Get code list editions returns a status of http not found if code list doesn't exist", t, func() {
@@ -120,6 +172,7 @@ func TestGetEditions(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: countEditionCodes,
 			GetEditionsFunc: func(ctx context.Context, f string) (*dbmodels.Editions, error) {
 				return &dbmodels.Editions{}, driver.ErrNotFound
 			},
@@ -135,6 +188,7 @@ func TestGetEditions(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: countEditionCodes,
 			GetEditionsFunc: func(ctx context.Context, f string) (*dbmodels.Editions, error) {
 				return &dbmodels.Editions{}, ErrInternal
 			},
@@ -150,6 +204,7 @@ func TestGetEditions(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: countEditionCodes,
 			GetEditionsFunc: func(ctx context.Context, f string) (*dbmodels.Editions, error) {
 				return &dbmodels.Editions{
 					Items: []dbmodels.Edition{{ID: ""}},
@@ -171,6 +226,7 @@ func TestGetEditions_Pagination(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: countEditionCodes,
 			GetEditionsFunc: func(ctx context.Context, f string) (*dbmodels.Editions, error) {
 				return &dbEditions, nil
 			},
@@ -188,6 +244,7 @@ func TestGetEditions_Pagination(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: countEditionCodes,
 			GetEditionsFunc: func(ctx context.Context, f string) (*dbmodels.Editions, error) {
 				return &dbEditions, nil
 			},
@@ -205,6 +262,7 @@ func TestGetEditions_Pagination(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: countEditionCodes,
 			GetEditionsFunc: func(ctx context.Context, f string) (*dbmodels.Editions, error) {
 				return &dbEditions, nil
 			},
@@ -252,6 +310,55 @@ func TestGetEditions_Pagination(t *testing.T) {
 		api.router.ServeHTTP(w, r)
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
 	})
+
+	Convey("When an unknown order is provided, then 400 status returned", t, func() {
+		r := httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions?order=label", codeListURL, codeListID1), nil)
+		w := httptest.NewRecorder()
+
+		api := CreateCodeListAPI(mux.NewRouter(), &storetest.DataStoreMock{}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+		api.router.ServeHTTP(w, r)
+		So(w.Code, ShouldEqual, http.StatusBadRequest)
+	})
+
+	Convey("Given a store holding the release dates of editions", t, func() {
+		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: countEditionCodes,
+			GetEditionsFunc: func(ctx context.Context, id string) (*dbmodels.Editions, error) {
+				return &dbEditions, nil
+			},
+		}
+		api := CreateCodeListAPI(mux.NewRouter(), struct {
+			*storetest.DataStoreMock
+			*storetest.EditionMetadataGetterMock
+		}{mockDatastore, newEditionMetadataGetterMock()}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When editions are ordered by release date, newest first, then the latest edition is returned first", func() {
+			r := httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions?order=-release_date&limit=1", codeListURL, codeListID1), nil)
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, r)
+			So(w.Code, ShouldEqual, http.StatusOK)
+
+			validateBody(w.Body, &models.Editions{}, &models.Editions{
+				Items:      []models.Edition{expectedEditionWithMetadata1},
+				Count:      1,
+				Limit:      1,
+				TotalCount: 2,
+			})
+		})
+
+		Convey("When editions are ordered by release date, then the oldest edition is returned first", func() {
+			r := httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions?order=release_date&limit=1", codeListURL, codeListID1), nil)
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, r)
+			So(w.Code, ShouldEqual, http.StatusOK)
+
+			editions := &models.Editions{}
+			So(json.NewDecoder(w.Body).Decode(editions), ShouldBeNil)
+			So(editions.Items, ShouldHaveLength, 1)
+			So(editions.Items[0].ID, ShouldEqual, editionID2)
+			So(editions.Items[0].State, ShouldEqual, "retired")
+		})
+	})
 }
 
 func TestGetEdition(t *testing.T) {
@@ -260,6 +367,7 @@ func TestGetEdition(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: countEditionCodes,
 			GetEditionFunc: func(ctx context.Context, f, e string) (*dbmodels.Edition, error) {
 				return &dbEdition1, nil
 			},
@@ -272,11 +380,33 @@ func TestGetEdition(t *testing.T) {
 		validateBody(w.Body, &models.Edition{}, &expectedEdition1)
 	})
 
+	Convey("Get code list edition returns its release date, state and supersession links", t, func() {
+		r := httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s", codeListURL, codeListID1, editionID1), nil)
+		w := httptest.NewRecorder()
+
+		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: countEditionCodes,
+			GetEditionFunc: func(ctx context.Context, f, e string) (*dbmodels.Edition, error) {
+				return &dbEdition1, nil
+			},
+		}
+
+		api := CreateCodeListAPI(mux.NewRouter(), struct {
+			*storetest.DataStoreMock
+			*storetest.EditionMetadataGetterMock
+		}{mockDatastore, newEditionMetadataGetterMock()}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+		api.router.ServeHTTP(w, r)
+		So(w.Code, ShouldEqual, http.StatusOK)
+
+		validateBody(w.Body, &models.Edition{}, &expectedEditionWithMetadata1)
+	})
+
 	Convey("Get code list edition returns a status of http not found if code list doesn't exist", t, func() {
 		r := httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/12345/editions/2016", codeListURL), nil)
 		w := httptest.NewRecorder()
 
 		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: countEditionCodes,
 			GetEditionFunc: func(ctx context.Context, f, e string) (*dbmodels.Edition, error) {
 				return &dbmodels.Edition{}, driver.ErrNotFound
 			},
@@ -292,6 +422,7 @@ func TestGetEdition(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: countEditionCodes,
 			GetEditionFunc: func(ctx context.Context, f, e string) (*dbmodels.Edition, error) {
 				return &dbmodels.Edition{}, ErrInternal
 			},
@@ -307,6 +438,7 @@ func TestGetEdition(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: countEditionCodes,
 			GetEditionFunc: func(ctx context.Context, f, e string) (*dbmodels.Edition, error) {
 				return &dbmodels.Edition{ID: ""}, nil
 			},
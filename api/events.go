@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/events"
+	"github.com/ONSdigital/log.go/log"
+)
+
+// publish publishes the event of a change made through the API, if a publisher is configured. The change was
+// already made, so a failure to publish its event is logged rather than returned to the caller.
+func (c *CodeListAPI) publish(ctx context.Context, event events.Event) {
+	if c.publisher == nil {
+		return
+	}
+
+	data := log.Data{"type": event.Type, "codelist_id": event.CodeListID, "edition": event.EditionID}
+	if err := c.publisher.Publish(ctx, event); err != nil {
+		log.Event(ctx, "failed to publish change event", log.ERROR, log.Error(err), data)
+		return
+	}
+	log.Event(ctx, "change event published", log.INFO, data)
+}
+
+// editionPublished reports whether an edition is published before it is changed, to find whether the change
+// publishes it. It is only looked up if a publisher is configured.
+func (c *CodeListAPI) editionPublished(ctx context.Context, codeListID, editionID string) bool {
+	if c.publisher == nil {
+		return false
+	}
+
+	metadata, err := datastore.GetEditionsMetadata(ctx, c.store, codeListID)
+	if err != nil {
+		log.Event(ctx, "failed to get the state of edition before changing it", log.WARN, log.Error(err), log.Data{"codelist_id": codeListID, "edition": editionID})
+		return false
+	}
+	return datastore.Published(metadata[editionID].State, metadata[editionID].ReleaseDate)
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/events"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestChangeEvents(t *testing.T) {
+	Convey("Given an API with private endpoints and a publisher", t, func() {
+		store, _ := newWriterStore()
+		publisher := events.NewMemory()
+		api := CreateCodeListAPI(mux.NewRouter(), store, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit, WithPrivateEndpoints(testIdentity), WithPublisher(publisher))
+		editionURL := fmt.Sprintf("%s/code-lists/%s/editions/%s", codeListURL, codeListID1, editionID2)
+
+		Convey("When a code list is created, then its event is published", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("POST", codeListURL+"/code-lists", `{"id":"new-list","label":"New list"}`))
+
+			So(w.Code, ShouldEqual, http.StatusCreated)
+			So(publisher.Events(), ShouldHaveLength, 1)
+			So(publisher.Events()[0].Type, ShouldEqual, events.CodeListCreated)
+			So(publisher.Events()[0].CodeListID, ShouldEqual, "new-list")
+		})
+
+		Convey("When a published edition is created, then its event is published", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("PUT", editionURL, `{"label":"Second edition","state":"published"}`))
+
+			So(w.Code, ShouldEqual, http.StatusCreated)
+			So(publisher.Events(), ShouldHaveLength, 1)
+			So(publisher.Events()[0].Type, ShouldEqual, events.EditionPublished)
+			So(publisher.Events()[0].EditionID, ShouldEqual, editionID2)
+		})
+
+		Convey("When an edition without a state is created with a release date, then it is published as well", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("PUT", editionURL, `{"label":"Second edition","release_date":"2021-05-01T00:00:00Z"}`))
+
+			So(w.Code, ShouldEqual, http.StatusCreated)
+			So(publisher.Events(), ShouldHaveLength, 1)
+			So(publisher.Events()[0].Type, ShouldEqual, events.EditionPublished)
+		})
+
+		Convey("When a draft edition is created, then no event is published", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("PUT", editionURL, `{"label":"Second edition","state":"draft"}`))
+
+			So(w.Code, ShouldEqual, http.StatusCreated)
+			So(publisher.Events(), ShouldBeEmpty)
+		})
+
+		Convey("When a code is changed, then its event is published", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("PUT", editionURL+"/codes/"+codeID2, `{"label":"Second code"}`))
+
+			So(w.Code, ShouldEqual, http.StatusCreated)
+			So(publisher.Events(), ShouldHaveLength, 1)
+			So(publisher.Events()[0].Type, ShouldEqual, events.CodesChanged)
+			So(publisher.Events()[0].Codes, ShouldResemble, []string{codeID2})
+		})
+
+		Convey("When a change fails, then no event is published", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("DELETE", editionURL+"/codes/"+codeID2, ""))
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+			So(publisher.Events(), ShouldBeEmpty)
+		})
+	})
+}
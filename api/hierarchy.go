@@ -0,0 +1,134 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+func (c *CodeListAPI) getParentCode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	edition := vars["edition"]
+	code := vars["code"]
+	data := log.Data{"codelist_id": id, "edition": edition, "code": code}
+
+	log.Event(ctx, "getParentCode endpoint: attempting to get parent of code", log.INFO, data)
+
+	dbCode, err := datastore.GetParentCode(ctx, c.store, id, edition, code)
+	if err != nil {
+		handleError(ctx, "getParentCode endpoint: failed to get parent code from store", data, err, w)
+		return
+	}
+	if dbCode == nil {
+		handleError(ctx, "getParentCode endpoint: code is at the top of the hierarchy", data, driver.ErrNotFound, w)
+		return
+	}
+	apiCode := models.NewCode(dbCode)
+
+	if err := apiCode.UpdateLinks(c.apiURL, id, edition); err != nil {
+		log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "getParentCode endpoint: links could not be created")))
+		http.Error(w, internalServerErr, http.StatusInternalServerError)
+		return
+	}
+
+	if err := c.writeBody(w, r, apiCode); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "getParentCode endpoint: failed to write bytes to response")))
+		return
+	}
+
+	log.Event(ctx, "getParentCode endpoint: request successful", log.INFO, data)
+}
+
+func (c *CodeListAPI) getChildCodes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	edition := vars["edition"]
+	code := vars["code"]
+	data := log.Data{"codelist_id": id, "edition": edition, "code": code}
+
+	log.Event(ctx, "getChildCodes endpoint: attempting to get children of code", log.INFO, data)
+
+	offset, limit, ok := c.getPage(ctx, w, r, data)
+	if !ok {
+		return
+	}
+
+	dbCodes, err := datastore.GetChildCodes(ctx, c.store, id, edition, code)
+	if err != nil {
+		handleError(ctx, "getChildCodes endpoint: failed to get child codes from store", data, err, w)
+		return
+	}
+
+	start, end := datastore.Page{Offset: offset, Limit: limit}.Bounds(len(dbCodes))
+	codes := models.NewCodeResults(dbCodes[start:end])
+
+	for i, item := range codes.Items {
+		if err := item.UpdateLinks(c.apiURL, id, edition); err != nil {
+			log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "getChildCodes endpoint: links could not be created")))
+			http.Error(w, internalServerErr, http.StatusInternalServerError)
+			return
+		}
+		codes.Items[i] = item
+	}
+
+	codes.Count = len(codes.Items)
+	codes.Offset = offset
+	codes.Limit = limit
+	codes.TotalCount = len(dbCodes)
+
+	if err := c.writeBody(w, r, codes); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "getChildCodes endpoint: failed to write bytes to response")))
+		return
+	}
+
+	log.Event(ctx, "getChildCodes endpoint: request successful", log.INFO, data)
+}
+
+// getAncestorCodes returns every ancestor of a code, from the top of the hierarchy down to its parent. The
+// results are not paginated, as hierarchies are only a few levels deep.
+func (c *CodeListAPI) getAncestorCodes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	edition := vars["edition"]
+	code := vars["code"]
+	data := log.Data{"codelist_id": id, "edition": edition, "code": code}
+
+	log.Event(ctx, "getAncestorCodes endpoint: attempting to get ancestors of code", log.INFO, data)
+
+	dbCodes, err := datastore.GetAncestorCodes(ctx, c.store, id, edition, code)
+	if err != nil {
+		handleError(ctx, "getAncestorCodes endpoint: failed to get ancestor codes from store", data, err, w)
+		return
+	}
+
+	codes := models.NewCodeResults(dbCodes)
+
+	for i, item := range codes.Items {
+		if err := item.UpdateLinks(c.apiURL, id, edition); err != nil {
+			log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "getAncestorCodes endpoint: links could not be created")))
+			http.Error(w, internalServerErr, http.StatusInternalServerError)
+			return
+		}
+		codes.Items[i] = item
+	}
+
+	codes.Count = len(codes.Items)
+	codes.Limit = len(codes.Items)
+	codes.TotalCount = len(codes.Items)
+
+	if err := c.writeBody(w, r, codes); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "getAncestorCodes endpoint: failed to write bytes to response")))
+		return
+	}
+
+	log.Event(ctx, "getAncestorCodes endpoint: request successful", log.INFO, data)
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// newHierarchyMock returns a hierarchy where testCode2 is the only child of testCode1
+func newHierarchyMock() *storetest.HierarchyMock {
+	return &storetest.HierarchyMock{
+		GetParentCodeFunc: func(ctx context.Context, codeListID string, editionID string, codeID string) (*dbmodels.Code, error) {
+			switch codeID {
+			case codeID1:
+				return nil, nil
+			case codeID2:
+				return &dbCode1, nil
+			}
+			return nil, driver.ErrNotFound
+		},
+		GetChildCodesFunc: func(ctx context.Context, codeListID string, editionID string, codeID string) ([]dbmodels.Code, error) {
+			switch codeID {
+			case codeID1:
+				return []dbmodels.Code{dbCode2}, nil
+			case codeID2:
+				return []dbmodels.Code{}, nil
+			}
+			return nil, driver.ErrNotFound
+		},
+		GetParentCodeIDsFunc: func(ctx context.Context, codeListID string, editionID string) (map[string]string, error) {
+			return map[string]string{codeID2: codeID1}, nil
+		},
+	}
+}
+
+func TestGetCodeHierarchyLinks(t *testing.T) {
+	Convey("Given a store holding a hierarchy of codes", t, func() {
+		mockDatastore := &storetest.DataStoreMock{
+			GetCodeFunc: func(ctx context.Context, codeListID string, editionID string, codeID string) (*dbmodels.Code, error) {
+				return &dbCode2, nil
+			},
+		}
+		api := CreateCodeListAPI(mux.NewRouter(), struct {
+			*storetest.DataStoreMock
+			*storetest.HierarchyMock
+		}{mockDatastore, newHierarchyMock()}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When a code is requested, then its links include its parent and children", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s", codeListURL, codeListID1, editionID1, codeID2), nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			links := *expectedCode2.Links
+			links.Parent = &models.Link{
+				ID:   codeID1,
+				Href: fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s", codeListURL, codeListID1, editionID1, codeID1),
+			}
+			links.Children = &models.Link{
+				Href: fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s/children", codeListURL, codeListID1, editionID1, codeID2),
+			}
+			validateBody(w.Body, &models.Code{}, &models.Code{ID: codeID2, Label: "test two", Links: &links})
+		})
+	})
+}
+
+func TestGetParentCode(t *testing.T) {
+	Convey("Given a store holding a hierarchy of codes", t, func() {
+		api := CreateCodeListAPI(mux.NewRouter(), struct {
+			*storetest.DataStoreMock
+			*storetest.HierarchyMock
+		}{&storetest.DataStoreMock{}, newHierarchyMock()}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When the parent of a code is requested, then it is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s/parent", codeListURL, codeListID1, editionID1, codeID2), nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.Code{}, &expectedCode1)
+		})
+
+		Convey("When the parent of a code at the top of the hierarchy is requested, then 404 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s/parent", codeListURL, codeListID1, editionID1, codeID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("When the parent of an unknown code is requested, then 404 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/unknown/parent", codeListURL, codeListID1, editionID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+
+	Convey("Given a store that does not support hierarchies", t, func() {
+		mockDatastore := &storetest.DataStoreMock{
+			GetCodeFunc: func(ctx context.Context, codeListID string, editionID string, codeID string) (*dbmodels.Code, error) {
+				return &dbCode1, nil
+			},
+		}
+		api := CreateCodeListAPI(mux.NewRouter(), mockDatastore, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When the parent of a code is requested, then 501 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s/parent", codeListURL, codeListID1, editionID1, codeID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusNotImplemented)
+		})
+
+		Convey("When a code is requested, then it is returned without hierarchy links", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s", codeListURL, codeListID1, editionID1, codeID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.Code{}, &expectedCode1)
+		})
+	})
+}
+
+func TestGetChildCodes(t *testing.T) {
+	Convey("Given a store holding a hierarchy of codes", t, func() {
+		api := CreateCodeListAPI(mux.NewRouter(), struct {
+			*storetest.DataStoreMock
+			*storetest.HierarchyMock
+		}{&storetest.DataStoreMock{}, newHierarchyMock()}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When the children of a code are requested, then a page of them is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s/children", codeListURL, codeListID1, editionID1, codeID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.CodeResults{}, &models.CodeResults{
+				Items:      []models.Code{expectedCode2},
+				Count:      1,
+				Limit:      defaultLimit,
+				TotalCount: 1,
+			})
+		})
+
+		Convey("When the children of a code without children are requested, then an empty page is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s/children", codeListURL, codeListID1, editionID1, codeID2), nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.CodeResults{}, &models.CodeResults{
+				Items: nil,
+				Limit: defaultLimit,
+			})
+		})
+
+		Convey("When an invalid offset is provided, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s/children?offset=-1", codeListURL, codeListID1, editionID1, codeID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+	})
+}
+
+func TestGetAncestorCodes(t *testing.T) {
+	Convey("Given a store holding a hierarchy of codes", t, func() {
+		api := CreateCodeListAPI(mux.NewRouter(), struct {
+			*storetest.DataStoreMock
+			*storetest.HierarchyMock
+		}{&storetest.DataStoreMock{}, newHierarchyMock()}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When the ancestors of a code are requested, then they are returned from the top of the hierarchy", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s/ancestors", codeListURL, codeListID1, editionID1, codeID2), nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.CodeResults{}, &models.CodeResults{
+				Items:      []models.Code{expectedCode1},
+				Count:      1,
+				Limit:      1,
+				TotalCount: 1,
+			})
+		})
+
+		Convey("When the ancestors of an unknown code are requested, then 404 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/unknown/ancestors", codeListURL, codeListID1, editionID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/csvimport"
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/events"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+const (
+	// importPath is the path, under an edition, of the endpoint creating the edition from a CSV file
+	importPath = "/import"
+	// maxImportSize is the maximum size of an imported file, which is larger than other request bodies
+	// to fit the largest code lists (e.g. LSOAs)
+	maxImportSize = 32 << 20
+)
+
+// importEdition creates an edition from a CSV file of codes, with a code,label or code,label,parent header.
+// The whole file is checked first, and the edition is only created if no problems are found in it.
+func (c *CodeListAPI) importEdition(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	edition := vars["edition"]
+	data := log.Data{"codelist_id": id, "edition": edition}
+
+	log.Event(ctx, "importEdition endpoint: attempting to import edition", log.INFO, data)
+
+	if err := models.ValidateEditionID(edition); err != nil {
+		log.Event(ctx, "importEdition endpoint: invalid edition id", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	update, err := importEditionUpdate(r.URL.Query(), edition)
+	if err != nil {
+		log.Event(ctx, "importEdition endpoint: invalid request", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// one more byte than the maximum size is read, so that a larger file can be told from a file which could
+	// not be read
+	body := &countingReader{reader: http.MaxBytesReader(w, r.Body, maxImportSize+1)}
+	codes, problems, err := csvimport.Read(body)
+	if body.count > maxImportSize {
+		err = errors.Errorf("file is larger than the maximum allowed size of %d bytes", maxImportSize)
+		log.Event(ctx, "importEdition endpoint: file too large", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		log.Event(ctx, "importEdition endpoint: failed to read request body", log.ERROR, log.Error(err), data)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	data["codes"] = len(codes)
+	data["problems"] = len(problems)
+
+	report := newImportReport(codes, problems, c.maxLimit)
+	if !report.Valid {
+		log.Event(ctx, "importEdition endpoint: invalid file", log.ERROR, data)
+		c.writeReport(w, r, report, http.StatusBadRequest, data)
+		return
+	}
+
+	if err := datastore.ImportEdition(ctx, c.store, id, update, newCodeUpdates(codes)); err != nil {
+		handleError(ctx, "importEdition endpoint: failed to import edition in store", data, err, w)
+		return
+	}
+	c.suggestions.Invalidate(id)
+	if datastore.Published(update.State, update.ReleaseDate) {
+		c.publish(ctx, events.New(events.EditionPublished, id, edition))
+	}
+
+	if err := report.UpdateLinks(c.apiURL, id, edition); err != nil {
+		log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "importEdition endpoint: links could not be created")))
+		http.Error(w, internalServerErr, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set(locationHeader, report.Links.Edition.Href)
+	if c.writeReport(w, r, report, http.StatusCreated, data) {
+		log.Event(ctx, "importEdition endpoint: request successful", log.INFO, data)
+	}
+}
+
+// importEditionUpdate returns the edition to create from the label, state and release_date query parameters
+// of an import, checked as for the other editions. The label defaults to the edition ID.
+func importEditionUpdate(query url.Values, editionID string) (datastore.EditionUpdate, error) {
+	request := &models.EditionRequest{
+		Label: strings.TrimSpace(query.Get("label")),
+		State: query.Get("state"),
+	}
+	if request.Label == "" {
+		request.Label = editionID
+	}
+	if value := query.Get("release_date"); value != "" {
+		releaseDate, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return datastore.EditionUpdate{}, errors.New("invalid release date, it must be an RFC 3339 date and time")
+		}
+		request.ReleaseDate = &releaseDate
+	}
+	return editionUpdate(request, editionID)
+}
+
+// writeReport writes the validation report of an imported file with the given status, and reports whether
+// it was written
+func (c *CodeListAPI) writeReport(w http.ResponseWriter, r *http.Request, report *models.ImportReport, status int, data log.Data) bool {
+	if err := c.writeResponse(w, r, status, report); err != nil {
+		log.Event(r.Context(), "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "importEdition endpoint: failed to write bytes to response")), data)
+		return false
+	}
+	return true
+}
+
+// countingReader counts the bytes read from a reader
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-code-list-api/events"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// newImporterStore returns a store holding the first code list, with the first edition
+func newImporterStore() (datastore.DataStore, *storetest.ImporterMock) {
+	importer := &storetest.ImporterMock{
+		ImportEditionFunc: func(ctx context.Context, codeListID string, edition datastore.EditionUpdate, codes []datastore.CodeUpdate) error {
+			if codeListID != codeListID1 {
+				return driver.ErrNotFound
+			}
+			if edition.ID == editionID1 {
+				return datastore.ErrAlreadyExists
+			}
+			return nil
+		},
+	}
+	return struct {
+		*storetest.DataStoreMock
+		*storetest.ImporterMock
+	}{&storetest.DataStoreMock{}, importer}, importer
+}
+
+func TestImportEdition(t *testing.T) {
+	importURL := func(id, edition string) string {
+		return fmt.Sprintf("%s/code-lists/%s/editions/%s/import", codeListURL, id, edition)
+	}
+	editionURL := fmt.Sprintf("%s/code-lists/%s/editions/%s", codeListURL, codeListID1, editionID2)
+	validFile := "code,label,parent\ntestCode1,test one,\ntestCode2,test two,testCode1\n"
+
+	Convey("Given an API with private endpoints", t, func() {
+		store, importer := newImporterStore()
+		api := CreateCodeListAPI(mux.NewRouter(), store, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit, WithPrivateEndpoints(testIdentity))
+
+		Convey("When a valid file is imported, then the edition is created with its codes", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("POST", importURL(codeListID1, editionID2)+"?label=Second+edition", validFile))
+
+			So(w.Code, ShouldEqual, http.StatusCreated)
+			So(w.Header().Get(locationHeader), ShouldEqual, editionURL)
+			validateBody(w.Body, &models.ImportReport{}, &models.ImportReport{
+				Valid:     true,
+				CodeCount: 2,
+				Problems:  []models.ImportProblem{},
+				Links: &models.ImportLinks{
+					Edition: &models.Link{ID: editionID2, Href: editionURL},
+					Codes:   &models.Link{Href: editionURL + "/codes"},
+				},
+			})
+
+			So(importer.ImportEditionCalls(), ShouldHaveLength, 1)
+			call := importer.ImportEditionCalls()[0]
+			So(call.Edition, ShouldResemble, datastore.EditionUpdate{ID: editionID2, Label: "Second edition"})
+			So(call.Codes, ShouldResemble, []datastore.CodeUpdate{
+				{Code: codeID1, Label: "test one"},
+				{Code: codeID2, Label: "test two", Parent: codeID1},
+			})
+		})
+
+		Convey("When a file is imported with a state and a release date, then the edition is created with them", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("POST", importURL(codeListID1, editionID2)+"?state=draft&release_date=2021-05-01T00:00:00Z", validFile))
+
+			So(w.Code, ShouldEqual, http.StatusCreated)
+			So(importer.ImportEditionCalls(), ShouldHaveLength, 1)
+			releaseDate := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+			So(importer.ImportEditionCalls()[0].Edition, ShouldResemble, datastore.EditionUpdate{
+				ID:          editionID2,
+				Label:       editionID2,
+				ReleaseDate: &releaseDate,
+				State:       datastore.EditionDraft,
+			})
+		})
+
+		Convey("When a file is imported with an invalid state or release date, then 400 is returned and the store is unchanged", func() {
+			for _, query := range []string{"?state=unknown", "?release_date=2021-05-01"} {
+				w := httptest.NewRecorder()
+				api.router.ServeHTTP(w, newWriteRequest("POST", importURL(codeListID1, editionID2)+query, validFile))
+
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			}
+			So(importer.ImportEditionCalls(), ShouldBeEmpty)
+		})
+
+		Convey("When an invalid file is imported, then 400 is returned with every problem, and the store is unchanged", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("POST", importURL(codeListID1, editionID2), "code,label\ntestCode1,\ntestCode1,test one\n"))
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			validateBody(w.Body, &models.ImportReport{}, &models.ImportReport{
+				CodeCount:    0,
+				ProblemCount: 2,
+				Problems: []models.ImportProblem{
+					{Line: 2, Code: codeID1, Message: "empty label"},
+					{Line: 3, Code: codeID1, Message: "duplicate code, first found on line 2"},
+				},
+			})
+			So(importer.ImportEditionCalls(), ShouldBeEmpty)
+		})
+
+		Convey("When a file is imported as an existing edition, then 409 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("POST", importURL(codeListID1, editionID1), validFile))
+
+			So(w.Code, ShouldEqual, http.StatusConflict)
+		})
+
+		Convey("When a file is imported in an unknown code list, then 404 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("POST", importURL(codeListID2, editionID2), validFile))
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("When a file is imported as the latest edition alias, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("POST", importURL(codeListID1, latestEdition), validFile))
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(importer.ImportEditionCalls(), ShouldBeEmpty)
+		})
+
+		Convey("When a file larger than the maximum size is imported, then 413 is returned with the maximum size", func() {
+			w := httptest.NewRecorder()
+			file := "code,label\n" + strings.Repeat("testCode,test\n", maxImportSize/14+1)
+			api.router.ServeHTTP(w, newWriteRequest("POST", importURL(codeListID1, editionID2), file))
+
+			So(w.Code, ShouldEqual, http.StatusRequestEntityTooLarge)
+			So(w.Body.String(), ShouldContainSubstring, strconv.Itoa(maxImportSize))
+			So(importer.ImportEditionCalls(), ShouldBeEmpty)
+		})
+
+		Convey("When a file is imported without authentication, then 401 is returned", func() {
+			w := httptest.NewRecorder()
+			r := newWriteRequest("POST", importURL(codeListID1, editionID2), validFile)
+			r.Header.Del("Authorization")
+			api.router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusUnauthorized)
+			So(importer.ImportEditionCalls(), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given an API with private endpoints and a publisher", t, func() {
+		store, _ := newImporterStore()
+		publisher := events.NewMemory()
+		api := CreateCodeListAPI(mux.NewRouter(), store, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit, WithPrivateEndpoints(testIdentity), WithPublisher(publisher))
+
+		Convey("When a file is imported as a published edition, then its event is published", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("POST", importURL(codeListID1, editionID2)+"?state=published", validFile))
+
+			So(w.Code, ShouldEqual, http.StatusCreated)
+			So(publisher.Events(), ShouldHaveLength, 1)
+			So(publisher.Events()[0].Type, ShouldEqual, events.EditionPublished)
+			So(publisher.Events()[0].EditionID, ShouldEqual, editionID2)
+		})
+
+		Convey("When a file is imported without a state or release date, then no event is published", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("POST", importURL(codeListID1, editionID2), validFile))
+
+			So(w.Code, ShouldEqual, http.StatusCreated)
+			So(publisher.Events(), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given an API with private endpoints over a store that cannot import editions", t, func() {
+		api := CreateCodeListAPI(mux.NewRouter(), &storetest.DataStoreMock{}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit, WithPrivateEndpoints(testIdentity))
+
+		Convey("When a valid file is imported, then 501 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("POST", importURL(codeListID1, editionID2), validFile))
+
+			So(w.Code, ShouldEqual, http.StatusNotImplemented)
+		})
+	})
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+)
+
+// latestEdition is the edition alias resolved to the current edition of a code list
+const latestEdition = "latest"
+
+const contentLocationHeader = "Content-Location"
+
+// resolveLatestEdition is a middleware serving the requests made for the latest edition of a code list from
+// its current edition. The canonical URL of the response is given by the Content-Location header. Changes
+// are never made through the alias, so requests changing an edition are left for their handlers to reject.
+func (c *CodeListAPI) resolveLatestEdition(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		if vars["edition"] != latestEdition || changesEdition(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		id := vars["id"]
+		data := log.Data{"codelist_id": id}
+
+		edition, err := datastore.GetLatestEditionID(ctx, c.store, id)
+		if err != nil {
+			handleError(ctx, "failed to resolve latest edition", data, err, w)
+			return
+		}
+
+		resolved := make(map[string]string, len(vars))
+		for k, v := range vars {
+			resolved[k] = v
+		}
+		resolved["edition"] = edition
+
+		location := c.apiURL + strings.Replace(r.URL.Path, "/editions/"+latestEdition, "/editions/"+edition, 1)
+		if r.URL.RawQuery != "" {
+			location += "?" + r.URL.RawQuery
+		}
+		w.Header().Set(contentLocationHeader, location)
+
+		data["edition"] = edition
+		log.Event(ctx, "latest edition resolved", log.INFO, data)
+		next.ServeHTTP(w, mux.SetURLVars(r, resolved))
+	})
+}
+
+// changesEdition reports whether a request changes the edition of its URL, rather than reading it
+func changesEdition(r *http.Request) bool {
+	return r.Method == http.MethodPut || r.Method == http.MethodDelete || strings.HasSuffix(r.URL.Path, importPath)
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLatestEdition(t *testing.T) {
+	Convey("Given a code list whose current edition is the first edition", t, func() {
+		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: countEditionCodes,
+			GetEditionsFunc: func(ctx context.Context, id string) (*dbmodels.Editions, error) {
+				return &dbEditions, nil
+			},
+			GetEditionFunc: func(ctx context.Context, id, edition string) (*dbmodels.Edition, error) {
+				return &dbEdition1, nil
+			},
+			GetCodeFunc: func(ctx context.Context, codeListID, editionID, codeID string) (*dbmodels.Code, error) {
+				return &dbCode1, nil
+			},
+		}
+		api := CreateCodeListAPI(mux.NewRouter(), struct {
+			*storetest.DataStoreMock
+			*storetest.EditionMetadataGetterMock
+		}{mockDatastore, newEditionMetadataGetterMock()}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When the latest edition is requested, then the current edition is returned with its canonical URL", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/latest", codeListURL, codeListID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(contentLocationHeader), ShouldEqual, fmt.Sprintf("%s/code-lists/%s/editions/%s", codeListURL, codeListID1, editionID1))
+			So(mockDatastore.GetEditionCalls()[0].EditionID, ShouldEqual, editionID1)
+			validateBody(w.Body, &models.Edition{}, &expectedEditionWithMetadata1)
+		})
+
+		Convey("When a code of the latest edition is requested, then it is taken from the current edition", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/latest/codes/%s", codeListURL, codeListID1, codeID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(contentLocationHeader), ShouldEqual, fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s", codeListURL, codeListID1, editionID1, codeID1))
+			So(mockDatastore.GetCodeCalls()[0].EditionID, ShouldEqual, editionID1)
+		})
+	})
+
+	Convey("Given a code list without editions", t, func() {
+		api := CreateCodeListAPI(mux.NewRouter(), struct {
+			*storetest.DataStoreMock
+			*storetest.EditionMetadataGetterMock
+		}{&storetest.DataStoreMock{}, &storetest.EditionMetadataGetterMock{
+			GetEditionsMetadataFunc: func(ctx context.Context, codeListID string) ([]datastore.EditionMetadata, error) {
+				return []datastore.EditionMetadata{}, nil
+			},
+		}}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When the latest edition is requested, then 404 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/latest", codeListURL, codeListID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+
+	Convey("Given a store without edition metadata", t, func() {
+		mockDatastore := &storetest.DataStoreMock{}
+		api := CreateCodeListAPI(mux.NewRouter(), mockDatastore, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When the latest edition is requested, then 501 is returned rather than the last edition by ID", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/latest", codeListURL, codeListID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusNotImplemented)
+			So(mockDatastore.GetEditionsCalls(), ShouldBeEmpty)
+		})
+	})
+}
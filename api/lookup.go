@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+func (c *CodeListAPI) getCodeEditions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	code := vars["code"]
+	data := log.Data{"code": code}
+
+	log.Event(ctx, "getCodeEditions endpoint: attempting to find code list editions containing code", log.INFO, data)
+
+	offset, limit, ok := c.getPage(ctx, w, r, data)
+	if !ok {
+		return
+	}
+
+	dbCodeEditions, err := datastore.GetCodeEditions(ctx, c.store, code)
+	if err != nil {
+		handleError(ctx, "getCodeEditions endpoint: failed to find code list editions containing code", data, err, w)
+		return
+	}
+	if len(dbCodeEditions) == 0 {
+		handleError(ctx, "getCodeEditions endpoint: code not found in any code list edition", data, driver.ErrNotFound, w)
+		return
+	}
+
+	start, end := datastore.Page{Offset: offset, Limit: limit}.Bounds(len(dbCodeEditions))
+	codeEditions := newCodeEditions(dbCodeEditions[start:end])
+
+	for i, item := range codeEditions.Items {
+		if err := item.UpdateLinks(c.apiURL); err != nil {
+			log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "getCodeEditions endpoint: links could not be created")))
+			http.Error(w, internalServerErr, http.StatusInternalServerError)
+			return
+		}
+		codeEditions.Items[i] = item
+	}
+
+	codeEditions.Count = len(codeEditions.Items)
+	codeEditions.Offset = offset
+	codeEditions.Limit = limit
+	codeEditions.TotalCount = len(dbCodeEditions)
+
+	if err := c.writeBody(w, r, codeEditions); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "getCodeEditions endpoint: failed to write bytes to response")))
+		return
+	}
+
+	log.Event(ctx, "getCodeEditions endpoint: request successful", log.INFO, data)
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// getLookupCodes looks up the codes provided as a comma separated ids query parameter
+func (c *CodeListAPI) getLookupCodes(w http.ResponseWriter, r *http.Request) {
+	var codeIDs []string
+	for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			codeIDs = append(codeIDs, id)
+		}
+	}
+	c.lookupCodes(w, r, codeIDs)
+}
+
+// postLookupCodes looks up the codes provided as a JSON array in the request body
+func (c *CodeListAPI) postLookupCodes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	codeIDs, err := decodeCodeIDs(w, r)
+	if err != nil {
+		log.Event(ctx, "lookupCodes endpoint: failed to parse request body", log.ERROR, log.Error(err), log.Data{"codelist_id": mux.Vars(r)["id"], "edition": mux.Vars(r)["edition"]})
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	c.lookupCodes(w, r, codeIDs)
+}
+
+func (c *CodeListAPI) lookupCodes(w http.ResponseWriter, r *http.Request, codeIDs []string) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	edition := vars["edition"]
+	codeIDs = unique(codeIDs)
+	data := log.Data{"codelist_id": id, "edition": edition}
+
+	log.Event(ctx, "lookupCodes endpoint: attempting to get codes", log.INFO, data)
+
+	dbCodes, ok := c.getRequestedCodes(ctx, w, "lookupCodes", id, edition, codeIDs, data)
+	if !ok {
+		return
+	}
+
+	lookup := models.NewCodeLookup(codeIDs, dbCodes)
+
+	for i, item := range lookup.Items {
+		if err := item.UpdateLinks(c.apiURL, id, edition); err != nil {
+			log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "lookupCodes endpoint: links could not be created")))
+			http.Error(w, internalServerErr, http.StatusInternalServerError)
+			return
+		}
+		lookup.Items[i] = item
+	}
+
+	if err := c.writeBody(w, r, lookup); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "lookupCodes endpoint: failed to write bytes to response")))
+		return
+	}
+
+	data["not_found"] = len(lookup.NotFound)
+	log.Event(ctx, "lookupCodes endpoint: request successful", log.INFO, data)
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLookupCodes(t *testing.T) {
+	Convey("Given a store able to get many codes at once", t, func() {
+		mockGetter := &storetest.MultiGetterMock{
+			GetCodesByIDFunc: func(ctx context.Context, codeListID string, editionID string, codeIDs []string) ([]dbmodels.Code, error) {
+				if editionID != editionID1 {
+					return nil, driver.ErrNotFound
+				}
+				return []dbmodels.Code{dbCode2, dbCode1}, nil
+			},
+		}
+		store := struct {
+			*storetest.DataStoreMock
+			*storetest.MultiGetterMock
+		}{&storetest.DataStoreMock{}, mockGetter}
+		api := CreateCodeListAPI(mux.NewRouter(), store, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+		url := fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/lookup", codeListURL, codeListID1, editionID1)
+		expected := &models.CodeLookup{
+			Items:    []models.Code{expectedCode2, expectedCode1},
+			Count:    2,
+			NotFound: []string{"unknown"},
+		}
+
+		Convey("When codes are looked up with a POST request, then the codes found are returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("POST", url, strings.NewReader(`["`+codeID2+`","unknown","`+codeID1+`"]`)))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.CodeLookup{}, expected)
+			So(mockGetter.GetCodesByIDCalls(), ShouldHaveLength, 1)
+			So(mockGetter.GetCodesByIDCalls()[0].CodeIDs, ShouldResemble, []string{codeID2, "unknown", codeID1})
+		})
+
+		Convey("When codes are looked up with a GET request, then the codes found are returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", url+"?ids="+codeID2+",unknown,,"+codeID1, nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.CodeLookup{}, expected)
+			So(mockGetter.GetCodesByIDCalls()[0].CodeIDs, ShouldResemble, []string{codeID2, "unknown", codeID1})
+		})
+
+		Convey("When no codes are provided, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("When the request body is invalid, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("POST", url, strings.NewReader(`[1,2]`)))
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("When codes are looked up in an unknown edition, then 404 is returned", func() {
+			w := httptest.NewRecorder()
+			url := fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/lookup?ids=%s", codeListURL, codeListID1, editionID2, codeID1)
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var expectedCodeEdition1 = models.CodeEdition{
+	ID:         codeID1,
+	Label:      "test one",
+	CodeListID: codeListID1,
+	Edition:    editionID1,
+	Links: &models.CodeEditionLinks{
+		Self: &models.Link{
+			ID:   codeID1,
+			Href: fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s", codeListURL, codeListID1, editionID1, codeID1),
+		},
+		CodeList: &models.Link{
+			ID:   codeListID1,
+			Href: fmt.Sprintf("%s/code-lists/%s", codeListURL, codeListID1),
+		},
+		Edition: &models.Link{
+			ID:   editionID1,
+			Href: fmt.Sprintf("%s/code-lists/%s/editions/%s", codeListURL, codeListID1, editionID1),
+		},
+	},
+}
+
+func TestGetCodeEditions(t *testing.T) {
+	Convey("Given a store that does not support reverse lookups", t, func() {
+		mockDatastore := &storetest.DataStoreMock{}
+		api := CreateCodeListAPI(mux.NewRouter(), mockDatastore, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When a code is looked up, then 501 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/codes/%s", codeListURL, codeID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusNotImplemented)
+		})
+
+		Convey("When an invalid limit is provided, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/codes/%s?limit=-1", codeListURL, codeID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+	})
+
+	Convey("Given a store that supports reverse lookups", t, func() {
+		mockLookup := &storetest.ReverseLookupMock{
+			GetCodeEditionsFunc: func(ctx context.Context, codeID string) ([]datastore.CodeEdition, error) {
+				return []datastore.CodeEdition{
+					{CodeListID: codeListID1, EditionID: editionID1, Code: dbCode1},
+					{CodeListID: codeListID2, EditionID: editionID1, Code: dbCode1},
+				}, nil
+			},
+		}
+		store := struct {
+			*storetest.DataStoreMock
+			*storetest.ReverseLookupMock
+		}{&storetest.DataStoreMock{}, mockLookup}
+		api := CreateCodeListAPI(mux.NewRouter(), store, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When a page of the code list editions containing a code is requested, then it is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/codes/%s?offset=0&limit=1", codeListURL, codeID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.CodeEditions{}, &models.CodeEditions{
+				Items:      []models.CodeEdition{expectedCodeEdition1},
+				Count:      1,
+				Limit:      1,
+				TotalCount: 2,
+			})
+			So(mockLookup.GetCodeEditionsCalls(), ShouldHaveLength, 1)
+			So(mockLookup.GetCodeEditionsCalls()[0].CodeID, ShouldEqual, codeID1)
+		})
+
+		Convey("When a code found in no code list is looked up, then 404 is returned", func() {
+			mockLookup.GetCodeEditionsFunc = func(ctx context.Context, codeID string) ([]datastore.CodeEdition, error) {
+				return nil, nil
+			}
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/codes/%s", codeListURL, codeID2), nil))
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
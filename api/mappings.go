@@ -0,0 +1,170 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// getCodeMappings returns a page of the mappings from and to a code, optionally only those of the type
+// provided by the type query parameter, or with their other code in the code list provided by code_list
+func (c *CodeListAPI) getCodeMappings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	edition := vars["edition"]
+	code := vars["code"]
+	mappingType := datastore.MappingType(r.URL.Query().Get("type"))
+	codeListFilter := r.URL.Query().Get("code_list")
+	data := log.Data{"codelist_id": id, "edition": edition, "code": code}
+
+	log.Event(ctx, "getCodeMappings endpoint: attempting to get mappings of code", log.INFO, data)
+
+	if mappingType != "" && !mappingType.Valid() {
+		data["type"] = mappingType
+		err := errors.New("invalid query parameter: type")
+		log.Event(ctx, "getCodeMappings endpoint: invalid query parameter: type", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	offset, limit, ok := c.getPage(ctx, w, r, data)
+	if !ok {
+		return
+	}
+
+	dbMappings, err := datastore.GetMappings(ctx, c.store, id, edition, code)
+	if err != nil {
+		handleError(ctx, "getCodeMappings endpoint: failed to get mappings from store", data, err, w)
+		return
+	}
+
+	ref := datastore.CodeRef{CodeListID: id, EditionID: edition, Code: code}
+	filtered := make([]datastore.Mapping, 0, len(dbMappings))
+	for _, mapping := range dbMappings {
+		other := mapping.Target
+		if mapping.Target == ref {
+			other = mapping.Source
+		}
+		if (mappingType == "" || mapping.Type == mappingType) && (codeListFilter == "" || other.CodeListID == codeListFilter) {
+			filtered = append(filtered, mapping)
+		}
+	}
+
+	start, end := datastore.Page{Offset: offset, Limit: limit}.Bounds(len(filtered))
+	mappings := newMappings(filtered[start:end])
+
+	for i, item := range mappings.Items {
+		if err := item.UpdateLinks(c.apiURL); err != nil {
+			log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "getCodeMappings endpoint: links could not be created")))
+			http.Error(w, internalServerErr, http.StatusInternalServerError)
+			return
+		}
+		mappings.Items[i] = item
+	}
+
+	mappings.Count = len(mappings.Items)
+	mappings.Offset = offset
+	mappings.Limit = limit
+	mappings.TotalCount = len(filtered)
+
+	if err := c.writeBody(w, r, mappings); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "getCodeMappings endpoint: failed to write bytes to response")))
+		return
+	}
+
+	log.Event(ctx, "getCodeMappings endpoint: request successful", log.INFO, data)
+}
+
+// postResolveMappings resolves each of the requested codes to the codes of a target code list, following the
+// mappings between codes. Requested codes that do not exist are returned as not found.
+func (c *CodeListAPI) postResolveMappings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	log.Event(ctx, "postResolveMappings endpoint: attempting to resolve codes", log.INFO)
+
+	var request models.ResolveRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(&request); err != nil {
+		log.Event(ctx, "postResolveMappings endpoint: failed to parse request body", log.ERROR, log.Error(err))
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	data := log.Data{"target_code_list": request.TargetCodeListID, "target_edition": request.TargetEditionID, "codes": len(request.Codes)}
+
+	if err := c.validateResolveRequest(request); err != nil {
+		log.Event(ctx, "postResolveMappings endpoint: invalid request", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resolutions := &models.Resolutions{Items: []models.Resolution{}, NotFound: []models.MappedCode{}}
+	seen := make(map[datastore.CodeRef]bool, len(request.Codes))
+	for _, code := range request.Codes {
+		source := codeRef(code)
+		if seen[source] {
+			continue
+		}
+		seen[source] = true
+
+		codes, err := datastore.ResolveMappings(ctx, c.store, source, request.TargetCodeListID, request.TargetEditionID)
+		if err == driver.ErrNotFound {
+			resolutions.NotFound = append(resolutions.NotFound, newMappedCode(source))
+			continue
+		}
+		if err != nil {
+			handleError(ctx, "postResolveMappings endpoint: failed to resolve code", data, err, w)
+			return
+		}
+
+		resolution := newResolution(source, codes)
+		if err := resolution.Source.UpdateLinks(c.apiURL); err != nil {
+			log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "postResolveMappings endpoint: links could not be created")))
+			http.Error(w, internalServerErr, http.StatusInternalServerError)
+			return
+		}
+		for i, item := range resolution.Codes {
+			if err := item.UpdateLinks(c.apiURL); err != nil {
+				log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "postResolveMappings endpoint: links could not be created")))
+				http.Error(w, internalServerErr, http.StatusInternalServerError)
+				return
+			}
+			resolution.Codes[i] = item
+		}
+		resolutions.Items = append(resolutions.Items, *resolution)
+	}
+	resolutions.Count = len(resolutions.Items)
+
+	if err := c.writeBody(w, r, resolutions); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "postResolveMappings endpoint: failed to write bytes to response")))
+		return
+	}
+
+	data["not_found"] = len(resolutions.NotFound)
+	log.Event(ctx, "postResolveMappings endpoint: request successful", log.INFO, data)
+}
+
+// validateResolveRequest checks that a target code list, and at least one and no more than the maximum limit
+// of fully identified codes were requested
+func (c *CodeListAPI) validateResolveRequest(request models.ResolveRequest) error {
+	if request.TargetCodeListID == "" {
+		return errors.New("a target code list must be provided")
+	}
+	if len(request.Codes) == 0 {
+		return errors.New("at least one code must be provided")
+	}
+	if len(request.Codes) > c.maxLimit {
+		return errors.New("number of codes is greater than the maximum allowed")
+	}
+	for _, code := range request.Codes {
+		if code.CodeListID == "" || code.EditionID == "" || code.ID == "" {
+			return errors.New("the code list, edition and code of every code must be provided")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	// testCode1 of the first edition is replaced by testCode2 of the second edition
+	successorMapping = datastore.Mapping{
+		Source: datastore.CodeRef{CodeListID: codeListID1, EditionID: editionID1, Code: codeID1},
+		Target: datastore.CodeRef{CodeListID: codeListID1, EditionID: editionID2, Code: codeID2},
+		Type:   datastore.MappingSuccessor,
+	}
+	// testCode3 of the second code list is within testCode1 of the first edition
+	withinMapping = datastore.Mapping{
+		Source: datastore.CodeRef{CodeListID: codeListID2, EditionID: editionID1, Code: "testCode3"},
+		Target: datastore.CodeRef{CodeListID: codeListID1, EditionID: editionID1, Code: codeID1},
+		Type:   datastore.MappingWithin,
+	}
+)
+
+// newMappingsStore returns a store holding the successor and within mappings
+func newMappingsStore() datastore.DataStore {
+	mappings := map[datastore.CodeRef][]datastore.Mapping{
+		successorMapping.Source: {successorMapping, withinMapping},
+		successorMapping.Target: {successorMapping},
+		withinMapping.Source:    {withinMapping},
+	}
+	return struct {
+		*storetest.DataStoreMock
+		*storetest.RelationshipsMock
+	}{
+		&storetest.DataStoreMock{
+			GetCodeFunc: func(ctx context.Context, codeListID string, editionID string, codeID string) (*dbmodels.Code, error) {
+				return nil, driver.ErrNotFound
+			},
+		},
+		&storetest.RelationshipsMock{
+			GetMappingsFunc: func(ctx context.Context, codeListID string, editionID string, codeID string) ([]datastore.Mapping, error) {
+				m, ok := mappings[datastore.CodeRef{CodeListID: codeListID, EditionID: editionID, Code: codeID}]
+				if !ok {
+					return nil, driver.ErrNotFound
+				}
+				return m, nil
+			},
+		},
+	}
+}
+
+func mappedCode(codeListID, editionID, codeID string) models.MappedCode {
+	return models.MappedCode{
+		CodeListID: codeListID,
+		EditionID:  editionID,
+		ID:         codeID,
+		Links: &models.SelfLinks{Self: &models.Link{
+			ID:   codeID,
+			Href: fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s", codeListURL, codeListID, editionID, codeID),
+		}},
+	}
+}
+
+func TestGetCodeMappings(t *testing.T) {
+	mappingsURL := fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s/mappings", codeListURL, codeListID1, editionID1, codeID1)
+	expectedSuccessor := models.Mapping{
+		Type:   string(datastore.MappingSuccessor),
+		Source: mappedCode(codeListID1, editionID1, codeID1),
+		Target: mappedCode(codeListID1, editionID2, codeID2),
+	}
+	expectedWithin := models.Mapping{
+		Type:   string(datastore.MappingWithin),
+		Source: mappedCode(codeListID2, editionID1, "testCode3"),
+		Target: mappedCode(codeListID1, editionID1, codeID1),
+	}
+
+	Convey("Given a store holding mappings between codes", t, func() {
+		api := CreateCodeListAPI(mux.NewRouter(), newMappingsStore(), codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When the mappings of a code are requested, then the mappings from and to the code are returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", mappingsURL, nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.Mappings{}, &models.Mappings{
+				Items:      []models.Mapping{expectedSuccessor, expectedWithin},
+				Count:      2,
+				Limit:      defaultLimit,
+				TotalCount: 2,
+			})
+		})
+
+		Convey("When the mappings of a type are requested, then only those mappings are returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", mappingsURL+"?type=within", nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.Mappings{}, &models.Mappings{
+				Items:      []models.Mapping{expectedWithin},
+				Count:      1,
+				Limit:      defaultLimit,
+				TotalCount: 1,
+			})
+		})
+
+		Convey("When the mappings with another code list are requested, then only those mappings are returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", mappingsURL+"?code_list="+codeListID1, nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.Mappings{}, &models.Mappings{
+				Items:      []models.Mapping{expectedSuccessor},
+				Count:      1,
+				Limit:      defaultLimit,
+				TotalCount: 1,
+			})
+		})
+
+		Convey("When an unknown type of mapping is requested, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", mappingsURL+"?type=renamed", nil))
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("When the mappings of an unknown code are requested, then 404 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/unknown/mappings", codeListURL, codeListID1, editionID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+
+	Convey("Given a store that does not support mappings", t, func() {
+		api := CreateCodeListAPI(mux.NewRouter(), &storetest.DataStoreMock{}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When the mappings of a code are requested, then 501 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", mappingsURL, nil))
+
+			So(w.Code, ShouldEqual, http.StatusNotImplemented)
+		})
+	})
+}
+
+func TestPostResolveMappings(t *testing.T) {
+	resolveURL := codeListURL + "/mappings/resolve"
+
+	Convey("Given a store holding mappings between codes", t, func() {
+		api := CreateCodeListAPI(mux.NewRouter(), newMappingsStore(), codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When codes are resolved to an edition, then the codes they map to in that edition are returned", func() {
+			body := fmt.Sprintf(`{"codes":[{"code_list":%q,"edition":%q,"code":"testCode3"},{"code_list":%q,"edition":%q,"code":"unknown"}],"target_code_list":%q,"target_edition":%q}`,
+				codeListID2, editionID1, codeListID2, editionID1, codeListID1, editionID2)
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("POST", resolveURL, bytes.NewBufferString(body)))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.Resolutions{}, &models.Resolutions{
+				Items: []models.Resolution{{
+					Source: mappedCode(codeListID2, editionID1, "testCode3"),
+					Codes:  []models.MappedCode{mappedCode(codeListID1, editionID2, codeID2)},
+				}},
+				Count:    1,
+				NotFound: []models.MappedCode{{CodeListID: codeListID2, EditionID: editionID1, ID: "unknown"}},
+			})
+		})
+
+		Convey("When no target code list is provided, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("POST", resolveURL, bytes.NewBufferString(`{"codes":[{"code_list":"a","edition":"b","code":"c"}]}`)))
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("When a code is not fully identified, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("POST", resolveURL, bytes.NewBufferString(`{"codes":[{"code":"c"}],"target_code_list":"a"}`)))
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("When the request body is invalid, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("POST", resolveURL, bytes.NewBufferString(`[`)))
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+	})
+}
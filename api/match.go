@@ -0,0 +1,98 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/match"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultMatchLimit     = 3
+	defaultMatchThreshold = 0.5
+	maxMatchLabels        = 100
+	maxRequestBodySize    = 1 << 20
+)
+
+func (c *CodeListAPI) postMatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	edition := vars["edition"]
+	data := log.Data{"codelist_id": id, "edition": edition}
+
+	log.Event(ctx, "postMatch endpoint: attempting to match labels to codes", log.INFO, data)
+
+	var request models.MatchRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(&request); err != nil {
+		log.Event(ctx, "postMatch endpoint: failed to parse request body", log.ERROR, log.Error(err), data)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	data["labels"] = len(request.Labels)
+
+	limit := defaultMatchLimit
+	if request.Limit != nil {
+		limit = *request.Limit
+	}
+	threshold := defaultMatchThreshold
+	if request.Threshold != nil {
+		threshold = *request.Threshold
+	}
+
+	if err := c.validateMatchRequest(request, limit, threshold); err != nil {
+		log.Event(ctx, "postMatch endpoint: invalid request", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	codes, err := datastore.GetAllCodes(ctx, c.store, id, edition)
+	if err != nil {
+		handleError(ctx, "postMatch endpoint: failed to get codes from store", data, err, w)
+		return
+	}
+
+	matcher := match.NewMatcher(codes)
+	matches := &models.Matches{Items: []models.Match{}}
+	for _, label := range request.Labels {
+		m := newMatch(label, matcher.Match(label, limit, threshold))
+		for i, candidate := range m.Candidates {
+			if err := candidate.UpdateLinks(c.apiURL, id, edition); err != nil {
+				log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "postMatch endpoint: links could not be created")))
+				http.Error(w, internalServerErr, http.StatusInternalServerError)
+				return
+			}
+			m.Candidates[i] = candidate
+		}
+		matches.Items = append(matches.Items, *m)
+	}
+	matches.Count = len(matches.Items)
+
+	if err := c.writeBody(w, r, matches); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "postMatch endpoint: failed to write bytes to response")))
+		return
+	}
+
+	log.Event(ctx, "postMatch endpoint: request successful", log.INFO, data)
+}
+
+func (c *CodeListAPI) validateMatchRequest(request models.MatchRequest, limit int, threshold float64) error {
+	if len(request.Labels) == 0 {
+		return errors.New("at least one label must be provided")
+	}
+	if len(request.Labels) > maxMatchLabels {
+		return errors.Errorf("number of labels is greater than the maximum allowed of %d", maxMatchLabels)
+	}
+	if limit <= 0 || limit > c.maxLimit {
+		return errors.New("limit must be between 1 and the maximum allowed")
+	}
+	if threshold < 0 || threshold > 1 {
+		return errors.New("threshold must be between 0 and 1")
+	}
+	return nil
+}
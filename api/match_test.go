@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPostMatch(t *testing.T) {
+	Convey("Given a store containing an edition", t, func() {
+		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: func(ctx context.Context, codeListID string, editionID string) (int64, error) {
+				if editionID != editionID1 {
+					return 0, driver.ErrNotFound
+				}
+				return 2, nil
+			},
+			GetCodesFunc: func(ctx context.Context, codeListID string, editionID string) (*dbmodels.CodeResults, error) {
+				return &dbmodels.CodeResults{Items: []dbmodels.Code{dbCode1, dbCode2}}, nil
+			},
+		}
+		api := CreateCodeListAPI(mux.NewRouter(), mockDatastore, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+		url := fmt.Sprintf("%s/code-lists/%s/editions/%s/match", codeListURL, codeListID1, editionID1)
+
+		Convey("When labels are matched, then the candidate codes of each label are returned in order", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("POST", url, strings.NewReader(`{"labels":["Test-One","unknown"],"limit":1}`)))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.Matches{}, &models.Matches{
+				Items: []models.Match{
+					{
+						Label: "Test-One",
+						Candidates: []models.MatchCandidate{{
+							ID:    codeID1,
+							Label: "test one",
+							Score: 1,
+							Links: &models.SelfLinks{
+								Self: &models.Link{
+									ID:   codeID1,
+									Href: fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s", codeListURL, codeListID1, editionID1, codeID1),
+								},
+							},
+						}},
+					},
+					{Label: "unknown", Candidates: []models.MatchCandidate{}},
+				},
+				Count: 2,
+			})
+		})
+
+		Convey("When the request body is invalid, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("POST", url, strings.NewReader(`{"labels":`)))
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("When no labels are provided, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("POST", url, strings.NewReader(`{"labels":[]}`)))
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("When more labels than allowed are provided, then 400 is returned", func() {
+			labels := `"test"` + strings.Repeat(`,"test"`, maxMatchLabels)
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("POST", url, strings.NewReader(`{"labels":[`+labels+`]}`)))
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.String(), ShouldContainSubstring, "maximum allowed of 100")
+		})
+
+		Convey("When the threshold is not between 0 and 1, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("POST", url, strings.NewReader(`{"labels":["test"],"threshold":2}`)))
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("When labels are matched against an unknown edition, then 404 is returned", func() {
+			w := httptest.NewRecorder()
+			url := fmt.Sprintf("%s/code-lists/%s/editions/%s/match", codeListURL, codeListID1, editionID2)
+			api.router.ServeHTTP(w, httptest.NewRequest("POST", url, strings.NewReader(`{"labels":["test"]}`)))
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ONSdigital/log.go/log"
+	"github.com/pkg/errors"
+)
+
+const (
+	contentTypeCSV = "text/csv"
+
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+// csvBody is implemented by the response bodies which can be written as CSV. The items of a body are loaded
+// from the store as a whole before any row is written; only the hierarchy endpoint flushes rows as it goes.
+type csvBody interface {
+	WriteCSV(w *csv.Writer) error
+}
+
+// format is a representation of response bodies, requested with its name in the format query parameter, or
+// with its content type in the Accept header
+type format struct {
+	name        string
+	contentType string
+	// supports reports whether a body can be written in the format
+	supports func(body interface{}) bool
+	// write writes a body in the format
+	write func(w io.Writer, body interface{}) error
+}
+
+var (
+	jsonFormat = &format{
+		name:        formatJSON,
+		contentType: contentTypeJSON,
+		supports:    func(body interface{}) bool { return true },
+		write: func(w io.Writer, body interface{}) error {
+			b, err := json.Marshal(body)
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(b)
+			return err
+		},
+	}
+	csvFormat = &format{
+		name:        formatCSV,
+		contentType: contentTypeCSV + "; charset=utf-8",
+		supports: func(body interface{}) bool {
+			_, ok := body.(csvBody)
+			return ok
+		},
+		write: func(w io.Writer, body interface{}) error {
+			cw := csv.NewWriter(w)
+			if err := body.(csvBody).WriteCSV(cw); err != nil {
+				return err
+			}
+			cw.Flush()
+			return cw.Error()
+		},
+	}
+)
+
+// formats are the formats response bodies can be written in, JSON being the default
+var formats = []*format{jsonFormat, csvFormat, turtleFormat, jsonLDFormat, rdfXMLFormat, sdmxMLFormat, sdmxJSONFormat}
+
+// writeBody writes the body of a successful response, in the format negotiated with the request
+func (c *CodeListAPI) writeBody(w http.ResponseWriter, r *http.Request, body interface{}) error {
+	return c.writeResponse(w, r, http.StatusOK, body)
+}
+
+// writeResponse writes the body of a response with the given status, in the format negotiated with the
+// request among the formats supporting the body. 406 is written instead if the requested format does not
+// support it, and 500 if it fails before anything was written.
+func (c *CodeListAPI) writeResponse(w http.ResponseWriter, r *http.Request, status int, body interface{}) error {
+	ctx := r.Context()
+
+	f, err := negotiate(r, supportedFormats(body))
+	w.Header().Add("Vary", "Accept")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotAcceptable)
+		return err
+	}
+
+	w.Header().Set(contentTypeHeader, f.contentType)
+	bw := &bodyWriter{ResponseWriter: w, status: status}
+	if err := f.write(bw, body); err != nil {
+		if !bw.written {
+			w.Header().Del(contentTypeHeader)
+			handleError(ctx, "failed to encode response body", log.Data{"format": f.name}, err, w)
+		}
+		return err
+	}
+	if !bw.written {
+		w.WriteHeader(status)
+	}
+	return nil
+}
+
+// supportedFormats returns the formats supporting a body
+func supportedFormats(body interface{}) []*format {
+	supported := []*format{}
+	for _, f := range formats {
+		if f.supports(body) {
+			supported = append(supported, f)
+		}
+	}
+	return supported
+}
+
+// wantsCSV reports whether a request asks for CSV, for endpoints returning every item rather than a page
+// of items when they are written as CSV
+func wantsCSV(r *http.Request) bool {
+	f, err := negotiate(r, []*format{jsonFormat, csvFormat})
+	return err == nil && f == csvFormat
+}
+
+// wantsEveryItem reports whether a request for a list asks for a format other than JSON, for endpoints
+// returning every item rather than a page of items when they are downloaded as CSV or linked data. list is an
+// empty list of the type returned by the endpoint.
+func wantsEveryItem(r *http.Request, list interface{}) bool {
+	f, err := negotiate(r, supportedFormats(list))
+	return err == nil && f != jsonFormat
+}
+
+// negotiate returns the format requested among the supported formats, the first of them being the default.
+// A format requested by name with the format query parameter must be supported. Otherwise the supported
+// format with the highest quality in the Accept header is chosen, and the default format is used if none of
+// them is acceptable, as clients sending an unexpected Accept header were always served JSON.
+func negotiate(r *http.Request, supported []*format) (*format, error) {
+	if name := r.URL.Query().Get("format"); name != "" {
+		for _, f := range supported {
+			if strings.EqualFold(name, f.name) {
+				return f, nil
+			}
+		}
+		names := make([]string, 0, len(supported))
+		for _, f := range supported {
+			names = append(names, f.name)
+		}
+		return nil, fmt.Errorf("format %q is not supported by this resource, it must be one of %s", name, strings.Join(names, ", "))
+	}
+
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return supported[0], nil
+	}
+	best, bestQuality := supported[0], 0.0
+	for _, f := range supported {
+		if q := quality(accept, f.contentType); q > bestQuality {
+			best, bestQuality = f, q
+		}
+	}
+	return best, nil
+}
+
+// quality returns the quality of a content type in an Accept header, given by the most specific media range
+// matching it, or 0 if it is not acceptable
+func quality(accept, contentType string) float64 {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	typ := strings.SplitN(mediaType, "/", 2)[0]
+
+	q, specificity := 0.0, -1
+	for _, accepted := range strings.Split(accept, ",") {
+		acceptedType, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		s := -1
+		switch acceptedType {
+		case mediaType:
+			s = 2
+		case typ + "/*":
+			s = 1
+		case "*/*":
+			s = 0
+		}
+		if s <= specificity {
+			continue
+		}
+		specificity, q = s, 1
+		if value, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+	}
+	return q
+}
+
+// bodyWriter is the writer of a response body, which only writes the status of the response along with the
+// first bytes of the body, so that a body failing to be encoded can still be replaced by an error
+type bodyWriter struct {
+	http.ResponseWriter
+	status  int
+	written bool
+}
+
+// Write writes the status of the response before the first bytes of the body
+func (w *bodyWriter) Write(b []byte) (int, error) {
+	if !w.written {
+		w.written = true
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	n, err := w.ResponseWriter.Write(b)
+	return n, errors.Wrap(err, "failed to write response body")
+}
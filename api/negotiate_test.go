@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// negotiated returns the name of the format negotiated for a request among JSON and CSV, or the error
+func negotiated(url, accept string) (string, error) {
+	r := httptest.NewRequest(http.MethodGet, url, nil)
+	if accept != "" {
+		r.Header.Set("Accept", accept)
+	}
+	f, err := negotiate(r, []*format{jsonFormat, csvFormat})
+	if err != nil {
+		return "", err
+	}
+	return f.name, nil
+}
+
+// mustNegotiate returns the name of the format negotiated for a request, asserting that it is supported
+func mustNegotiate(url, accept string) string {
+	name, err := negotiated(url, accept)
+	So(err, ShouldBeNil)
+	return name
+}
+
+func TestNegotiate(t *testing.T) {
+	Convey("JSON is the default format", t, func() {
+		So(mustNegotiate("/codes", ""), ShouldEqual, formatJSON)
+		So(mustNegotiate("/codes", "*/*"), ShouldEqual, formatJSON)
+	})
+
+	Convey("A format can be requested with the Accept header", t, func() {
+		So(mustNegotiate("/codes", "text/csv"), ShouldEqual, formatCSV)
+		So(mustNegotiate("/codes", "text/*, application/json;q=0.5"), ShouldEqual, formatCSV)
+	})
+
+	Convey("The format with the highest quality in the Accept header is chosen", t, func() {
+		So(mustNegotiate("/codes", "text/csv;q=0.5, application/json"), ShouldEqual, formatJSON)
+		So(mustNegotiate("/codes", "text/csv, */*;q=0.1"), ShouldEqual, formatCSV)
+	})
+
+	Convey("JSON is used if no format is acceptable", t, func() {
+		So(mustNegotiate("/codes", "text/html"), ShouldEqual, formatJSON)
+		So(mustNegotiate("/codes", "text/csv;q=0"), ShouldEqual, formatJSON)
+	})
+
+	Convey("The format query parameter takes precedence over the Accept header", t, func() {
+		So(mustNegotiate("/codes?format=CSV", "application/json"), ShouldEqual, formatCSV)
+		So(mustNegotiate("/codes?format=json", "text/csv"), ShouldEqual, formatJSON)
+	})
+
+	Convey("An unsupported format query parameter is an error", t, func() {
+		_, err := negotiated("/codes?format=xlsx", "")
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestWriteBody(t *testing.T) {
+	Convey("Given an API over an edition with two codes", t, func() {
+		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: func(ctx context.Context, codeListID string, edition string) (int64, error) {
+				return 2, nil
+			},
+			GetCodesFunc: func(ctx context.Context, codeListID string, editionID string) (*dbmodels.CodeResults, error) {
+				return &dbmodels.CodeResults{Items: []dbmodels.Code{dbCode1, {Code: "a,b", Label: `with "quotes"`}}}, nil
+			},
+			GetCodeFunc: func(ctx context.Context, codeListID string, editionID string, codeID string) (*dbmodels.Code, error) {
+				return &dbCode1, nil
+			},
+		}
+		router := mux.NewRouter()
+		CreateCodeListAPI(router, mockDatastore, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+		codesURL := fmt.Sprintf("%s/code-lists/%s/editions/%s/codes", codeListURL, codeListID1, editionID1)
+
+		Convey("When codes are requested as CSV, then every code is returned as a row", func() {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, codesURL+"?limit=1", nil)
+			r.Header.Set("Accept", "text/csv")
+			router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(contentTypeHeader), ShouldEqual, "text/csv; charset=utf-8")
+			So(w.Header().Get("Vary"), ShouldEqual, "Accept")
+			So(w.Body.String(), ShouldEqual, "code,label\ntestCode1,test one\n\"a,b\",\"with \"\"quotes\"\"\"\n")
+		})
+
+		Convey("When codes are requested with ?format=csv, then they are returned as CSV", func() {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, codesURL+"?format=csv", nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(contentTypeHeader), ShouldEqual, "text/csv; charset=utf-8")
+		})
+
+		Convey("When a code is requested with ?format=csv, then 406 is returned as it has no CSV representation", func() {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, codesURL+"/"+codeID1+"?format=csv", nil))
+
+			So(w.Code, ShouldEqual, http.StatusNotAcceptable)
+		})
+
+		Convey("When a code is requested with an Accept: text/csv header, then it is returned as JSON", func() {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, codesURL+"/"+codeID1, nil)
+			r.Header.Set("Accept", "text/csv")
+			router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(contentTypeHeader), ShouldEqual, contentTypeJSON)
+		})
+	})
+}
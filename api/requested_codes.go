@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/pkg/errors"
+)
+
+// The lookup and validate endpoints both take a list of code IDs, and get the codes of an edition for them.
+
+// decodeCodeIDs decodes the code IDs provided as a JSON array in the request body
+func decodeCodeIDs(w http.ResponseWriter, r *http.Request) ([]string, error) {
+	var codeIDs []string
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(&codeIDs); err != nil {
+		return nil, err
+	}
+	return codeIDs, nil
+}
+
+// getRequestedCodes gets the codes of an edition for the requested code IDs, without duplicates. It writes an
+// error response and returns false if the number of code IDs is not allowed, or the codes could not be got.
+func (c *CodeListAPI) getRequestedCodes(ctx context.Context, w http.ResponseWriter, endpoint, id, edition string, codeIDs []string, data log.Data) ([]dbmodels.Code, bool) {
+	data["codes"] = len(codeIDs)
+
+	if err := c.validateCodeIDs(codeIDs); err != nil {
+		log.Event(ctx, endpoint+" endpoint: invalid request", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+
+	dbCodes, err := datastore.GetCodesByID(ctx, c.store, id, edition, codeIDs)
+	if err != nil {
+		handleError(ctx, endpoint+" endpoint: failed to get codes from store", data, err, w)
+		return nil, false
+	}
+	return dbCodes, true
+}
+
+// validateCodeIDs checks that at least one, and no more than the maximum limit of code IDs were requested
+func (c *CodeListAPI) validateCodeIDs(codeIDs []string) error {
+	if len(codeIDs) == 0 {
+		return errors.New("at least one code must be provided")
+	}
+	if len(codeIDs) > c.maxLimit {
+		return errors.New("number of codes is greater than the maximum allowed")
+	}
+	return nil
+}
+
+// unique returns the values without duplicates, in the order they were first found
+func unique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
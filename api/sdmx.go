@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/dp-code-list-api/sdmx"
+)
+
+const (
+	contentTypeSDMX     = "application/vnd.sdmx.structure+xml; version=2.1"
+	contentTypeSDMXJSON = "application/vnd.sdmx.structure+json; version=1.0"
+
+	formatSDMX     = "sdmx"
+	formatSDMXJSON = "sdmx-json"
+)
+
+// sdmxBody is implemented by the response bodies which can be written as an SDMX structure message
+type sdmxBody interface {
+	SDMX() *sdmx.Message
+}
+
+// sdmxFormat returns an SDMX format, writing the structure message of a body
+func sdmxFormat(name, contentType string, write func(m *sdmx.Message, w io.Writer) error) *format {
+	return &format{
+		name:        name,
+		contentType: contentType,
+		supports: func(body interface{}) bool {
+			_, ok := body.(sdmxBody)
+			return ok
+		},
+		write: func(w io.Writer, body interface{}) error {
+			return write(body.(sdmxBody).SDMX(), w)
+		},
+	}
+}
+
+var (
+	sdmxMLFormat   = sdmxFormat(formatSDMX, contentTypeSDMX, (*sdmx.Message).WriteML)
+	sdmxJSONFormat = sdmxFormat(formatSDMXJSON, contentTypeSDMXJSON, (*sdmx.Message).WriteJSON)
+)
+
+// sdmxEdition is an edition along with the structure message of its codelist, which is written in the SDMX
+// formats, while the edition itself is written in the other formats
+type sdmxEdition struct {
+	*models.Edition
+	message *sdmx.Message
+}
+
+// SDMX returns the structure message of the codelist of the edition
+func (e *sdmxEdition) SDMX() *sdmx.Message {
+	return e.message
+}
+
+// wantsSDMX reports whether a request for an edition asks for one of the SDMX formats, if they are enabled
+func (c *CodeListAPI) wantsSDMX(r *http.Request, edition *models.Edition) bool {
+	if c.sdmxAgencyID == "" {
+		return false
+	}
+	f, err := negotiate(r, supportedFormats(&sdmxEdition{Edition: edition}))
+	return err == nil && (f == sdmxMLFormat || f == sdmxJSONFormat)
+}
+
+// sdmxEdition returns an edition along with the structure message of its codelist, containing every code of
+// the edition and their parent, for stores holding hierarchies. An *sdmx.InvalidIDError is returned if the
+// codelist or one of its codes is not identified by a valid SDMX identifier.
+func (c *CodeListAPI) sdmxEdition(ctx context.Context, edition *models.Edition, codeListID string) (*sdmxEdition, error) {
+	codes, err := datastore.GetAllCodes(ctx, c.store, codeListID, edition.ID)
+	if err != nil {
+		return nil, err
+	}
+	parents, err := datastore.GetParentCodeIDs(ctx, c.store, codeListID, edition.ID)
+	if err != nil && err != datastore.ErrNotSupported {
+		return nil, err
+	}
+
+	codelist := newSDMXCodelist(edition, codeListID, c.sdmxAgencyID, c.sdmxVersion, codes, parents)
+	if err := codelist.Validate(); err != nil {
+		return nil, err
+	}
+	return &sdmxEdition{Edition: edition, message: sdmx.NewMessage(codelist, time.Now())}, nil
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetEditionSDMX(t *testing.T) {
+	Convey("Given an API exporting SDMX codelists, over a hierarchy where testCode2 is the only child of testCode1", t, func() {
+		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: countEditionCodes,
+			GetEditionFunc: func(ctx context.Context, f, e string) (*dbmodels.Edition, error) {
+				return &dbEdition1, nil
+			},
+			GetCodesFunc: func(ctx context.Context, codeListID string, editionID string) (*dbmodels.CodeResults, error) {
+				return &dbmodels.CodeResults{Items: []dbmodels.Code{dbCode1, dbCode2}}, nil
+			},
+		}
+		store := struct {
+			*storetest.DataStoreMock
+			*storetest.HierarchyMock
+		}{mockDatastore, newHierarchyMock()}
+		api := CreateCodeListAPI(mux.NewRouter(), store, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit, WithSDMX("ONS", "1.0"))
+		editionURL := fmt.Sprintf("%s/code-lists/%s/editions/%s", codeListURL, codeListID1, editionID1)
+
+		Convey("When an edition is requested with ?format=sdmx, then its codelist is returned as SDMX-ML", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, editionURL+"?format=sdmx", nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(contentTypeHeader), ShouldEqual, contentTypeSDMX)
+			So(w.Body.String(), ShouldContainSubstring, fmt.Sprintf(`<structure:Codelist id="%s_%s" agencyID="ONS" version="1.0" isFinal="false"`, codeListID1, editionID1))
+			So(w.Body.String(), ShouldContainSubstring, fmt.Sprintf(`<structure:Code id="%s"`, codeID1))
+			So(w.Body.String(), ShouldContainSubstring, fmt.Sprintf(`<structure:Parent><Ref id="%s"/></structure:Parent>`, codeID1))
+		})
+
+		Convey("When an edition is requested as SDMX-JSON, then its codelist is returned as SDMX-JSON", func() {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, editionURL, nil)
+			r.Header.Set("Accept", "application/vnd.sdmx.structure+json")
+			api.router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(contentTypeHeader), ShouldEqual, contentTypeSDMXJSON)
+			var message struct {
+				Data struct {
+					Codelists []struct {
+						AgencyID string `json:"agencyID"`
+						Name     string `json:"name"`
+						Codes    []struct {
+							ID     string `json:"id"`
+							Parent string `json:"parent"`
+						} `json:"codes"`
+					} `json:"codelists"`
+				} `json:"data"`
+			}
+			So(json.Unmarshal(w.Body.Bytes(), &message), ShouldBeNil)
+			So(message.Data.Codelists, ShouldHaveLength, 1)
+			So(message.Data.Codelists[0].Name, ShouldEqual, dbEdition1.Label)
+			So(message.Data.Codelists[0].Codes, ShouldHaveLength, 2)
+			So(message.Data.Codelists[0].Codes[1].Parent, ShouldEqual, codeID1)
+		})
+
+		Convey("When an edition is requested as JSON, then its codes are not fetched", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, editionURL, nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(mockDatastore.GetCodesCalls(), ShouldBeEmpty)
+			validateBody(w.Body, &models.Edition{}, &expectedEdition1)
+		})
+
+		Convey("When codes are requested with ?format=sdmx, then 406 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, editionURL+"/codes?format=sdmx", nil))
+
+			So(w.Code, ShouldEqual, http.StatusNotAcceptable)
+		})
+
+		Convey("When a code of the edition is not a valid SDMX identifier, then 406 is returned", func() {
+			mockDatastore.GetCodesFunc = func(ctx context.Context, codeListID string, editionID string) (*dbmodels.CodeResults, error) {
+				return &dbmodels.CodeResults{Items: []dbmodels.Code{{Code: "E12000001 (old)", Label: "North East"}}}, nil
+			}
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, editionURL+"?format=sdmx", nil))
+
+			So(w.Code, ShouldEqual, http.StatusNotAcceptable)
+			So(w.Body.String(), ShouldContainSubstring, "not a valid SDMX identifier")
+		})
+
+		Convey("When the codes of the edition cannot be fetched, then 500 is returned", func() {
+			mockDatastore.GetCodesFunc = func(ctx context.Context, codeListID string, editionID string) (*dbmodels.CodeResults, error) {
+				return nil, errors.New("store error")
+			}
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, editionURL+"?format=sdmx", nil))
+
+			So(w.Code, ShouldEqual, http.StatusInternalServerError)
+		})
+	})
+
+	Convey("Given an API which does not export SDMX codelists", t, func() {
+		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: countEditionCodes,
+			GetEditionFunc: func(ctx context.Context, f, e string) (*dbmodels.Edition, error) {
+				return &dbEdition1, nil
+			},
+		}
+		api := CreateCodeListAPI(mux.NewRouter(), mockDatastore, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When an edition is requested with ?format=sdmx, then 406 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/code-lists/%s/editions/%s?format=sdmx", codeListURL, codeListID1, editionID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusNotAcceptable)
+		})
+	})
+}
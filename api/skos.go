@@ -0,0 +1,202 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/dp-code-list-api/skos"
+)
+
+const (
+	contentTypeTurtle = "text/turtle"
+	contentTypeJSONLD = "application/ld+json"
+	contentTypeRDFXML = "application/rdf+xml"
+
+	formatTurtle = "turtle"
+	formatJSONLD = "jsonld"
+	formatRDFXML = "rdfxml"
+)
+
+// skosFormat returns a linked data format, writing the SKOS graph of a body
+func skosFormat(name, contentType string, write func(g *skos.Graph, w io.Writer) error) *format {
+	return &format{
+		name:        name,
+		contentType: contentType,
+		supports: func(body interface{}) bool {
+			switch body.(type) {
+			case *models.CodeList, *models.CodeListResults, *models.Edition, *sdmxEdition, *models.Editions, *models.Code, *models.CodeResults:
+				return true
+			}
+			return false
+		},
+		write: func(w io.Writer, body interface{}) error {
+			return write(skosGraph(body), w)
+		},
+	}
+}
+
+var (
+	turtleFormat = skosFormat(formatTurtle, contentTypeTurtle+"; charset=utf-8", (*skos.Graph).WriteTurtle)
+	jsonLDFormat = skosFormat(formatJSONLD, contentTypeJSONLD, (*skos.Graph).WriteJSONLD)
+	rdfXMLFormat = skosFormat(formatRDFXML, contentTypeRDFXML+"; charset=utf-8", (*skos.Graph).WriteRDFXML)
+)
+
+// wantsSKOS reports whether a request asks for one of the linked data formats, among the formats supporting
+// the body of its response
+func wantsSKOS(r *http.Request, body interface{}) bool {
+	f, err := negotiate(r, supportedFormats(body))
+	return err == nil && (f == turtleFormat || f == jsonLDFormat || f == rdfXMLFormat)
+}
+
+// updateBroaderLinks adds the hierarchy links of the codes of an edition, so that their parent is described as
+// their broader concept. Codes are left as they are for stores that do not hold hierarchies.
+func (c *CodeListAPI) updateBroaderLinks(ctx context.Context, codes *models.CodeResults, codeListID, edition string) error {
+	parents, err := datastore.GetParentCodeIDs(ctx, c.store, codeListID, edition)
+	if err == datastore.ErrNotSupported {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for i := range codes.Items {
+		if err := codes.Items[i].UpdateHierarchyLinks(c.apiURL, codeListID, edition, parents[codes.Items[i].ID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Code lists and editions are described as SKOS concept schemes, and codes as concepts of the scheme of their
+// edition. Resources are identified by the hrefs of their self links, so the graphs of resources without links
+// are empty.
+
+// skosGraph returns the graph describing a body supported by the linked data formats
+func skosGraph(body interface{}) *skos.Graph {
+	switch b := body.(type) {
+	case *models.CodeList:
+		return codeListSKOS(b)
+	case *models.CodeListResults:
+		return codeListsSKOS(b)
+	case *models.Edition:
+		return editionSKOS(b)
+	case *sdmxEdition:
+		return editionSKOS(b.Edition)
+	case *models.Editions:
+		return editionsSKOS(b)
+	case *models.Code:
+		return codeSKOS(b)
+	case *models.CodeResults:
+		return codesSKOS(b)
+	}
+	return skos.NewGraph()
+}
+
+// codeListSKOS returns the graph describing a code list as a concept scheme
+func codeListSKOS(c *models.CodeList) *skos.Graph {
+	g := skos.NewGraph()
+	if c.Links == nil || c.Links.Self == nil {
+		return g
+	}
+	uri := c.Links.Self.Href
+
+	g.Add(uri, skos.Type, skos.IRI(skos.ConceptScheme))
+	g.Add(uri, skos.Notation, skos.Literal(c.ID))
+	if c.Label != "" {
+		g.Add(uri, skos.PrefLabel, skos.Label(c.Label))
+	}
+	if c.Description != "" {
+		g.Add(uri, skos.Description, skos.Label(c.Description))
+	}
+	return g
+}
+
+// codeListsSKOS returns the graph describing code lists as concept schemes
+func codeListsSKOS(r *models.CodeListResults) *skos.Graph {
+	g := skos.NewGraph()
+	for i := range r.Items {
+		g.Merge(codeListSKOS(&r.Items[i]))
+	}
+	return g
+}
+
+// editionSKOS returns the graph describing an edition as a concept scheme, which is a version of the scheme of
+// its code list
+func editionSKOS(e *models.Edition) *skos.Graph {
+	g := skos.NewGraph()
+	if e.Links == nil || e.Links.Self == nil {
+		return g
+	}
+	uri := e.Links.Self.Href
+
+	g.Add(uri, skos.Type, skos.IRI(skos.ConceptScheme))
+	g.Add(uri, skos.VersionInfo, skos.Literal(e.ID))
+	if e.Label != "" {
+		g.Add(uri, skos.PrefLabel, skos.Label(e.Label))
+	}
+	if e.Links.Editions != nil {
+		g.Add(uri, skos.IsVersionOf, skos.IRI(strings.TrimSuffix(e.Links.Editions.Href, "/editions")))
+	}
+	if e.ReleaseDate != nil {
+		g.Add(uri, skos.Issued, skos.TypedLiteral(e.ReleaseDate.UTC().Format(time.RFC3339), skos.DateTime))
+	}
+	if e.Links.Supersedes != nil {
+		g.Add(uri, skos.Replaces, skos.IRI(e.Links.Supersedes.Href))
+	}
+	if e.Links.SupersededBy != nil {
+		g.Add(uri, skos.IsReplacedBy, skos.IRI(e.Links.SupersededBy.Href))
+	}
+	return g
+}
+
+// editionsSKOS returns the graph describing editions as concept schemes
+func editionsSKOS(e *models.Editions) *skos.Graph {
+	g := skos.NewGraph()
+	for i := range e.Items {
+		g.Merge(editionSKOS(&e.Items[i]))
+	}
+	return g
+}
+
+// codeSKOS returns the graph describing a code as a concept of the scheme of its edition. For codes with hierarchy
+// links, the parent is the broader concept, and codes without a parent are top concepts of the scheme.
+func codeSKOS(c *models.Code) *skos.Graph {
+	g := skos.NewGraph()
+	if c.Links == nil || c.Links.Self == nil {
+		return g
+	}
+	uri := c.Links.Self.Href
+
+	g.Add(uri, skos.Type, skos.IRI(skos.Concept))
+	g.Add(uri, skos.Notation, skos.Literal(c.ID))
+	if c.Label != "" {
+		g.Add(uri, skos.PrefLabel, skos.Label(c.Label))
+	}
+
+	// the self link of a code is under the codes of its edition
+	scheme := ""
+	if i := strings.LastIndex(uri, "/codes/"); i >= 0 {
+		scheme = uri[:i]
+		g.Add(uri, skos.InScheme, skos.IRI(scheme))
+	}
+	switch {
+	case c.Links.Parent != nil:
+		g.Add(uri, skos.Broader, skos.IRI(c.Links.Parent.Href))
+	case c.Links.Children != nil && scheme != "":
+		g.Add(uri, skos.TopConceptOf, skos.IRI(scheme))
+	}
+	return g
+}
+
+// codesSKOS returns the graph describing codes as concepts
+func codesSKOS(c *models.CodeResults) *skos.Graph {
+	g := skos.NewGraph()
+	for i := range c.Items {
+		g.Merge(codeSKOS(&c.Items[i]))
+	}
+	return g
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/dp-code-list-api/skos"
+	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWriteSKOS(t *testing.T) {
+	Convey("Given an API over a hierarchy where testCode2 is the only child of testCode1", t, func() {
+		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: func(ctx context.Context, codeListID string, edition string) (int64, error) {
+				return 2, nil
+			},
+			GetCodesFunc: func(ctx context.Context, codeListID string, editionID string) (*dbmodels.CodeResults, error) {
+				return &dbmodels.CodeResults{Items: []dbmodels.Code{dbCode1, dbCode2}}, nil
+			},
+			GetCodeFunc: func(ctx context.Context, codeListID string, editionID string, codeID string) (*dbmodels.Code, error) {
+				return &dbCode2, nil
+			},
+		}
+		router := mux.NewRouter()
+		CreateCodeListAPI(router, struct {
+			*storetest.DataStoreMock
+			*storetest.HierarchyMock
+		}{mockDatastore, newHierarchyMock()}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+		editionURL := fmt.Sprintf("%s/code-lists/%s/editions/%s", codeListURL, codeListID1, editionID1)
+		codesURL := editionURL + "/codes"
+
+		Convey("When codes are requested as Turtle, then every code is a concept whose parent is broader", func() {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, codesURL+"?limit=1", nil)
+			r.Header.Set("Accept", "text/turtle")
+			router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(contentTypeHeader), ShouldEqual, "text/turtle; charset=utf-8")
+			So(w.Body.String(), ShouldContainSubstring, "\n<"+codesURL+"/"+codeID1+">\n"+
+				"    a skos:Concept ;\n"+
+				"    skos:notation \""+codeID1+"\" ;\n"+
+				"    skos:prefLabel \"test one\"@en ;\n"+
+				"    skos:inScheme <"+editionURL+"> ;\n"+
+				"    skos:topConceptOf <"+editionURL+"> .\n")
+			So(w.Body.String(), ShouldContainSubstring, "    skos:broader <"+codesURL+"/"+codeID1+"> .\n")
+		})
+
+		Convey("When a code is requested with ?format=jsonld, then it is returned as JSON-LD", func() {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, codesURL+"/"+codeID2+"?format=jsonld", nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(contentTypeHeader), ShouldEqual, contentTypeJSONLD)
+			So(w.Body.String(), ShouldContainSubstring, `"skos:broader":{"@id":"`+codesURL+"/"+codeID1+`"}`)
+		})
+
+		Convey("When codes are requested as JSON, then they do not have hierarchy links", func() {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, codesURL, nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Body.String(), ShouldNotContainSubstring, "children")
+		})
+	})
+
+	Convey("Given an API over a store without hierarchies", t, func() {
+		mockDatastore := &storetest.DataStoreMock{
+			GetCodeListFunc: func(ctx context.Context, id string) (*dbmodels.CodeList, error) {
+				return &dbmodels.CodeList{ID: codeListID1}, nil
+			},
+			CountCodesFunc: func(ctx context.Context, codeListID string, edition string) (int64, error) {
+				return 1, nil
+			},
+			GetCodesFunc: func(ctx context.Context, codeListID string, editionID string) (*dbmodels.CodeResults, error) {
+				return &dbmodels.CodeResults{Items: []dbmodels.Code{dbCode1}}, nil
+			},
+		}
+		router := mux.NewRouter()
+		CreateCodeListAPI(router, mockDatastore, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When a code list is requested as RDF/XML, then it is described as a concept scheme", func() {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/code-lists/%s", codeListURL, codeListID1), nil)
+			r.Header.Set("Accept", "application/rdf+xml, application/json;q=0.5")
+			router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(contentTypeHeader), ShouldEqual, "application/rdf+xml; charset=utf-8")
+			So(w.Body.String(), ShouldContainSubstring, fmt.Sprintf("<rdf:Description rdf:about=\"%s/code-lists/%s\">", codeListURL, codeListID1))
+			So(w.Body.String(), ShouldContainSubstring, `<rdf:type rdf:resource="http://www.w3.org/2004/02/skos/core#ConceptScheme"/>`)
+		})
+
+		Convey("When codes are requested as Turtle, then they are concepts without broader concepts", func() {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/code-lists/%s/editions/%s/codes?format=turtle", codeListURL, codeListID1, editionID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(strings.Count(w.Body.String(), "a skos:Concept"), ShouldEqual, 1)
+			So(w.Body.String(), ShouldNotContainSubstring, "skos:broader")
+		})
+	})
+}
+
+const skosHost = "http://localhost:22400"
+
+// turtle returns the statements of a graph written as Turtle, without the prefixes
+func turtle(g *skos.Graph) string {
+	var b bytes.Buffer
+	So(g.WriteTurtle(&b), ShouldBeNil)
+	return strings.SplitN(b.String(), "\n\n", 2)[1]
+}
+
+func TestSKOSGraphs(t *testing.T) {
+	Convey("A code list is a concept scheme", t, func() {
+		codeList := &models.CodeList{ID: "local-authority", Label: "Local authority"}
+		So(codeList.UpdateLinks(skosHost), ShouldBeNil)
+		So(turtle(codeListSKOS(codeList)), ShouldEqual, "<http://localhost:22400/code-lists/local-authority>\n"+
+			"    a skos:ConceptScheme ;\n"+
+			"    skos:notation \"local-authority\" ;\n"+
+			"    skos:prefLabel \"Local authority\"@en .\n")
+	})
+
+	Convey("An edition is a version of the concept scheme of its code list", t, func() {
+		releaseDate := time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)
+		edition := &models.Edition{ID: "2020", Label: "Local authorities, 2020"}
+		So(edition.UpdateLinks("local-authority", skosHost), ShouldBeNil)
+		edition.ReleaseDate = &releaseDate
+		edition.UpdateSupersessionLinks("local-authority", skosHost, "2019", "")
+		So(turtle(editionSKOS(edition)), ShouldEqual, "<http://localhost:22400/code-lists/local-authority/editions/2020>\n"+
+			"    a skos:ConceptScheme ;\n"+
+			"    owl:versionInfo \"2020\" ;\n"+
+			"    skos:prefLabel \"Local authorities, 2020\"@en ;\n"+
+			"    dct:isVersionOf <http://localhost:22400/code-lists/local-authority> ;\n"+
+			"    dct:issued \"2020-04-01T00:00:00Z\"^^xsd:dateTime ;\n"+
+			"    dct:replaces <http://localhost:22400/code-lists/local-authority/editions/2019> .\n")
+	})
+
+	Convey("A code is a concept in the scheme of its edition, whose parent is broader", t, func() {
+		code := &models.Code{ID: "E06000001", Label: "Hartlepool"}
+		So(code.UpdateLinks(skosHost, "local-authority", "2020"), ShouldBeNil)
+		So(code.UpdateHierarchyLinks(skosHost, "local-authority", "2020", "E12000001"), ShouldBeNil)
+		So(turtle(codeSKOS(code)), ShouldEqual, "<http://localhost:22400/code-lists/local-authority/editions/2020/codes/E06000001>\n"+
+			"    a skos:Concept ;\n"+
+			"    skos:notation \"E06000001\" ;\n"+
+			"    skos:prefLabel \"Hartlepool\"@en ;\n"+
+			"    skos:inScheme <http://localhost:22400/code-lists/local-authority/editions/2020> ;\n"+
+			"    skos:broader <http://localhost:22400/code-lists/local-authority/editions/2020/codes/E12000001> .\n")
+	})
+
+	Convey("A code without a parent in a hierarchy is a top concept of its scheme", t, func() {
+		code := &models.Code{ID: "E92000001", Label: "England"}
+		So(code.UpdateLinks(skosHost, "country", "2020"), ShouldBeNil)
+		So(code.UpdateHierarchyLinks(skosHost, "country", "2020", ""), ShouldBeNil)
+		So(turtle(codeSKOS(code)), ShouldEndWith, "    skos:topConceptOf <http://localhost:22400/code-lists/country/editions/2020> .\n")
+	})
+
+	Convey("Lists describe every item with links, and resources without links are not described", t, func() {
+		codes := &models.CodeResults{Items: []models.Code{{ID: "E06000001"}, {ID: "E06000002"}}}
+		So(codesSKOS(codes).Len(), ShouldEqual, 0)
+		So(codes.Items[1].UpdateLinks(skosHost, "local-authority", "2020"), ShouldBeNil)
+		So(codesSKOS(codes).Len(), ShouldEqual, 1)
+
+		editions := &models.Editions{Items: []models.Edition{{ID: "2019"}, {ID: "2020"}}}
+		for i := range editions.Items {
+			So(editions.Items[i].UpdateLinks("local-authority", skosHost), ShouldBeNil)
+		}
+		So(editionsSKOS(editions).Len(), ShouldEqual, 2)
+
+		codeLists := &models.CodeListResults{Items: []models.CodeList{{ID: "local-authority"}}}
+		So(codeListsSKOS(codeLists).Len(), ShouldEqual, 0)
+	})
+}
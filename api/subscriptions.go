@@ -0,0 +1,143 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// postSubscription registers a callback URL for the events of code lists. The response is the only one
+// including the secret signing the payloads, which is generated if it is not provided.
+func (c *CodeListAPI) postSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	log.Event(ctx, "postSubscription endpoint: attempting to create subscription", log.INFO)
+
+	var request models.SubscriptionRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(&request); err != nil {
+		log.Event(ctx, "postSubscription endpoint: failed to parse request body", log.ERROR, log.Error(err))
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	data := log.Data{"url_host": callbackHost(request.URL)}
+
+	if err := request.Validate(); err != nil {
+		log.Event(ctx, "postSubscription endpoint: invalid request", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	subscription, err := newWebhookSubscription(&request)
+	if err != nil {
+		log.Event(ctx, "postSubscription endpoint: invalid request", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	subscription, err = c.subscriptions.Add(subscription)
+	if err != nil {
+		handleError(ctx, "postSubscription endpoint: failed to add subscription", data, err, w)
+		return
+	}
+	data["subscription_id"] = subscription.ID
+
+	subscriptionModel := newSubscription(subscription)
+	subscriptionModel.Secret = subscription.Secret
+	if err := subscriptionModel.UpdateLinks(c.apiURL); err != nil {
+		log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "postSubscription endpoint: links could not be created")))
+		http.Error(w, internalServerErr, http.StatusInternalServerError)
+		return
+	}
+
+	status := changeStatus(w, true, subscriptionModel.Links.Self.Href)
+	if err := c.writeResponse(w, r, status, subscriptionModel); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "postSubscription endpoint: failed to write bytes to response")), data)
+		return
+	}
+
+	log.Event(ctx, "postSubscription endpoint: request successful", log.INFO, data)
+}
+
+// getSubscription returns a subscription, without its secret
+func (c *CodeListAPI) getSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := mux.Vars(r)["id"]
+	data := log.Data{"subscription_id": id}
+
+	log.Event(ctx, "getSubscription endpoint: attempting to get subscription", log.INFO, data)
+
+	subscription, err := c.subscriptions.Get(id)
+	if err != nil {
+		handleError(ctx, "getSubscription endpoint: failed to get subscription", data, err, w)
+		return
+	}
+
+	subscriptionModel := newSubscription(subscription)
+	if err := subscriptionModel.UpdateLinks(c.apiURL); err != nil {
+		log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "getSubscription endpoint: links could not be created")))
+		http.Error(w, internalServerErr, http.StatusInternalServerError)
+		return
+	}
+
+	if err := c.writeBody(w, r, subscriptionModel); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "getSubscription endpoint: failed to write bytes to response")), data)
+		return
+	}
+
+	log.Event(ctx, "getSubscription endpoint: request successful", log.INFO, data)
+}
+
+// deleteSubscription removes a subscription and its dead-letter list. Deliveries in progress are not retried.
+func (c *CodeListAPI) deleteSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := mux.Vars(r)["id"]
+	data := log.Data{"subscription_id": id}
+
+	log.Event(ctx, "deleteSubscription endpoint: attempting to delete subscription", log.INFO, data)
+
+	if err := c.subscriptions.Remove(id); err != nil {
+		handleError(ctx, "deleteSubscription endpoint: failed to delete subscription", data, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Event(ctx, "deleteSubscription endpoint: request successful", log.INFO, data)
+}
+
+// getSubscriptionFailures returns the dead-letter list of a subscription, with the events which could not be
+// delivered to it after every attempt
+func (c *CodeListAPI) getSubscriptionFailures(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := mux.Vars(r)["id"]
+	data := log.Data{"subscription_id": id}
+
+	log.Event(ctx, "getSubscriptionFailures endpoint: attempting to get subscription failures", log.INFO, data)
+
+	failures, err := c.subscriptions.Failures(id)
+	if err != nil {
+		handleError(ctx, "getSubscriptionFailures endpoint: failed to get subscription failures", data, err, w)
+		return
+	}
+
+	if err := c.writeBody(w, r, newSubscriptionFailures(failures)); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "getSubscriptionFailures endpoint: failed to write bytes to response")), data)
+		return
+	}
+
+	log.Event(ctx, "getSubscriptionFailures endpoint: request successful", log.INFO, data)
+}
+
+// callbackHost returns the host of the callback url of a subscription, which is logged rather than the url as
+// its path and query may hold credentials
+func callbackHost(callbackURL string) string {
+	u, err := url.Parse(callbackURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+const defaultSuggestLimit = 10
+
+func (c *CodeListAPI) getSuggestions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	edition := vars["edition"]
+	prefix := r.URL.Query().Get("prefix")
+	limitParameter := r.URL.Query().Get("limit")
+	limit := defaultSuggestLimit
+	data := log.Data{"codelist_id": id, "edition": edition, "prefix": prefix}
+	var err error
+
+	log.Event(ctx, "getSuggestions endpoint: attempting to suggest codes", log.INFO, data)
+
+	if prefix == "" {
+		err = errors.New("prefix query parameter is required")
+		log.Event(ctx, "invalid query parameter: prefix", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if limitParameter != "" {
+		data["limit"] = limitParameter
+		limit, err = ValidatePositiveInt(limitParameter)
+		if err != nil {
+			log.Event(ctx, "invalid query parameter: limit", log.ERROR, log.Error(err), data)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if limit > c.maxLimit {
+		data["max_limit"] = c.maxLimit
+		err = errors.New("limit is greater than the maximum allowed")
+		log.Event(ctx, "invalid query parameter: limit, maximum limit reached", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dbCodes, err := c.suggestions.Suggest(ctx, c.store, id, edition, prefix, limit)
+	if err != nil {
+		handleError(ctx, "getSuggestions endpoint: failed to suggest codes", data, err, w)
+		return
+	}
+
+	suggestions := models.NewSuggestions(dbCodes)
+
+	for i, item := range suggestions.Items {
+		if err := item.UpdateLinks(c.apiURL, id, edition); err != nil {
+			log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "getSuggestions endpoint: links could not be created")))
+			http.Error(w, internalServerErr, http.StatusInternalServerError)
+			return
+		}
+		suggestions.Items[i] = item
+	}
+
+	suggestions.Count = len(suggestions.Items)
+	suggestions.Limit = limit
+
+	if err := c.writeBody(w, r, suggestions); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "getSuggestions endpoint: failed to write bytes to response")))
+		return
+	}
+
+	log.Event(ctx, "getSuggestions endpoint: request successful", log.INFO, data)
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetSuggestions(t *testing.T) {
+	Convey("Given a store containing an edition", t, func() {
+		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: func(ctx context.Context, codeListID string, editionID string) (int64, error) {
+				if editionID != editionID1 {
+					return 0, driver.ErrNotFound
+				}
+				return 2, nil
+			},
+			GetCodesFunc: func(ctx context.Context, codeListID string, editionID string) (*dbmodels.CodeResults, error) {
+				return &dbmodels.CodeResults{Items: []dbmodels.Code{dbCode1, dbCode2}}, nil
+			},
+		}
+		api := CreateCodeListAPI(mux.NewRouter(), mockDatastore, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When codes are suggested for a prefix, then the codes whose label starts with it are returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/suggest?prefix=TEST&limit=1", codeListURL, codeListID1, editionID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.Suggestions{}, &models.Suggestions{
+				Items: []models.Suggestion{{
+					ID:    codeID1,
+					Label: "test one",
+					Links: &models.SelfLinks{
+						Self: &models.Link{
+							ID:   codeID1,
+							Href: fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s", codeListURL, codeListID1, editionID1, codeID1),
+						},
+					},
+				}},
+				Count: 1,
+				Limit: 1,
+			})
+		})
+
+		Convey("When codes are suggested without a prefix, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/suggest", codeListURL, codeListID1, editionID1), nil))
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("When codes are suggested with an invalid limit, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/suggest?prefix=t&limit=x", codeListURL, codeListID1, editionID1), nil))
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("When codes are suggested for an unknown edition, then 404 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/suggest?prefix=t", codeListURL, codeListID1, editionID2), nil))
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
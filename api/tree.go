@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// hierarchyFlushSize is the number of codes written to the response between flushes when streaming a hierarchy
+const hierarchyFlushSize = 500
+
+// hierarchyCSVHeader is the header of a hierarchy streamed as CSV
+var hierarchyCSVHeader = []string{"code", "label", "parent", "depth"}
+
+// getHierarchy streams every code of an edition in depth-first order, as {"items":[...],"count":n}, or as
+// code,label,parent,depth CSV rows. Codes are written as they are converted, so that a large tree is never held
+// in the response as a whole. Once the first code is written the status can no longer change, so a failure
+// ends the response early and leaves an incomplete document.
+func (c *CodeListAPI) getHierarchy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	edition := vars["edition"]
+	data := log.Data{"codelist_id": id, "edition": edition}
+
+	log.Event(ctx, "getHierarchy endpoint: attempting to get hierarchy of edition", log.INFO, data)
+
+	tree, err := datastore.GetCodeTree(ctx, c.store, id, edition)
+	if err != nil {
+		handleError(ctx, "getHierarchy endpoint: failed to get code tree from store", data, err, w)
+		return
+	}
+
+	f, err := negotiate(r, []*format{jsonFormat, csvFormat})
+	w.Header().Add("Vary", "Accept")
+	if err != nil {
+		log.Event(ctx, "getHierarchy endpoint: format not supported", log.WARN, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusNotAcceptable)
+		return
+	}
+
+	w.Header().Set(contentTypeHeader, f.contentType)
+	if f == csvFormat {
+		err = writeHierarchyCSV(w, tree)
+	} else {
+		err = writeHierarchy(w, tree, c.apiURL, id, edition)
+	}
+	if err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "getHierarchy endpoint: failed to stream hierarchy")), data)
+		return
+	}
+
+	data["count"] = len(tree)
+	log.Event(ctx, "getHierarchy endpoint: request successful", log.INFO, data)
+}
+
+// writeHierarchy writes the codes of a tree as a JSON document, flushing the response periodically
+func writeHierarchy(w http.ResponseWriter, tree []datastore.TreeCode, host, codeListID, edition string) error {
+	flusher, _ := w.(http.Flusher)
+
+	if _, err := io.WriteString(w, `{"items":[`); err != nil {
+		return err
+	}
+	for i, treeCode := range tree {
+		item := newHierarchyCode(treeCode)
+		if err := item.UpdateLinks(host, codeListID, edition); err != nil {
+			return errors.WithMessage(err, "links could not be created")
+		}
+		b, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			b = append([]byte{','}, b...)
+		}
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+		if flusher != nil && (i+1)%hierarchyFlushSize == 0 {
+			flusher.Flush()
+		}
+	}
+	_, err := io.WriteString(w, `],"count":`+strconv.Itoa(len(tree))+`}`)
+	return err
+}
+
+// writeHierarchyCSV writes the codes of a tree as CSV rows, flushing the response periodically
+func writeHierarchyCSV(w http.ResponseWriter, tree []datastore.TreeCode) error {
+	flusher, _ := w.(http.Flusher)
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(hierarchyCSVHeader); err != nil {
+		return err
+	}
+	for i, treeCode := range tree {
+		if err := cw.Write([]string{treeCode.Code.Code, treeCode.Code.Label, treeCode.Parent, strconv.Itoa(treeCode.Depth)}); err != nil {
+			return err
+		}
+		if (i+1)%hierarchyFlushSize == 0 {
+			cw.Flush()
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
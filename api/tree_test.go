@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type hierarchyBody struct {
+	Items []models.HierarchyCode `json:"items"`
+	Count int                    `json:"count"`
+}
+
+func TestGetHierarchy(t *testing.T) {
+	Convey("Given a store holding a hierarchy of codes", t, func() {
+		mockDatastore := &storetest.DataStoreMock{
+			CountCodesFunc: func(ctx context.Context, codeListID string, editionID string) (int64, error) {
+				return 2, nil
+			},
+			GetCodesFunc: func(ctx context.Context, codeListID string, editionID string) (*dbmodels.CodeResults, error) {
+				return &dbmodels.CodeResults{Items: []dbmodels.Code{dbCode2, dbCode1}}, nil
+			},
+		}
+		api := CreateCodeListAPI(mux.NewRouter(), struct {
+			*storetest.DataStoreMock
+			*storetest.HierarchyMock
+		}{mockDatastore, newHierarchyMock()}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When the hierarchy of an edition is requested, then every code is returned depth-first", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/hierarchy", codeListURL, codeListID1, editionID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(contentTypeHeader), ShouldEqual, contentTypeJSON)
+			validateBody(w.Body, &hierarchyBody{}, &hierarchyBody{
+				Items: []models.HierarchyCode{
+					{
+						ID:    codeID1,
+						Label: "test one",
+						Links: &models.SelfLinks{Self: expectedCode1.Links.Self},
+					},
+					{
+						ID:     codeID2,
+						Label:  "test two",
+						Parent: codeID1,
+						Depth:  1,
+						Links:  &models.SelfLinks{Self: expectedCode2.Links.Self},
+					},
+				},
+				Count: 2,
+			})
+		})
+
+		Convey("When the hierarchy of an edition is requested as CSV, then every code is returned as a row", func() {
+			r := httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/hierarchy", codeListURL, codeListID1, editionID1), nil)
+			r.Header.Set("Accept", "text/csv")
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(contentTypeHeader), ShouldEqual, "text/csv; charset=utf-8")
+			So(w.Body.String(), ShouldEqual, "code,label,parent,depth\n"+codeID1+",test one,,0\n"+codeID2+",test two,"+codeID1+",1\n")
+		})
+
+		Convey("When the hierarchy of an edition is requested in an unsupported format, then 406 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/hierarchy?format=turtle", codeListURL, codeListID1, editionID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusNotAcceptable)
+		})
+	})
+
+	Convey("Given a store that does not support hierarchies", t, func() {
+		api := CreateCodeListAPI(mux.NewRouter(), &storetest.DataStoreMock{}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When the hierarchy of an edition is requested, then 501 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("%s/code-lists/%s/editions/%s/hierarchy", codeListURL, codeListID1, editionID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusNotImplemented)
+		})
+	})
+}
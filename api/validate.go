@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+func (c *CodeListAPI) validateCodes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	edition := vars["edition"]
+	data := log.Data{"codelist_id": id, "edition": edition}
+
+	log.Event(ctx, "validateCodes endpoint: attempting to validate codes", log.INFO, data)
+
+	codeIDs, err := decodeCodeIDs(w, r)
+	if err != nil {
+		log.Event(ctx, "validateCodes endpoint: failed to parse request body", log.ERROR, log.Error(err), data)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	codeIDs = unique(codeIDs)
+
+	dbCodes, ok := c.getRequestedCodes(ctx, w, "validateCodes", id, edition, codeIDs, data)
+	if !ok {
+		return
+	}
+
+	validation := models.NewCodeValidation(codeIDs, dbCodes)
+
+	for i, item := range validation.Valid {
+		if err := item.UpdateLinks(c.apiURL, id, edition); err != nil {
+			log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "validateCodes endpoint: links could not be created")))
+			http.Error(w, internalServerErr, http.StatusInternalServerError)
+			return
+		}
+		validation.Valid[i] = item
+	}
+
+	if err := c.writeBody(w, r, validation); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "validateCodes endpoint: failed to write bytes to response")))
+		return
+	}
+
+	data["unknown"] = validation.UnknownCount
+	log.Event(ctx, "validateCodes endpoint: request successful", log.INFO, data)
+}
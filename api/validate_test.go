@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestValidateCodes(t *testing.T) {
+	Convey("Given a store containing an edition", t, func() {
+		mockDatastore := &storetest.DataStoreMock{
+			GetEditionFunc: func(ctx context.Context, codeListID string, editionID string) (*dbmodels.Edition, error) {
+				if editionID != editionID1 {
+					return nil, driver.ErrNotFound
+				}
+				return &dbEdition1, nil
+			},
+			GetCodeFunc: func(ctx context.Context, codeListID string, editionID string, codeID string) (*dbmodels.Code, error) {
+				if codeID != codeID1 {
+					return nil, driver.ErrNotFound
+				}
+				return &dbCode1, nil
+			},
+		}
+		api := CreateCodeListAPI(mux.NewRouter(), mockDatastore, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+		url := fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/validate", codeListURL, codeListID1, editionID1)
+
+		Convey("When codes are validated, then the valid and unknown codes are returned in the requested order", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("POST", url, strings.NewReader(`["unknown","`+codeID1+`","unknown"]`)))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			validateBody(w.Body, &models.CodeValidation{}, &models.CodeValidation{
+				Valid: []models.ValidCode{{
+					ID:    codeID1,
+					Label: "test one",
+					Links: &models.SelfLinks{
+						Self: &models.Link{
+							ID:   codeID1,
+							Href: fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s", codeListURL, codeListID1, editionID1, codeID1),
+						},
+					},
+				}},
+				Unknown:      []string{"unknown"},
+				ValidCount:   1,
+				UnknownCount: 1,
+			})
+			So(mockDatastore.GetCodeCalls(), ShouldHaveLength, 2)
+		})
+
+		Convey("When the request body is not an array of codes, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("POST", url, strings.NewReader(`{"codes":[]}`)))
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("When no codes are provided, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("POST", url, strings.NewReader(`[]`)))
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("When codes are validated against an unknown edition, then 404 is returned", func() {
+			w := httptest.NewRecorder()
+			url := fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/validate", codeListURL, codeListID1, editionID2)
+			api.router.ServeHTTP(w, httptest.NewRequest("POST", url, strings.NewReader(`["`+codeID1+`"]`)))
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
@@ -0,0 +1,230 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/events"
+	"github.com/ONSdigital/dp-code-list-api/models"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+const locationHeader = "Location"
+
+// postCodeList creates a code list without editions
+func (c *CodeListAPI) postCodeList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	log.Event(ctx, "postCodeList endpoint: attempting to create code list", log.INFO)
+
+	var request models.CodeListRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(&request); err != nil {
+		log.Event(ctx, "postCodeList endpoint: failed to parse request body", log.ERROR, log.Error(err))
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	data := log.Data{"codelist_id": request.ID}
+
+	if err := request.Validate(); err != nil {
+		log.Event(ctx, "postCodeList endpoint: invalid request", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	metadata := codeListMetadata(&request)
+	if err := datastore.CreateCodeList(ctx, c.store, metadata); err != nil {
+		handleError(ctx, "postCodeList endpoint: failed to create code list in store", data, err, w)
+		return
+	}
+
+	c.publish(ctx, events.New(events.CodeListCreated, metadata.ID, ""))
+
+	codeList := &models.CodeList{ID: metadata.ID}
+	updateCodeListMetadata(codeList, &metadata)
+	if err := codeList.UpdateLinks(c.apiURL); err != nil {
+		log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "postCodeList endpoint: links could not be created")))
+		http.Error(w, internalServerErr, http.StatusInternalServerError)
+		return
+	}
+
+	status := changeStatus(w, true, codeList.Links.Self.Href)
+	if err := c.writeResponse(w, r, status, codeList); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "postCodeList endpoint: failed to write bytes to response")), data)
+		return
+	}
+
+	log.Event(ctx, "postCodeList endpoint: request successful", log.INFO, data)
+}
+
+// putEdition creates an edition without codes, or replaces the label and metadata of an existing edition
+func (c *CodeListAPI) putEdition(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	edition := vars["edition"]
+	data := log.Data{"codelist_id": id, "edition": edition}
+
+	log.Event(ctx, "putEdition endpoint: attempting to put edition", log.INFO, data)
+
+	if err := models.ValidateEditionID(edition); err != nil {
+		log.Event(ctx, "putEdition endpoint: invalid edition id", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var request models.EditionRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(&request); err != nil {
+		log.Event(ctx, "putEdition endpoint: failed to parse request body", log.ERROR, log.Error(err), data)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		log.Event(ctx, "putEdition endpoint: invalid request", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	update, err := editionUpdate(&request, edition)
+	if err != nil {
+		log.Event(ctx, "putEdition endpoint: invalid request", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	wasPublished := c.editionPublished(ctx, id, edition)
+	created, err := datastore.PutEdition(ctx, c.store, id, update)
+	if err != nil {
+		handleError(ctx, "putEdition endpoint: failed to put edition in store", data, err, w)
+		return
+	}
+	data["created"] = created
+	if datastore.Published(update.State, update.ReleaseDate) && (created || !wasPublished) {
+		c.publish(ctx, events.New(events.EditionPublished, id, edition))
+	}
+
+	editionModel := &models.Edition{ID: update.ID, Label: update.Label}
+	if err := editionModel.UpdateLinks(id, c.apiURL); err != nil {
+		log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "putEdition endpoint: links could not be created")))
+		http.Error(w, internalServerErr, http.StatusInternalServerError)
+		return
+	}
+	updateEditionMetadata(editionModel, id, c.apiURL, datastore.EditionMetadata{
+		ID:          update.ID,
+		ReleaseDate: update.ReleaseDate,
+		State:       update.State,
+		Supersedes:  update.Supersedes,
+	})
+
+	status := changeStatus(w, created, editionModel.Links.Self.Href)
+	if err := c.writeResponse(w, r, status, editionModel); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "putEdition endpoint: failed to write bytes to response")), data)
+		return
+	}
+
+	log.Event(ctx, "putEdition endpoint: request successful", log.INFO, data)
+}
+
+// putCode creates or replaces a code of an edition
+func (c *CodeListAPI) putCode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	edition := vars["edition"]
+	code := vars["code"]
+	data := log.Data{"codelist_id": id, "edition": edition, "code": code}
+
+	log.Event(ctx, "putCode endpoint: attempting to put code", log.INFO, data)
+
+	if edition == latestEdition {
+		err := errors.New("codes cannot be changed through the latest edition alias")
+		log.Event(ctx, "putCode endpoint: invalid edition", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var request models.CodeRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(&request); err != nil {
+		log.Event(ctx, "putCode endpoint: failed to parse request body", log.ERROR, log.Error(err), data)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		log.Event(ctx, "putCode endpoint: invalid request", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	update := codeUpdate(&request, code)
+	created, err := datastore.PutCode(ctx, c.store, id, edition, update)
+	if err != nil {
+		handleError(ctx, "putCode endpoint: failed to put code in store", data, err, w)
+		return
+	}
+	c.suggestions.Invalidate(id)
+	data["created"] = created
+	c.publish(ctx, events.New(events.CodesChanged, id, edition, update.Code))
+
+	apiCode := &models.Code{ID: update.Code, Label: update.Label}
+	if err := apiCode.UpdateLinks(c.apiURL, id, edition); err != nil {
+		log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "putCode endpoint: links could not be created")))
+		http.Error(w, internalServerErr, http.StatusInternalServerError)
+		return
+	}
+	if err := apiCode.UpdateHierarchyLinks(c.apiURL, id, edition, update.Parent); err != nil {
+		log.Event(ctx, "error updating links", log.ERROR, log.Error(errors.WithMessage(err, "putCode endpoint: hierarchy links could not be created")))
+		http.Error(w, internalServerErr, http.StatusInternalServerError)
+		return
+	}
+
+	status := changeStatus(w, created, apiCode.Links.Self.Href)
+	if err := c.writeResponse(w, r, status, apiCode); err != nil {
+		log.Event(ctx, "error writting body", log.ERROR, log.Error(errors.WithMessage(err, "putCode endpoint: failed to write bytes to response")), data)
+		return
+	}
+
+	log.Event(ctx, "putCode endpoint: request successful", log.INFO, data)
+}
+
+// deleteCode deletes a code of an edition
+func (c *CodeListAPI) deleteCode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	edition := vars["edition"]
+	code := vars["code"]
+	data := log.Data{"codelist_id": id, "edition": edition, "code": code}
+
+	log.Event(ctx, "deleteCode endpoint: attempting to delete code", log.INFO, data)
+
+	if edition == latestEdition {
+		err := errors.New("codes cannot be changed through the latest edition alias")
+		log.Event(ctx, "deleteCode endpoint: invalid edition", log.ERROR, log.Error(err), data)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := datastore.DeleteCode(ctx, c.store, id, edition, code); err != nil {
+		handleError(ctx, "deleteCode endpoint: failed to delete code from store", data, err, w)
+		return
+	}
+	c.suggestions.Invalidate(id)
+	c.publish(ctx, events.New(events.CodesChanged, id, edition, code))
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Event(ctx, "deleteCode endpoint: request successful", log.INFO, data)
+}
+
+// changeStatus returns the status of a request creating or replacing a resource, 201 if it was created, in
+// which case the location of the resource is set, or 200 if it was replaced
+func changeStatus(w http.ResponseWriter, created bool, location string) int {
+	if !created {
+		return http.StatusOK
+	}
+	w.Header().Set(locationHeader, location)
+	return http.StatusCreated
+}
@@ -0,0 +1,267 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	dprequest "github.com/ONSdigital/dp-net/request"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testCaller = "test@ons.gov.uk"
+
+// testIdentity stands in for the identity middleware, authenticating the requests with an Authorization header
+func testIdentity(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			r = r.WithContext(dprequest.SetCaller(r.Context(), testCaller))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// newWriteRequest returns an authenticated request changing a code list
+func newWriteRequest(method, url, body string) *http.Request {
+	r := httptest.NewRequest(method, url, strings.NewReader(body))
+	r.Header.Set("Authorization", "token")
+	return r
+}
+
+// newWriterStore returns a store holding the first code list, with the first edition containing the first code
+func newWriterStore() (datastore.DataStore, *storetest.WriterMock) {
+	writer := &storetest.WriterMock{
+		CreateCodeListFunc: func(ctx context.Context, metadata datastore.CodeListMetadata) error {
+			if metadata.ID == codeListID1 {
+				return datastore.ErrAlreadyExists
+			}
+			return nil
+		},
+		PutEditionFunc: func(ctx context.Context, codeListID string, edition datastore.EditionUpdate) (bool, error) {
+			if codeListID != codeListID1 {
+				return false, driver.ErrNotFound
+			}
+			return edition.ID != editionID1, nil
+		},
+		PutCodeFunc: func(ctx context.Context, codeListID string, editionID string, code datastore.CodeUpdate) (bool, error) {
+			if code.Parent != "" && code.Parent != codeID1 {
+				return false, datastore.Invalid("unknown parent %q of code %q", code.Parent, code.Code)
+			}
+			return code.Code != codeID1, nil
+		},
+		DeleteCodeFunc: func(ctx context.Context, codeListID string, editionID string, codeID string) error {
+			if codeID != codeID1 {
+				return driver.ErrNotFound
+			}
+			return nil
+		},
+	}
+	return struct {
+		*storetest.DataStoreMock
+		*storetest.WriterMock
+	}{&storetest.DataStoreMock{}, writer}, writer
+}
+
+func TestPostCodeList(t *testing.T) {
+	Convey("Given an API with private endpoints", t, func() {
+		store, writer := newWriterStore()
+		api := CreateCodeListAPI(mux.NewRouter(), store, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit, WithPrivateEndpoints(testIdentity))
+		url := codeListURL + "/code-lists"
+
+		Convey("When a code list is created, then 201 is returned with its location", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("POST", url, `{"id":"new-list","label":" New list "}`))
+
+			So(w.Code, ShouldEqual, http.StatusCreated)
+			So(w.Header().Get(locationHeader), ShouldEqual, codeListURL+"/code-lists/new-list")
+			So(w.Header().Get(contentTypeHeader), ShouldEqual, contentTypeJSON)
+			So(writer.CreateCodeListCalls(), ShouldHaveLength, 1)
+			So(writer.CreateCodeListCalls()[0].Metadata, ShouldResemble, datastore.CodeListMetadata{ID: "new-list", Label: "New list"})
+		})
+
+		Convey("When a code list is created without authentication, then 401 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("POST", url, strings.NewReader(`{"id":"new-list","label":"New list"}`)))
+
+			So(w.Code, ShouldEqual, http.StatusUnauthorized)
+			So(writer.CreateCodeListCalls(), ShouldBeEmpty)
+		})
+
+		Convey("When a code list is created with an invalid id, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("POST", url, `{"id":"new list","label":"New list"}`))
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(writer.CreateCodeListCalls(), ShouldBeEmpty)
+		})
+
+		Convey("When an existing code list is created, then 409 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("POST", url, fmt.Sprintf(`{"id":"%s","label":"Existing"}`, codeListID1)))
+
+			So(w.Code, ShouldEqual, http.StatusConflict)
+		})
+	})
+
+	Convey("Given an API without private endpoints", t, func() {
+		store, _ := newWriterStore()
+		api := CreateCodeListAPI(mux.NewRouter(), store, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit)
+
+		Convey("When a code list is created, then 405 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("POST", codeListURL+"/code-lists", `{"id":"new-list","label":"New list"}`))
+
+			So(w.Code, ShouldEqual, http.StatusMethodNotAllowed)
+		})
+	})
+
+	Convey("Given an API with private endpoints over a read-only store", t, func() {
+		api := CreateCodeListAPI(mux.NewRouter(), &storetest.DataStoreMock{}, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit, WithPrivateEndpoints(testIdentity))
+
+		Convey("When a code list is created, then 501 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("POST", codeListURL+"/code-lists", `{"id":"new-list","label":"New list"}`))
+
+			So(w.Code, ShouldEqual, http.StatusNotImplemented)
+		})
+	})
+}
+
+func TestPutEdition(t *testing.T) {
+	Convey("Given an API with private endpoints", t, func() {
+		store, writer := newWriterStore()
+		api := CreateCodeListAPI(mux.NewRouter(), store, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit, WithPrivateEndpoints(testIdentity))
+		editionURL := func(id, edition string) string {
+			return fmt.Sprintf("%s/code-lists/%s/editions/%s", codeListURL, id, edition)
+		}
+
+		Convey("When a new edition is put, then 201 is returned with its location", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("PUT", editionURL(codeListID1, editionID2), `{"label":"Second edition","state":"draft"}`))
+
+			So(w.Code, ShouldEqual, http.StatusCreated)
+			So(w.Header().Get(locationHeader), ShouldEqual, editionURL(codeListID1, editionID2))
+			So(writer.PutEditionCalls()[0].Edition, ShouldResemble, datastore.EditionUpdate{ID: editionID2, Label: "Second edition", State: datastore.EditionDraft})
+		})
+
+		Convey("When an existing edition is put, then 200 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("PUT", editionURL(codeListID1, editionID1), `{"label":"First edition"}`))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(locationHeader), ShouldBeEmpty)
+		})
+
+		Convey("When an edition is put in an unknown code list, then 404 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("PUT", editionURL(codeListID2, editionID1), `{"label":"First edition"}`))
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("When the latest edition alias is put, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("PUT", editionURL(codeListID1, latestEdition), `{"label":"Latest"}`))
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(writer.PutEditionCalls(), ShouldBeEmpty)
+		})
+
+		Convey("When an edition is put with an unknown state, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("PUT", editionURL(codeListID1, editionID2), `{"label":"Second edition","state":"archived"}`))
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(writer.PutEditionCalls(), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestPutCode(t *testing.T) {
+	Convey("Given an API with private endpoints", t, func() {
+		store, writer := newWriterStore()
+		api := CreateCodeListAPI(mux.NewRouter(), store, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit, WithPrivateEndpoints(testIdentity))
+		codeURL := func(edition, code string) string {
+			return fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s", codeListURL, codeListID1, edition, code)
+		}
+
+		Convey("When a new code is put, then 201 is returned with its location", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("PUT", codeURL(editionID1, codeID2), fmt.Sprintf(`{"label":"test two","parent":"%s"}`, codeID1)))
+
+			So(w.Code, ShouldEqual, http.StatusCreated)
+			So(w.Header().Get(locationHeader), ShouldEqual, codeURL(editionID1, codeID2))
+			So(writer.PutCodeCalls()[0].Code, ShouldResemble, datastore.CodeUpdate{Code: codeID2, Label: "test two", Parent: codeID1})
+		})
+
+		Convey("When an existing code is put, then 200 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("PUT", codeURL(editionID1, codeID1), `{"label":"test one"}`))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("When a code is put with an unknown parent, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("PUT", codeURL(editionID1, codeID2), `{"label":"test two","parent":"unknown"}`))
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("When a code is put without a label, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("PUT", codeURL(editionID1, codeID2), `{"label":" "}`))
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(writer.PutCodeCalls(), ShouldBeEmpty)
+		})
+
+		Convey("When a code is put through the latest edition alias, then 400 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("PUT", codeURL(latestEdition, codeID2), `{"label":"test two"}`))
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(writer.PutCodeCalls(), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestDeleteCode(t *testing.T) {
+	Convey("Given an API with private endpoints", t, func() {
+		store, writer := newWriterStore()
+		api := CreateCodeListAPI(mux.NewRouter(), store, codeListURL, datasetURL, defaultOffset, defaultLimit, maxLimit, WithPrivateEndpoints(testIdentity))
+		codeURL := func(code string) string {
+			return fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s", codeListURL, codeListID1, editionID1, code)
+		}
+
+		Convey("When a code is deleted, then 204 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("DELETE", codeURL(codeID1), ""))
+
+			So(w.Code, ShouldEqual, http.StatusNoContent)
+			So(writer.DeleteCodeCalls(), ShouldHaveLength, 1)
+		})
+
+		Convey("When an unknown code is deleted, then 404 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, newWriteRequest("DELETE", codeURL(codeID2), ""))
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("When a code is deleted without authentication, then 401 is returned", func() {
+			w := httptest.NewRecorder()
+			api.router.ServeHTTP(w, httptest.NewRequest("DELETE", codeURL(codeID1), nil))
+
+			So(w.Code, ShouldEqual, http.StatusUnauthorized)
+			So(writer.DeleteCodeCalls(), ShouldBeEmpty)
+		})
+	})
+}
@@ -6,13 +6,22 @@ import (
 
 	"context"
 	"errors"
+	"fmt"
 	"os/signal"
 	"syscall"
 
 	"github.com/ONSdigital/dp-code-list-api/api"
 	"github.com/ONSdigital/dp-code-list-api/config"
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/datastore/cache"
+	"github.com/ONSdigital/dp-code-list-api/datastore/coalesce"
+	"github.com/ONSdigital/dp-code-list-api/datastore/file"
+	"github.com/ONSdigital/dp-code-list-api/datastore/memory"
+	"github.com/ONSdigital/dp-code-list-api/events"
+	"github.com/ONSdigital/dp-code-list-api/webhooks"
 	"github.com/ONSdigital/dp-graph/v2/graph"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	dphandlers "github.com/ONSdigital/dp-net/handlers"
 	dphttp "github.com/ONSdigital/dp-net/http"
 	"github.com/ONSdigital/log.go/log"
 	"github.com/gorilla/mux"
@@ -27,6 +36,13 @@ var (
 	Version string
 )
 
+// codeListStore is a DataStore that can be health checked and closed on shutdown
+type codeListStore interface {
+	datastore.DataStore
+	Checker(ctx context.Context, state *healthcheck.CheckState) error
+	Close(ctx context.Context) error
+}
+
 func main() {
 	log.Namespace = "dp-code-list-api"
 	ctx := context.Background()
@@ -41,14 +57,54 @@ func main() {
 		os.Exit(1)
 	}
 
+	// only the file store is changed outside the API and cannot be changed through it: detecting the changes of
+	// other stores would publish the changes made through the API again, and read every code of the graph store
+	// at each interval
+	if cfg.ChangeDetectionInterval > 0 && cfg.DatastoreType != config.DatastoreTypeFile {
+		log.Event(ctx, "store change detection is only supported by the file store", log.FATAL, log.Data{"datastore_type": cfg.DatastoreType})
+		os.Exit(1)
+	}
+
 	// Create CodeList Store
-	datastore, err := graph.NewCodeListStore(ctx)
-	if err != nil {
-		log.Event(ctx, "error creating codelist store", log.FATAL, log.Error(err))
+	var store codeListStore
+	var storeName string
+	var graphErrorConsumer *graph.ErrorConsumer
+	switch cfg.DatastoreType {
+	case config.DatastoreTypeGraph:
+		db, err := graph.NewCodeListStore(ctx)
+		if err != nil {
+			log.Event(ctx, "error creating codelist store", log.FATAL, log.Error(err))
+			os.Exit(1)
+		}
+		graphErrorConsumer = graph.NewLoggingErrorConsumer(ctx, db.Errors)
+		store = db
+		storeName = "Graph DB"
+	case config.DatastoreTypeMemory:
+		store, err = newMemoryStore(cfg.DatastoreFixtures)
+		if err != nil {
+			log.Event(ctx, "error creating in-memory codelist store", log.FATAL, log.Error(err), log.Data{"fixtures": cfg.DatastoreFixtures})
+			os.Exit(1)
+		}
+		storeName = "In-memory store"
+	case config.DatastoreTypeFile:
+		store, err = file.New(ctx, cfg.DatastoreDir, cfg.DatastoreReloadInterval)
+		if err != nil {
+			log.Event(ctx, "error creating file codelist store", log.FATAL, log.Error(err), log.Data{"dir": cfg.DatastoreDir})
+			os.Exit(1)
+		}
+		storeName = "File store"
+	default:
+		log.Event(ctx, "unsupported datastore type", log.FATAL, log.Data{"datastore_type": cfg.DatastoreType})
 		os.Exit(1)
 	}
+	log.Event(ctx, "codelist store created", log.INFO, log.Data{"datastore_type": cfg.DatastoreType})
 
-	graphErrorConsumer := graph.NewLoggingErrorConsumer(ctx, datastore.Errors)
+	// Create the publisher of change events, if configured
+	publisher, err := newPublisher(cfg)
+	if err != nil {
+		log.Event(ctx, "error creating events publisher", log.FATAL, log.Error(err), log.Data{"events_publisher": cfg.EventsPublisher})
+		os.Exit(1)
+	}
 
 	// Create healthcheck object with versionInfo
 	versionInfo, err := healthcheck.NewVersionInfo(BuildTime, GitCommit, Version)
@@ -59,18 +115,83 @@ func main() {
 	hc := healthcheck.New(versionInfo, cfg.HealthCheckCriticalTimeout, cfg.HealthCheckInterval)
 
 	// Register checkers
-	if err := registerCheckers(ctx, &hc, datastore); err != nil {
+	if err := registerCheckers(ctx, &hc, storeName, store, publisher); err != nil {
 		os.Exit(1)
 	}
 
+	// Deliver change events to webhook subscriptions as well, if configured
+	var subscriptions *webhooks.Store
+	if cfg.SubscriptionsFile != "" {
+		subscriptions, err = webhooks.NewStore(cfg.SubscriptionsFile)
+		if err != nil {
+			log.Event(ctx, "error creating webhook subscriptions store", log.FATAL, log.Error(err), log.Data{"subscriptions_file": cfg.SubscriptionsFile})
+			os.Exit(1)
+		}
+		dispatcher := webhooks.NewDispatcher(webhooks.NewClient(), subscriptions, store, cfg.WebhookMaxAttempts, cfg.WebhookRetryInterval)
+		if publisher == nil {
+			publisher = dispatcher
+		} else {
+			publisher = events.Fanout{publisher, dispatcher}
+		}
+	}
+
 	// Create HTTP Server with health endpoint and CodeList API
 	router := mux.NewRouter()
 	router.Path("/health").HandlerFunc(hc.Handler)
 
-	api.CreateCodeListAPI(router, datastore, cfg.CodeListAPIURL, cfg.DatasetAPIURL, cfg.DefaultOffset, cfg.DefaultLimit, cfg.DefaultMaxLimit)
+	// Coalesce concurrent identical calls to the store, and cache its results, if enabled
+	var apiStore datastore.DataStore = store
+	if cfg.CoalesceEnabled {
+		apiStore = coalesce.New(apiStore, cfg.CoalesceTimeout)
+		log.Event(ctx, "concurrent identical codelist store calls are coalesced", log.INFO, log.Data{"coalesce_timeout": cfg.CoalesceTimeout})
+	}
+	if cfg.CacheEnabled {
+		cacheStore := cache.New(apiStore, cfg.CacheSize, cfg.CacheTTL)
+		// the file store changes outside of the cache when its directory is reloaded
+		if fileStore, ok := store.(*file.Store); ok {
+			fileStore.OnReload(func(ctx context.Context) {
+				cacheStore.InvalidateAll(ctx)
+			})
+		}
+		apiStore = cacheStore
+		log.Event(ctx, "codelist store results are cached", log.INFO, log.Data{"cache_size": cfg.CacheSize, "cache_ttl": cfg.CacheTTL})
+	}
+
+	// Enable the endpoints changing code lists, for callers authenticated by zebedee, if configured
+	options := []api.Option{api.WithSuggestIndexes(cfg.SuggestIndexSize, cfg.SuggestIndexTTL)}
+	if cfg.EnablePrivateEndpoints {
+		options = append(options, api.WithPrivateEndpoints(dphandlers.Identity(cfg.ZebedeeURL)))
+		log.Event(ctx, "private endpoints are enabled", log.INFO, log.Data{"zebedee_url": cfg.ZebedeeURL})
+	}
+	if publisher != nil {
+		options = append(options, api.WithPublisher(publisher))
+		log.Event(ctx, "change events are published", log.INFO, log.Data{"events_publisher": cfg.EventsPublisher})
+	}
+	if subscriptions != nil {
+		options = append(options, api.WithSubscriptions(subscriptions))
+		log.Event(ctx, "change events are delivered to webhook subscriptions", log.INFO, log.Data{"subscriptions_file": cfg.SubscriptionsFile})
+	}
+
+	if cfg.SDMXAgencyID != "" {
+		options = append(options, api.WithSDMX(cfg.SDMXAgencyID, cfg.SDMXVersion))
+		log.Event(ctx, "editions can be exported as sdmx codelists", log.INFO, log.Data{"sdmx_agency_id": cfg.SDMXAgencyID, "sdmx_version": cfg.SDMXVersion})
+	}
+
+	api.CreateCodeListAPI(router, apiStore, cfg.CodeListAPIURL, cfg.DatasetAPIURL, cfg.DefaultOffset, cfg.DefaultLimit, cfg.DefaultMaxLimit, options...)
 	httpServer := dphttp.NewServer(cfg.BindAddr, router)
 	httpServer.HandleOSSignals = false
 
+	// Publish the changes made to the store outside the API, comparing the store itself rather than its cache
+	var detector *events.Detector
+	if publisher != nil && cfg.ChangeDetectionInterval > 0 {
+		detector, err = events.NewDetector(ctx, store, publisher, cfg.ChangeDetectionInterval)
+		if err != nil {
+			log.Event(ctx, "error creating store change detector", log.FATAL, log.Error(err))
+			os.Exit(1)
+		}
+		log.Event(ctx, "store changes are detected", log.INFO, log.Data{"interval": cfg.ChangeDetectionInterval})
+	}
+
 	// Start healthcheck ticker
 	hc.Start(ctx)
 
@@ -109,19 +230,49 @@ func main() {
 		hc.Stop()
 		log.Event(shutdownCtx, "healthcheck stopped", log.INFO)
 
+		// Stop detecting store changes
+		if detector != nil {
+			if err = detector.Close(shutdownCtx); err != nil {
+				anyError = true
+				log.Event(shutdownCtx, "store change detector close error", log.ERROR, log.Error(err))
+			} else {
+				log.Event(shutdownCtx, "store change detector successfully closed", log.INFO)
+			}
+		}
+
 		// Close data store
-		if err = datastore.Close(shutdownCtx); err != nil {
+		if err = store.Close(shutdownCtx); err != nil {
 			anyError = true
 			log.Event(shutdownCtx, "datastore close error", log.ERROR, log.Error(err))
 		} else {
 			log.Event(shutdownCtx, "datastore successfully closed", log.INFO)
 		}
 
-		if err = graphErrorConsumer.Close(shutdownCtx); err != nil {
-			anyError = true
-			log.Event(shutdownCtx, "graph error consumer close error", log.ERROR, log.Error(err))
-		} else {
-			log.Event(shutdownCtx, "graph error consumer successfully closed", log.INFO)
+		if publisher != nil {
+			if err = publisher.Close(shutdownCtx); err != nil {
+				anyError = true
+				log.Event(shutdownCtx, "events publisher close error", log.ERROR, log.Error(err))
+			} else {
+				log.Event(shutdownCtx, "events publisher successfully closed", log.INFO)
+			}
+		}
+
+		if subscriptions != nil {
+			if err = subscriptions.Close(shutdownCtx); err != nil {
+				anyError = true
+				log.Event(shutdownCtx, "webhook subscriptions store close error", log.ERROR, log.Error(err))
+			} else {
+				log.Event(shutdownCtx, "webhook subscriptions store successfully closed", log.INFO)
+			}
+		}
+
+		if graphErrorConsumer != nil {
+			if err = graphErrorConsumer.Close(shutdownCtx); err != nil {
+				anyError = true
+				log.Event(shutdownCtx, "graph error consumer close error", log.ERROR, log.Error(err))
+			} else {
+				log.Event(shutdownCtx, "graph error consumer successfully closed", log.INFO)
+			}
 		}
 
 		// If any error happened during shutdown, log it and exit with err code
@@ -147,14 +298,54 @@ func main() {
 	os.Exit(0)
 }
 
+// newMemoryStore creates an in-memory store, seeded from the fixtures file if one is provided
+func newMemoryStore(fixtures string) (*memory.Store, error) {
+	if fixtures == "" {
+		return memory.New(nil), nil
+	}
+	return memory.NewFromFile(fixtures)
+}
+
+// newPublisher creates the publisher of change events selected by the configuration, or returns nil if none
+// is selected
+func newPublisher(cfg *config.Configuration) (events.Publisher, error) {
+	switch cfg.EventsPublisher {
+	case "":
+		return nil, nil
+	case config.EventsPublisherKafka:
+		if cfg.KafkaRestProxyURL == "" {
+			return nil, errors.New("KAFKA_REST_PROXY_URL is required by the kafka events publisher")
+		}
+		// a produce request that timed out may have been produced, and retrying it would produce the events twice
+		client := dphttp.NewClient()
+		client.SetMaxRetries(0)
+		return events.NewKafka(client, cfg.KafkaRestProxyURL, cfg.EventsTopic), nil
+	case config.EventsPublisherFile:
+		publisher, err := events.NewFile(cfg.EventsFile)
+		if err != nil {
+			return nil, err
+		}
+		return publisher, nil
+	default:
+		return nil, fmt.Errorf("unsupported events publisher %q", cfg.EventsPublisher)
+	}
+}
+
 // RegisterCheckers adds the checkers for the provided clients to the healthcheck object.
-func registerCheckers(ctx context.Context, hc *healthcheck.HealthCheck, db *graph.DB) (err error) {
+func registerCheckers(ctx context.Context, hc *healthcheck.HealthCheck, storeName string, store codeListStore, publisher events.Publisher) (err error) {
 
 	hasErrors := false
 
-	if err = hc.AddCheck("Graph DB", db.Checker); err != nil {
+	if err = hc.AddCheck(storeName, store.Checker); err != nil {
 		hasErrors = true
-		log.Event(ctx, "error adding check for graph db", log.ERROR, log.Error(err))
+		log.Event(ctx, "error adding check for codelist store", log.ERROR, log.Error(err), log.Data{"store": storeName})
+	}
+
+	if kafka, ok := publisher.(*events.Kafka); ok {
+		if err = hc.AddCheck("Kafka REST proxy", kafka.Checker); err != nil {
+			hasErrors = true
+			log.Event(ctx, "error adding check for kafka rest proxy", log.ERROR, log.Error(err))
+		}
 	}
 
 	if hasErrors {
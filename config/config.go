@@ -6,6 +6,19 @@ import (
 	"github.com/kelseyhightower/envconfig"
 )
 
+// Data store types that can be selected with DATASTORE_TYPE
+const (
+	DatastoreTypeGraph  = "graph"
+	DatastoreTypeMemory = "memory"
+	DatastoreTypeFile   = "file"
+)
+
+// Event publishers that can be selected with EVENTS_PUBLISHER
+const (
+	EventsPublisherKafka = "kafka"
+	EventsPublisherFile  = "file"
+)
+
 type Configuration struct {
 	BindAddr                   string        `envconfig:"BIND_ADDR"`
 	CodeListAPIURL             string        `envconfig:"CODE_LIST_API_URL"`
@@ -16,6 +29,29 @@ type Configuration struct {
 	DefaultLimit               int           `envconfig:"DEFAULT_LIMIT"`
 	DefaultOffset              int           `envconfig:"DEFAULT_OFFSET"`
 	DefaultMaxLimit            int           `envconfig:"DEFAULT_MAXIMUM_LIMIT"`
+	DatastoreType              string        `envconfig:"DATASTORE_TYPE"`
+	DatastoreFixtures          string        `envconfig:"DATASTORE_FIXTURES"`
+	DatastoreDir               string        `envconfig:"DATASTORE_DIR"`
+	DatastoreReloadInterval    time.Duration `envconfig:"DATASTORE_RELOAD_INTERVAL"`
+	CoalesceEnabled            bool          `envconfig:"COALESCE_ENABLED"`
+	CoalesceTimeout            time.Duration `envconfig:"COALESCE_TIMEOUT"`
+	CacheEnabled               bool          `envconfig:"CACHE_ENABLED"`
+	CacheSize                  int           `envconfig:"CACHE_SIZE"`
+	CacheTTL                   time.Duration `envconfig:"CACHE_TTL"`
+	SuggestIndexSize           int           `envconfig:"SUGGEST_INDEX_SIZE"`
+	SuggestIndexTTL            time.Duration `envconfig:"SUGGEST_INDEX_TTL"`
+	EnablePrivateEndpoints     bool          `envconfig:"ENABLE_PRIVATE_ENDPOINTS"`
+	ZebedeeURL                 string        `envconfig:"ZEBEDEE_URL"`
+	EventsPublisher            string        `envconfig:"EVENTS_PUBLISHER"`
+	EventsTopic                string        `envconfig:"EVENTS_TOPIC"`
+	EventsFile                 string        `envconfig:"EVENTS_FILE"`
+	KafkaRestProxyURL          string        `envconfig:"KAFKA_REST_PROXY_URL"`
+	ChangeDetectionInterval    time.Duration `envconfig:"CHANGE_DETECTION_INTERVAL"`
+	SubscriptionsFile          string        `envconfig:"SUBSCRIPTIONS_FILE"`
+	WebhookMaxAttempts         int           `envconfig:"WEBHOOK_MAX_ATTEMPTS"`
+	WebhookRetryInterval       time.Duration `envconfig:"WEBHOOK_RETRY_INTERVAL"`
+	SDMXAgencyID               string        `envconfig:"SDMX_AGENCY_ID"`
+	SDMXVersion                string        `envconfig:"SDMX_VERSION"`
 }
 
 var cfg *Configuration
@@ -36,6 +72,29 @@ func Get() (*Configuration, error) {
 		DefaultLimit:               20,
 		DefaultOffset:              0,
 		DefaultMaxLimit:            1000,
+		DatastoreType:              DatastoreTypeGraph,
+		DatastoreFixtures:          "",
+		DatastoreDir:               "",
+		DatastoreReloadInterval:    10 * time.Second,
+		CoalesceEnabled:            false,
+		CoalesceTimeout:            30 * time.Second,
+		CacheEnabled:               false,
+		CacheSize:                  1000,
+		CacheTTL:                   5 * time.Minute,
+		SuggestIndexSize:           100,
+		SuggestIndexTTL:            5 * time.Minute,
+		EnablePrivateEndpoints:     false,
+		ZebedeeURL:                 "http://localhost:8082",
+		EventsPublisher:            "",
+		EventsTopic:                "code-list-changed",
+		EventsFile:                 "",
+		KafkaRestProxyURL:          "",
+		ChangeDetectionInterval:    0,
+		SubscriptionsFile:          "",
+		WebhookMaxAttempts:         5,
+		WebhookRetryInterval:       time.Second,
+		SDMXAgencyID:               "ONS",
+		SDMXVersion:                "1.0",
 	}
 
 	return cfg, envconfig.Process("", cfg)
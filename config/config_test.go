@@ -22,6 +22,29 @@ func TestGetReturnsDefaultValues(t *testing.T) {
 			DefaultOffset:              0,
 			DefaultLimit:               20,
 			DefaultMaxLimit:            1000,
+			DatastoreType:              DatastoreTypeGraph,
+			DatastoreFixtures:          "",
+			DatastoreDir:               "",
+			DatastoreReloadInterval:    10 * time.Second,
+			CoalesceEnabled:            false,
+			CoalesceTimeout:            30 * time.Second,
+			CacheEnabled:               false,
+			CacheSize:                  1000,
+			CacheTTL:                   5 * time.Minute,
+			SuggestIndexSize:           100,
+			SuggestIndexTTL:            5 * time.Minute,
+			EnablePrivateEndpoints:     false,
+			ZebedeeURL:                 "http://localhost:8082",
+			EventsPublisher:            "",
+			EventsTopic:                "code-list-changed",
+			EventsFile:                 "",
+			KafkaRestProxyURL:          "",
+			ChangeDetectionInterval:    0,
+			SubscriptionsFile:          "",
+			WebhookMaxAttempts:         5,
+			WebhookRetryInterval:       time.Second,
+			SDMXAgencyID:               "ONS",
+			SDMXVersion:                "1.0",
 		})
 	})
 }
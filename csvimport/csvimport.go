@@ -0,0 +1,198 @@
+// Package csvimport reads the codes of a new edition from a CSV file, as exported from the spreadsheets
+// maintained by publishing teams, and reports every problem found in the file so that they can all be fixed
+// before the file is uploaded again.
+package csvimport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	utf8BOM    = []byte("\ufeff")
+	utf16BOMLE = []byte{0xff, 0xfe}
+	utf16BOMBE = []byte{0xfe, 0xff}
+)
+
+// header is the expected header of a file, where the parent column is optional
+var header = []string{"code", "label", "parent"}
+
+// Code is a code read from a row of a file. Parent is the code of its parent in the file, for code lists
+// that are hierarchies.
+type Code struct {
+	Code   string
+	Label  string
+	Parent string
+	Line   int
+}
+
+// Problem is a problem found on a line of a file. Code is the code of the row, if any.
+type Problem struct {
+	Line    int
+	Code    string
+	Message string
+}
+
+// Read reads the codes of a file, in their order, along with the problems found in the file. The file is
+// only valid if no problems are found. Lines are counted from the header, which is line 1, and rows are
+// expected to fit on a single line. An error is only returned if the file could not be read.
+func Read(r io.Reader) ([]Code, []Problem, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read file")
+	}
+	if bytes.HasPrefix(content, utf16BOMLE) || bytes.HasPrefix(content, utf16BOMBE) {
+		return nil, []Problem{{Line: 1, Message: "the file is encoded as UTF-16, it must be saved as UTF-8"}}, nil
+	}
+
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, utf8BOM)))
+	reader.TrimLeadingSpace = true
+
+	columns, err := reader.Read()
+	if err == io.EOF {
+		return nil, []Problem{{Line: 1, Message: "the file is empty, a code,label header is required"}}, nil
+	}
+	if err != nil {
+		return nil, []Problem{{Line: 1, Message: parseMessage(err)}}, nil
+	}
+	if !validHeader(columns) {
+		return nil, []Problem{{Line: 1, Message: fmt.Sprintf("invalid header %q, expected code,label or code,label,parent", strings.Join(columns, ","))}}, nil
+	}
+	reader.FieldsPerRecord = len(columns)
+
+	codes := []Code{}
+	problems := []Problem{}
+	lines := map[string]int{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			problems = append(problems, Problem{Line: line, Message: parseMessage(err)})
+			continue
+		}
+
+		code := Code{Code: strings.TrimSpace(record[0]), Label: strings.TrimSpace(record[1]), Line: line}
+		if len(record) > 2 {
+			code.Parent = strings.TrimSpace(record[2])
+		}
+		if message := checkRow(code, lines); message != "" {
+			problems = append(problems, Problem{Line: line, Code: code.Code, Message: message})
+			continue
+		}
+		codes = append(codes, code)
+	}
+
+	if len(codes) == 0 && len(problems) == 0 {
+		problems = append(problems, Problem{Line: 1, Message: "the file does not contain any codes"})
+	}
+	problems = append(problems, checkHierarchy(codes, lines)...)
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Line < problems[j].Line
+	})
+	return codes, problems, nil
+}
+
+// validHeader reports whether a header is code,label, or code,label,parent for a hierarchy, ignoring case
+func validHeader(columns []string) bool {
+	if len(columns) < 2 || len(columns) > len(header) {
+		return false
+	}
+	for i := range columns {
+		if !strings.EqualFold(strings.TrimSpace(columns[i]), header[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkRow checks the encoding and values of a row, and that its code was not found on a previous line, and
+// returns the problem found if any. lines holds the line number of each code found so far, including the codes
+// of the rows with an empty label, so that they are not reported as unknown parents as well.
+func checkRow(code Code, lines map[string]int) string {
+	if !utf8.ValidString(code.Code + code.Label + code.Parent) {
+		return "invalid characters, the file must be saved as UTF-8"
+	}
+	if code.Code == "" {
+		return "empty code"
+	}
+	if first, ok := lines[code.Code]; ok {
+		return fmt.Sprintf("duplicate code, first found on line %d", first)
+	}
+	lines[code.Code] = code.Line
+	if code.Label == "" {
+		return "empty label"
+	}
+	return ""
+}
+
+// checkHierarchy checks that the parent of each code is a code of the file, and that no code is its own
+// ancestor. lines holds the line number of each code.
+func checkHierarchy(codes []Code, lines map[string]int) []Problem {
+	problems := []Problem{}
+	parents := make(map[string]string, len(codes))
+	for _, code := range codes {
+		if code.Parent == "" {
+			continue
+		}
+		if _, ok := lines[code.Parent]; !ok {
+			problems = append(problems, Problem{Line: code.Line, Code: code.Code, Message: fmt.Sprintf("unknown parent %q", code.Parent)})
+			continue
+		}
+		parents[code.Code] = code.Parent
+	}
+
+	inCycle := Cycles(parents)
+	for _, code := range codes {
+		if inCycle[code.Code] {
+			problems = append(problems, Problem{Line: code.Line, Code: code.Code, Message: "the code is its own ancestor"})
+		}
+	}
+	return problems
+}
+
+// Cycles returns the codes that are their own ancestor, given the parent of each code. Each code is walked
+// through once: a walk up from a code stops at the first code already visited, which is either on the walk,
+// closing a cycle, or was visited by an earlier walk, in which case the codes of the walk are not in a cycle.
+func Cycles(parents map[string]string) map[string]bool {
+	inCycle := map[string]bool{}
+	visited := make(map[string]bool, len(parents))
+	for code := range parents {
+		walk := map[string]int{}
+		var path []string
+		for c := code; c != "" && !visited[c]; c = parents[c] {
+			if start, ok := walk[c]; ok {
+				for _, member := range path[start:] {
+					inCycle[member] = true
+				}
+				break
+			}
+			walk[c] = len(path)
+			path = append(path, c)
+		}
+		for _, c := range path {
+			visited[c] = true
+		}
+	}
+	return inCycle
+}
+
+// parseMessage returns the message of a CSV parsing error, without the line number that is already reported
+func parseMessage(err error) string {
+	if parseErr, ok := err.(*csv.ParseError); ok {
+		if parseErr.Err == csv.ErrFieldCount {
+			return "wrong number of fields"
+		}
+		return parseErr.Err.Error()
+	}
+	return err.Error()
+}
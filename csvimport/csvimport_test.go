@@ -0,0 +1,104 @@
+package csvimport
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func read(content string) ([]Code, []Problem) {
+	codes, problems, err := Read(strings.NewReader(content))
+	So(err, ShouldBeNil)
+	return codes, problems
+}
+
+func TestRead(t *testing.T) {
+	Convey("A valid file is read in order, without problems", t, func() {
+		codes, problems := read("\ufeffCode,Label,Parent\nE92000001,England,\nE06000001,\"Hartlepool, Borough of\",E92000001\n")
+		So(problems, ShouldBeEmpty)
+		So(codes, ShouldResemble, []Code{
+			{Code: "E92000001", Label: "England", Line: 2},
+			{Code: "E06000001", Label: "Hartlepool, Borough of", Parent: "E92000001", Line: 3},
+		})
+	})
+
+	Convey("The parent column is optional", t, func() {
+		codes, problems := read("code,label\nE06000001,Hartlepool\n")
+		So(problems, ShouldBeEmpty)
+		So(codes, ShouldResemble, []Code{{Code: "E06000001", Label: "Hartlepool", Line: 2}})
+	})
+
+	Convey("Every problem of the rows is reported by line", t, func() {
+		codes, problems := read("code,label,parent\n" +
+			"E06000001,Hartlepool,E92000001\n" +
+			",Middlesbrough,\n" +
+			"E06000003,,\n" +
+			"E06000001,Hartlepool,\n" +
+			"E06000004,Stockton-on-Tees\n" +
+			"E06000005,Darlington,E06000003\n")
+		So(codes, ShouldHaveLength, 2)
+		So(problems, ShouldResemble, []Problem{
+			{Line: 2, Code: "E06000001", Message: `unknown parent "E92000001"`},
+			{Line: 3, Message: "empty code"},
+			{Line: 4, Code: "E06000003", Message: "empty label"},
+			{Line: 5, Code: "E06000001", Message: "duplicate code, first found on line 2"},
+			{Line: 6, Message: "wrong number of fields"},
+		})
+	})
+
+	Convey("A code which is its own ancestor is reported", t, func() {
+		_, problems := read("code,label,parent\nA,a,B\nB,b,A\nC,c,A\n")
+		So(problems, ShouldResemble, []Problem{
+			{Line: 2, Code: "A", Message: "the code is its own ancestor"},
+			{Line: 3, Code: "B", Message: "the code is its own ancestor"},
+		})
+	})
+
+	Convey("Codes are only walked through once when looking for codes which are their own ancestor", t, func() {
+		parents := map[string]string{}
+		for i := 1; i < 100000; i++ {
+			parents[strconv.Itoa(i)] = strconv.Itoa(i - 1)
+		}
+		parents["0"] = "99999"
+		parents["a"] = "b"
+		parents["b"] = "50000"
+
+		inCycle := Cycles(parents)
+		So(inCycle, ShouldHaveLength, 100000)
+		So(inCycle["a"], ShouldBeFalse)
+		So(inCycle["b"], ShouldBeFalse)
+	})
+
+	Convey("Rows which are not UTF-8 encoded are reported", t, func() {
+		_, problems := read("code,label\nE06000001,Ynys M\xf4n\n")
+		So(problems, ShouldHaveLength, 1)
+		So(problems[0].Line, ShouldEqual, 2)
+		So(problems[0].Message, ShouldContainSubstring, "UTF-8")
+	})
+
+	Convey("A UTF-16 encoded file is reported", t, func() {
+		_, problems := read("\xff\xfec\x00o\x00d\x00e\x00")
+		So(problems, ShouldHaveLength, 1)
+		So(problems[0].Message, ShouldContainSubstring, "UTF-16")
+	})
+
+	Convey("A file with an invalid header is reported", t, func() {
+		_, problems := read("id,name\nE06000001,Hartlepool\n")
+		So(problems, ShouldHaveLength, 1)
+		So(problems[0].Line, ShouldEqual, 1)
+		So(problems[0].Message, ShouldContainSubstring, "invalid header")
+	})
+
+	Convey("A file without codes is reported", t, func() {
+		_, problems := read("code,label\n")
+		So(problems, ShouldResemble, []Problem{{Line: 1, Message: "the file does not contain any codes"}})
+	})
+
+	Convey("An error is returned if the file cannot be read", t, func() {
+		_, _, err := Read(iotest.TimeoutReader(strings.NewReader("code,label\n")))
+		So(err, ShouldNotBeNil)
+	})
+}
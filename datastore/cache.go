@@ -0,0 +1,20 @@
+package datastore
+
+import "context"
+
+//go:generate moq -out datastoretest/cache.go -pkg storetest . Cache
+
+// Cache is implemented by stores that cache results, so that the results cached for a code list can be
+// dropped once the code list has changed.
+type Cache interface {
+	Invalidate(ctx context.Context, codeListID string) int
+	Stats() CacheStats
+}
+
+// CacheStats holds the number of entries of a cache, and counters of how the cache has been used
+type CacheStats struct {
+	Entries   int
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
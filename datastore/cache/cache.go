@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+)
+
+// Type check to ensure that Store implements the datastore.Cache interface
+var _ datastore.Cache = (*Store)(nil)
+
+// uncached are the methods whose results are not cached, as they are cheap to get from any store
+var uncached = map[string]bool{
+	"GetCodeList": true,
+	"GetEdition":  true,
+	"GetCode":     true,
+}
+
+// Store is a read-through cache in front of another datastore.DataStore. The results of every read call but
+// GetCodeList, GetEdition and GetCode are cached, keyed by their arguments. Errors are never cached, and the
+// results of a code list are dropped once it is changed through the Store.
+type Store struct {
+	*datastore.Decorator
+	cache *lru
+}
+
+// New returns a Store caching up to size results of the provided store, for the provided ttl. Nothing is
+// cached if size is zero.
+func New(store datastore.DataStore, size int, ttl time.Duration) *Store {
+	s := &Store{cache: newLRU(size, ttl)}
+	s.Decorator = datastore.NewDecorator(store, s.get, func(ctx context.Context, codeListID string) {
+		s.Invalidate(ctx, codeListID)
+	})
+	return s
+}
+
+// Invalidate drops every result cached for the code list, along with the cached lists of code lists,
+// and returns the number of dropped results
+func (s *Store) Invalidate(ctx context.Context, codeListID string) int {
+	return s.cache.invalidate(codeListID) + s.cache.invalidate("")
+}
+
+// InvalidateAll drops every cached result, e.g. once the code lists of the store were changed outside of
+// the Store, and returns the number of dropped results
+func (s *Store) InvalidateAll(ctx context.Context) int {
+	return s.cache.clear()
+}
+
+// Stats returns the number of cached results and the hit, miss and eviction counters of the cache
+func (s *Store) Stats() datastore.CacheStats {
+	s.cache.mutex.Lock()
+	defer s.cache.mutex.Unlock()
+
+	return datastore.CacheStats{
+		Entries:   s.cache.order.Len(),
+		Hits:      s.cache.hits,
+		Misses:    s.cache.misses,
+		Evictions: s.cache.evictions,
+	}
+}
+
+// get returns the value cached for a call, or fetches it and caches it if it is successful
+func (s *Store) get(ctx context.Context, call datastore.Call, fetch datastore.Fetch) (interface{}, error) {
+	if uncached[call.Method] {
+		return fetch(ctx)
+	}
+	if value, ok := s.cache.get(call.Key); ok {
+		return value, nil
+	}
+
+	// a result fetched while the code list is being changed may predate the change, and is not cached
+	generation := s.cache.generationOf(call.CodeListID)
+	value, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.set(call.Key, call.CodeListID, value, generation)
+	return value, nil
+}
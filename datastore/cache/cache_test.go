@@ -0,0 +1,311 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-graph/v2/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var errStore = errors.New("store error")
+
+func newMockStore() *storetest.DataStoreMock {
+	return &storetest.DataStoreMock{
+		GetCodeListsFunc: func(ctx context.Context, filterBy string) (*models.CodeListResults, error) {
+			return &models.CodeListResults{Items: []models.CodeList{{ID: "b"}, {ID: "a"}}}, nil
+		},
+		GetEditionsFunc: func(ctx context.Context, codeListID string) (*models.Editions, error) {
+			if codeListID == "unknown" {
+				return nil, errStore
+			}
+			return &models.Editions{Items: []models.Edition{{ID: "2019"}}}, nil
+		},
+		CountCodesFunc: func(ctx context.Context, codeListID string, edition string) (int64, error) {
+			return 2, nil
+		},
+		GetCodesFunc: func(ctx context.Context, codeListID string, editionID string) (*models.CodeResults, error) {
+			return &models.CodeResults{Items: []models.Code{{Code: "2", Label: "two"}, {Code: "1", Label: "one"}}}, nil
+		},
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a cache in front of a store", t, func() {
+		mockStore := newMockStore()
+		store := New(mockStore, 10, time.Minute)
+
+		Convey("When the same results are requested twice, the store is only called once", func() {
+			for i := 0; i < 2; i++ {
+				codeLists, err := store.GetCodeLists(ctx, "geography")
+				So(err, ShouldBeNil)
+				So(codeLists.Items, ShouldHaveLength, 2)
+			}
+			So(mockStore.GetCodeListsCalls(), ShouldHaveLength, 1)
+			So(store.Stats(), ShouldResemble, datastore.CacheStats{Entries: 1, Hits: 1, Misses: 1})
+		})
+
+		Convey("Results requested with different arguments are cached separately", func() {
+			store.GetCodeLists(ctx, "geography")
+			store.GetCodeLists(ctx, "")
+			So(mockStore.GetCodeListsCalls(), ShouldHaveLength, 2)
+		})
+
+		Convey("Errors are not cached", func() {
+			_, err := store.GetEditions(ctx, "unknown")
+			So(err, ShouldEqual, errStore)
+			_, err = store.GetEditions(ctx, "unknown")
+			So(err, ShouldEqual, errStore)
+			So(mockStore.GetEditionsCalls(), ShouldHaveLength, 2)
+		})
+
+		Convey("Sorting returned results does not modify the cached results", func() {
+			codeLists, _ := store.GetCodeLists(ctx, "")
+			datastore.SortCodeLists(codeLists.Items, datastore.OrderByID)
+
+			codeLists, _ = store.GetCodeLists(ctx, "")
+			So(codeLists.Items, ShouldResemble, []models.CodeList{{ID: "b"}, {ID: "a"}})
+		})
+
+		Convey("Pages are taken from the cached results when the store does not support pagination", func() {
+			for i := 0; i < 2; i++ {
+				codes, totalCount, err := store.GetCodesPage(ctx, "codelist", "2019", datastore.Page{Offset: i, Limit: 1})
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 2)
+				So(codes.Items, ShouldHaveLength, 1)
+			}
+			So(mockStore.CountCodesCalls(), ShouldHaveLength, 1)
+			So(mockStore.GetCodesCalls(), ShouldHaveLength, 1)
+		})
+
+		Convey("Many codes requested by ID are taken from the cached results when the store cannot get many codes at once", func() {
+			ids := make([]string, 100)
+			for i := range ids {
+				ids[i] = "1"
+			}
+			for i := 0; i < 2; i++ {
+				codes, err := store.GetCodesByID(ctx, "codelist", "2019", ids)
+				So(err, ShouldBeNil)
+				So(codes, ShouldHaveLength, 100)
+				So(codes[0], ShouldResemble, models.Code{Code: "1", Label: "one"})
+			}
+			So(mockStore.GetCodesCalls(), ShouldHaveLength, 1)
+		})
+
+		Convey("Invalidate drops the results cached for a code list, and the cached lists of code lists", func() {
+			store.GetCodeLists(ctx, "")
+			store.GetEditions(ctx, "codelist1")
+			store.GetEditions(ctx, "codelist2")
+
+			So(store.Invalidate(ctx, "codelist1"), ShouldEqual, 2)
+
+			store.GetCodeLists(ctx, "")
+			store.GetEditions(ctx, "codelist1")
+			store.GetEditions(ctx, "codelist2")
+			So(mockStore.GetCodeListsCalls(), ShouldHaveLength, 2)
+			So(mockStore.GetEditionsCalls(), ShouldHaveLength, 3)
+		})
+
+		Convey("Results fetched while their code list is invalidated are not cached", func() {
+			mockStore.GetEditionsFunc = func(ctx context.Context, codeListID string) (*models.Editions, error) {
+				store.Invalidate(ctx, codeListID)
+				return &models.Editions{Items: []models.Edition{{ID: "2019"}}}, nil
+			}
+
+			store.GetEditions(ctx, "codelist1")
+			store.GetEditions(ctx, "codelist1")
+			So(mockStore.GetEditionsCalls(), ShouldHaveLength, 2)
+		})
+
+		Convey("InvalidateAll drops every cached result", func() {
+			store.GetCodeLists(ctx, "")
+			store.GetEditions(ctx, "codelist1")
+
+			So(store.InvalidateAll(ctx), ShouldEqual, 2)
+			So(store.Stats().Entries, ShouldEqual, 0)
+		})
+	})
+
+	Convey("Given a cache in front of a store that does not support hierarchies", t, func() {
+		store := New(newMockStore(), 10, time.Minute)
+
+		Convey("GetParentCode returns ErrNotSupported", func() {
+			_, err := store.GetParentCode(ctx, "codelist", "2019", "1")
+			So(err, ShouldEqual, datastore.ErrNotSupported)
+			So(store.Stats().Entries, ShouldEqual, 0)
+		})
+
+		Convey("The optional methods the store does not implement return ErrNotSupported without going through the cache", func() {
+			_, err := store.GetChildCodes(ctx, "codelist", "2019", "1")
+			So(err, ShouldEqual, datastore.ErrNotSupported)
+			_, err = store.GetParentCodeIDs(ctx, "codelist", "2019")
+			So(err, ShouldEqual, datastore.ErrNotSupported)
+			_, err = store.GetCodeEditions(ctx, "1")
+			So(err, ShouldEqual, datastore.ErrNotSupported)
+			_, err = store.GetMappings(ctx, "codelist", "2019", "1")
+			So(err, ShouldEqual, datastore.ErrNotSupported)
+			So(store.Stats(), ShouldResemble, datastore.CacheStats{})
+		})
+	})
+
+	Convey("Given a cache in front of a store that supports hierarchies", t, func() {
+		mockHierarchy := &storetest.HierarchyMock{
+			GetParentCodeFunc: func(ctx context.Context, codeListID string, editionID string, codeID string) (*models.Code, error) {
+				if codeID == "0" {
+					return nil, nil
+				}
+				return &models.Code{Code: "0", Label: "zero"}, nil
+			},
+		}
+		store := New(struct {
+			*storetest.DataStoreMock
+			*storetest.HierarchyMock
+		}{newMockStore(), mockHierarchy}, 10, time.Minute)
+
+		Convey("The parent of a code is cached, including the absence of a parent", func() {
+			for i := 0; i < 2; i++ {
+				parent, err := store.GetParentCode(ctx, "codelist", "2019", "1")
+				So(err, ShouldBeNil)
+				So(parent, ShouldResemble, &models.Code{Code: "0", Label: "zero"})
+
+				parent, err = store.GetParentCode(ctx, "codelist", "2019", "0")
+				So(err, ShouldBeNil)
+				So(parent, ShouldBeNil)
+			}
+			So(mockHierarchy.GetParentCodeCalls(), ShouldHaveLength, 2)
+		})
+	})
+
+	Convey("Given a cache in front of a store that supports mappings", t, func() {
+		mapping := datastore.Mapping{
+			Source: datastore.CodeRef{CodeListID: "codelist", EditionID: "2019", Code: "1"},
+			Target: datastore.CodeRef{CodeListID: "codelist", EditionID: "2020", Code: "1"},
+			Type:   datastore.MappingSuccessor,
+		}
+		mockRelationships := &storetest.RelationshipsMock{
+			GetMappingsFunc: func(ctx context.Context, codeListID string, editionID string, codeID string) ([]datastore.Mapping, error) {
+				return []datastore.Mapping{mapping}, nil
+			},
+		}
+		store := New(struct {
+			*storetest.DataStoreMock
+			*storetest.RelationshipsMock
+		}{newMockStore(), mockRelationships}, 10, time.Minute)
+
+		Convey("The mappings of a code are cached", func() {
+			for i := 0; i < 2; i++ {
+				mappings, err := store.GetMappings(ctx, "codelist", "2019", "1")
+				So(err, ShouldBeNil)
+				So(mappings, ShouldResemble, []datastore.Mapping{mapping})
+			}
+			So(mockRelationships.GetMappingsCalls(), ShouldHaveLength, 1)
+		})
+	})
+
+	Convey("Given a cache in front of a store that can be changed", t, func() {
+		mockStore := newMockStore()
+		mockWriter := &storetest.WriterMock{
+			PutCodeFunc: func(ctx context.Context, codeListID string, editionID string, code datastore.CodeUpdate) (bool, error) {
+				return true, nil
+			},
+		}
+		store := New(struct {
+			*storetest.DataStoreMock
+			*storetest.WriterMock
+		}{mockStore, mockWriter}, 10, time.Minute)
+
+		Convey("Changing a code drops the results cached for its code list", func() {
+			store.GetCodes(ctx, "codelist", "2019")
+			created, err := store.PutCode(ctx, "codelist", "2019", datastore.CodeUpdate{Code: "3", Label: "three"})
+			So(err, ShouldBeNil)
+			So(created, ShouldBeTrue)
+
+			store.GetCodes(ctx, "codelist", "2019")
+			So(mockStore.GetCodesCalls(), ShouldHaveLength, 2)
+			So(mockWriter.PutCodeCalls(), ShouldHaveLength, 1)
+		})
+	})
+
+	Convey("Given a cache in front of a store that supports pagination", t, func() {
+		mockPaginator := &storetest.PaginatorMock{
+			GetCodesPageFunc: func(ctx context.Context, codeListID string, editionID string, page datastore.Page) (*models.CodeResults, int, error) {
+				return &models.CodeResults{Items: []models.Code{{Code: "1"}}}, 2, nil
+			},
+		}
+		store := New(struct {
+			*storetest.DataStoreMock
+			*storetest.PaginatorMock
+		}{newMockStore(), mockPaginator}, 10, time.Minute)
+
+		Convey("Each requested page is cached", func() {
+			page := datastore.Page{Offset: 0, Limit: 1}
+			for i := 0; i < 2; i++ {
+				codes, totalCount, err := store.GetCodesPage(ctx, "codelist", "2019", page)
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 2)
+				So(codes.Items, ShouldResemble, []models.Code{{Code: "1"}})
+			}
+			store.GetCodesPage(ctx, "codelist", "2019", datastore.Page{Offset: 1, Limit: 1})
+			So(mockPaginator.GetCodesPageCalls(), ShouldHaveLength, 2)
+		})
+	})
+}
+
+func TestLRU(t *testing.T) {
+	Convey("Given a full cache", t, func() {
+		c := newLRU(2, time.Minute)
+		now := time.Now()
+		c.now = func() time.Time { return now }
+
+		c.set("a", "codelist", 1, 0)
+		c.set("b", "codelist", 2, 0)
+
+		Convey("Setting a new value evicts the least recently used value", func() {
+			c.get("a")
+			c.set("c", "codelist", 3, 0)
+
+			_, ok := c.get("b")
+			So(ok, ShouldBeFalse)
+			_, ok = c.get("a")
+			So(ok, ShouldBeTrue)
+			So(c.evictions, ShouldEqual, 1)
+		})
+
+		Convey("Values expire once their TTL has passed", func() {
+			now = now.Add(time.Minute)
+
+			_, ok := c.get("a")
+			So(ok, ShouldBeFalse)
+			So(c.order.Len(), ShouldEqual, 1)
+		})
+
+		Convey("A value read before its code list was invalidated is not cached", func() {
+			generation := c.generationOf("codelist")
+			c.invalidate("codelist")
+			c.set("c", "codelist", 3, generation)
+
+			_, ok := c.get("c")
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Clear removes every value", func() {
+			So(c.clear(), ShouldEqual, 2)
+			So(c.order.Len(), ShouldEqual, 0)
+		})
+	})
+
+	Convey("Given a cache of size zero, nothing is cached", t, func() {
+		c := newLRU(0, time.Minute)
+		c.set("a", "codelist", 1, 0)
+
+		_, ok := c.get("a")
+		So(ok, ShouldBeFalse)
+		So(c.order.Len(), ShouldEqual, 0)
+	})
+}
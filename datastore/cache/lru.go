@@ -0,0 +1,140 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// entry is a cached value, along with the code list it belongs to and its expiry time
+type entry struct {
+	key        string
+	codeListID string
+	value      interface{}
+	expires    time.Time
+}
+
+// lru is a fixed size, least recently used cache of values that expire after a TTL. Each code list has a
+// generation, bumped whenever its values are invalidated, so that values fetched before an invalidation
+// are not cached after it.
+type lru struct {
+	mutex   sync.Mutex
+	size    int
+	ttl     time.Duration
+	now     func() time.Time
+	order   *list.List
+	entries map[string]*list.Element
+
+	generation  uint64
+	generations map[string]uint64
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+func newLRU(size int, ttl time.Duration) *lru {
+	return &lru{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+
+		generations: map[string]uint64{},
+	}
+}
+
+// generationOf returns the current generation of the code list, to be passed to set
+func (c *lru) generationOf(codeListID string) uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.generation + c.generations[codeListID]
+}
+
+// get returns the value cached for key, if it has not expired
+func (c *lru) get(key string) (interface{}, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+
+	e := element.Value.(*entry)
+	if !c.now().Before(e.expires) {
+		c.remove(element)
+		c.misses++
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	c.hits++
+	return e.value, true
+}
+
+// set caches value for key, evicting the least recently used value if the cache is full. Nothing is
+// cached if the size of the cache is zero, or if the code list was invalidated since generation was read.
+func (c *lru) set(key, codeListID string, value interface{}, generation uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.size <= 0 || generation != c.generation+c.generations[codeListID] {
+		return
+	}
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+
+	for c.order.Len() >= c.size && c.order.Len() > 0 {
+		c.remove(c.order.Back())
+		c.evictions++
+	}
+
+	c.entries[key] = c.order.PushFront(&entry{
+		key:        key,
+		codeListID: codeListID,
+		value:      value,
+		expires:    c.now().Add(c.ttl),
+	})
+}
+
+// invalidate removes every value cached for the code list, and returns how many were removed
+func (c *lru) invalidate(codeListID string) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generations[codeListID]++
+
+	removed := 0
+	for element := c.order.Front(); element != nil; {
+		next := element.Next()
+		if element.Value.(*entry).codeListID == codeListID {
+			c.remove(element)
+			removed++
+		}
+		element = next
+	}
+	return removed
+}
+
+// clear removes every cached value, and returns how many were removed
+func (c *lru) clear() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generation++
+
+	removed := c.order.Len()
+	c.order.Init()
+	c.entries = map[string]*list.Element{}
+	return removed
+}
+
+func (c *lru) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*entry).key)
+}
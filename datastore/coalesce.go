@@ -0,0 +1,15 @@
+package datastore
+
+//go:generate moq -out datastoretest/coalescer.go -pkg storetest . Coalescer
+
+// Coalescer is implemented by stores that collapse concurrent identical calls into a single call
+type Coalescer interface {
+	CoalesceStats() CoalesceStats
+}
+
+// CoalesceStats holds counters of the calls made to a Coalescer. Coalesced is the number of calls
+// that shared the result of an identical call already in flight, rather than calling the store.
+type CoalesceStats struct {
+	Calls     uint64
+	Coalesced uint64
+}
@@ -0,0 +1,94 @@
+package coalesce
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/pkg/errors"
+)
+
+// Type check to ensure that Store implements the datastore.Coalescer interface
+var _ datastore.Coalescer = (*Store)(nil)
+
+// call is a store call in flight, or completed
+type call struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// Store sits in front of another datastore.DataStore and collapses concurrent identical read calls, so that
+// they result in a single call to the underlying store whose result is shared by every caller. The shared
+// call is made with a context of its own, bounded by a timeout, so that it is not cancelled along with the
+// first caller; each caller stops waiting for it once its own context is done. Changes are never coalesced.
+type Store struct {
+	*datastore.Decorator
+	timeout time.Duration
+
+	mutex     sync.Mutex
+	inFlight  map[string]*call
+	calls     uint64
+	coalesced uint64
+}
+
+// New returns a Store coalescing the calls made to the provided store, which are given up after timeout
+func New(store datastore.DataStore, timeout time.Duration) *Store {
+	s := &Store{timeout: timeout, inFlight: map[string]*call{}}
+	s.Decorator = datastore.NewDecorator(store, s.do, nil)
+	return s
+}
+
+// CoalesceStats returns the number of calls made, and how many of them were coalesced
+func (s *Store) CoalesceStats() datastore.CoalesceStats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return datastore.CoalesceStats{
+		Calls:     s.calls,
+		Coalesced: s.coalesced,
+	}
+}
+
+// do fetches the result of a call, unless an identical call is already in flight, in which case its result is
+// returned
+func (s *Store) do(ctx context.Context, dc datastore.Call, fetch datastore.Fetch) (interface{}, error) {
+	s.mutex.Lock()
+	s.calls++
+	c, ok := s.inFlight[dc.Key]
+	if ok {
+		s.coalesced++
+	} else {
+		c = &call{done: make(chan struct{})}
+		s.inFlight[dc.Key] = c
+		go s.fetch(dc.Key, c, fetch)
+	}
+	s.mutex.Unlock()
+
+	select {
+	case <-c.done:
+		return c.value, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fetch makes the shared call, and releases its callers once it returns or panics
+func (s *Store) fetch(key string, c *call, fetch datastore.Fetch) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.value, c.err = nil, errors.Errorf("store call panicked: %v", r)
+		}
+
+		s.mutex.Lock()
+		delete(s.inFlight, key)
+		s.mutex.Unlock()
+		close(c.done)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	c.value, c.err = fetch(ctx)
+}
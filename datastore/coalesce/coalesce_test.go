@@ -0,0 +1,164 @@
+package coalesce
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-graph/v2/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var errStore = errors.New("store error")
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a store whose calls are blocked until released", t, func() {
+		release := make(chan struct{})
+		mockStore := &storetest.DataStoreMock{
+			GetCodesFunc: func(ctx context.Context, codeListID string, editionID string) (*models.CodeResults, error) {
+				<-release
+				return &models.CodeResults{Items: []models.Code{{Code: "2"}, {Code: "1"}}}, nil
+			},
+			GetEditionsFunc: func(ctx context.Context, codeListID string) (*models.Editions, error) {
+				<-release
+				return nil, errStore
+			},
+		}
+		store := New(mockStore, time.Minute)
+
+		// waitInFlight blocks until n calls are waiting on the call in flight
+		waitInFlight := func(n uint64) {
+			for store.CoalesceStats().Calls < n {
+				runtime.Gosched()
+			}
+		}
+
+		Convey("When identical calls are made concurrently, the store is only called once", func() {
+			var wg sync.WaitGroup
+			results := make([]*models.CodeResults, 5)
+			errs := make([]error, 5)
+			for i := range results {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					results[i], errs[i] = store.GetCodes(ctx, "codelist", "2019")
+				}(i)
+			}
+			waitInFlight(5)
+			close(release)
+			wg.Wait()
+
+			So(errs, ShouldResemble, make([]error, 5))
+
+			So(mockStore.GetCodesCalls(), ShouldHaveLength, 1)
+			So(store.CoalesceStats(), ShouldResemble, datastore.CoalesceStats{Calls: 5, Coalesced: 4})
+
+			Convey("And each caller gets its own copy of the results", func() {
+				datastore.SortCodes(results[0].Items, datastore.OrderByID)
+				So(results[1].Items, ShouldResemble, []models.Code{{Code: "2"}, {Code: "1"}})
+			})
+		})
+
+		Convey("When different calls are made concurrently, the store is called for each of them", func() {
+			var wg sync.WaitGroup
+			for _, edition := range []string{"2018", "2019"} {
+				wg.Add(1)
+				go func(edition string) {
+					defer wg.Done()
+					store.GetCodes(ctx, "codelist", edition)
+				}(edition)
+			}
+			waitInFlight(2)
+			close(release)
+			wg.Wait()
+
+			So(mockStore.GetCodesCalls(), ShouldHaveLength, 2)
+			So(store.CoalesceStats().Coalesced, ShouldEqual, 0)
+		})
+
+		Convey("When an error is returned, it is shared by the coalesced calls but not kept", func() {
+			var wg sync.WaitGroup
+			errs := make([]error, 2)
+			for i := range errs {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					_, errs[i] = store.GetEditions(ctx, "codelist")
+				}(i)
+			}
+			waitInFlight(2)
+			close(release)
+			wg.Wait()
+
+			So(errs, ShouldResemble, []error{errStore, errStore})
+
+			_, err := store.GetEditions(ctx, "codelist")
+			So(err, ShouldEqual, errStore)
+			So(mockStore.GetEditionsCalls(), ShouldHaveLength, 2)
+		})
+
+		Convey("When the first caller gives up, the shared call goes on for the other callers", func() {
+			cancelled, cancel := context.WithCancel(ctx)
+			errs := make(chan error)
+			go func() {
+				_, err := store.GetCodes(cancelled, "codelist", "2019")
+				errs <- err
+			}()
+			waitInFlight(1)
+			cancel()
+			So(<-errs, ShouldEqual, context.Canceled)
+
+			var results *models.CodeResults
+			var err error
+			done := make(chan struct{})
+			go func() {
+				results, err = store.GetCodes(ctx, "codelist", "2019")
+				close(done)
+			}()
+			waitInFlight(2)
+			close(release)
+			<-done
+
+			So(err, ShouldBeNil)
+			So(results.Items, ShouldHaveLength, 2)
+			So(mockStore.GetCodesCalls(), ShouldHaveLength, 1)
+			So(store.CoalesceStats().Coalesced, ShouldEqual, 1)
+		})
+	})
+
+	Convey("Given a store whose calls panic", t, func() {
+		store := New(&storetest.DataStoreMock{
+			GetEditionsFunc: func(ctx context.Context, codeListID string) (*models.Editions, error) {
+				panic("store failure")
+			},
+		}, time.Minute)
+
+		Convey("The panic is returned as an error, and the call is no longer in flight", func() {
+			_, err := store.GetEditions(ctx, "codelist")
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "store failure")
+			So(store.inFlight, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a store whose calls outlast the timeout", t, func() {
+		store := New(&storetest.DataStoreMock{
+			GetEditionsFunc: func(ctx context.Context, codeListID string) (*models.Editions, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		}, time.Millisecond)
+
+		Convey("The shared call is given up", func() {
+			_, err := store.GetEditions(ctx, "codelist")
+			So(err, ShouldResemble, context.DeadlineExceeded)
+		})
+	})
+}
@@ -0,0 +1,18 @@
+package datastore
+
+import (
+	"context"
+	"math"
+
+	"github.com/ONSdigital/dp-graph/v2/models"
+)
+
+// GetAllCodes returns every code of an edition, in the order of the store. Unlike DataStore.GetCodes,
+// an edition without codes results in an empty list rather than an error.
+func GetAllCodes(ctx context.Context, store DataStore, codeListID, editionID string) ([]models.Code, error) {
+	codes, _, err := GetCodesPage(ctx, store, codeListID, editionID, Page{Limit: math.MaxInt32})
+	if err != nil {
+		return nil, err
+	}
+	return codes.Items, nil
+}
@@ -0,0 +1,99 @@
+package datastore
+
+import "github.com/ONSdigital/dp-graph/v2/models"
+
+// The copy functions below are used by stores sharing a result between callers, so that a caller
+// sorting or updating the items it received does not modify the items received by others.
+
+// CopyCodeLists returns a copy of the provided code lists
+func CopyCodeLists(items []models.CodeList) []models.CodeList {
+	if items == nil {
+		return nil
+	}
+	return append(make([]models.CodeList, 0, len(items)), items...)
+}
+
+// CopyEditions returns a copy of the provided editions
+func CopyEditions(items []models.Edition) []models.Edition {
+	if items == nil {
+		return nil
+	}
+	return append(make([]models.Edition, 0, len(items)), items...)
+}
+
+// CopyCodes returns a copy of the provided codes
+func CopyCodes(items []models.Code) []models.Code {
+	if items == nil {
+		return nil
+	}
+	return append(make([]models.Code, 0, len(items)), items...)
+}
+
+// CopyDatasets returns a copy of the provided datasets
+func CopyDatasets(items []models.Dataset) []models.Dataset {
+	if items == nil {
+		return nil
+	}
+	return append(make([]models.Dataset, 0, len(items)), items...)
+}
+
+// CopyCodeEditions returns a copy of the provided code editions
+func CopyCodeEditions(items []CodeEdition) []CodeEdition {
+	if items == nil {
+		return nil
+	}
+	return append(make([]CodeEdition, 0, len(items)), items...)
+}
+
+// CopyParentCodeIDs returns a copy of the provided parent code IDs
+func CopyParentCodeIDs(parents map[string]string) map[string]string {
+	if parents == nil {
+		return nil
+	}
+	parentsCopy := make(map[string]string, len(parents))
+	for code, parent := range parents {
+		parentsCopy[code] = parent
+	}
+	return parentsCopy
+}
+
+// CopyCodeListMetadata returns a copy of the provided metadata
+func CopyCodeListMetadata(metadata *CodeListMetadata) *CodeListMetadata {
+	if metadata == nil {
+		return nil
+	}
+	metadataCopy := *metadata
+	if metadata.Contact != nil {
+		contact := *metadata.Contact
+		metadataCopy.Contact = &contact
+	}
+	return &metadataCopy
+}
+
+// CopyCodeListsMetadata returns a copy of the provided code list metadata
+func CopyCodeListsMetadata(items []CodeListMetadata) []CodeListMetadata {
+	if items == nil {
+		return nil
+	}
+	itemsCopy := make([]CodeListMetadata, 0, len(items))
+	for i := range items {
+		itemsCopy = append(itemsCopy, *CopyCodeListMetadata(&items[i]))
+	}
+	return itemsCopy
+}
+
+// CopyEditionsMetadata returns a copy of the provided edition metadata
+func CopyEditionsMetadata(items []EditionMetadata) []EditionMetadata {
+	if items == nil {
+		return nil
+	}
+	return append(make([]EditionMetadata, 0, len(items)), items...)
+}
+
+// CopyMappings returns a copy of the provided mappings
+func CopyMappings(items []Mapping) []Mapping {
+	if items == nil {
+		return nil
+	}
+	return append(make([]Mapping, 0, len(items)), items...)
+}
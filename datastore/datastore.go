@@ -19,3 +19,21 @@ type DataStore interface {
 	GetCode(ctx context.Context, codeListID, editionID string, codeID string) (*models.Code, error)
 	GetCodeDatasets(ctx context.Context, codeListID, edition string, code string) (*models.Datasets, error)
 }
+
+// Unwrapper is implemented by stores decorating another store, e.g. to cache its results
+type Unwrapper interface {
+	Unwrap() DataStore
+}
+
+// Layers returns the store followed by each of the stores it decorates, outermost first
+func Layers(store DataStore) []DataStore {
+	layers := []DataStore{store}
+	for {
+		unwrapper, ok := store.(Unwrapper)
+		if !ok {
+			return layers
+		}
+		store = unwrapper.Unwrap()
+		layers = append(layers, store)
+	}
+}
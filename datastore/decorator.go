@@ -0,0 +1,491 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ONSdigital/dp-graph/v2/models"
+)
+
+// Type check to ensure that Decorator implements the DataStore interface and the optional datastore interfaces
+var (
+	_ DataStore             = (*Decorator)(nil)
+	_ Paginator             = (*Decorator)(nil)
+	_ Searcher              = (*Decorator)(nil)
+	_ ReverseLookup         = (*Decorator)(nil)
+	_ MultiGetter           = (*Decorator)(nil)
+	_ Hierarchy             = (*Decorator)(nil)
+	_ MetadataGetter        = (*Decorator)(nil)
+	_ EditionMetadataGetter = (*Decorator)(nil)
+	_ Relationships         = (*Decorator)(nil)
+	_ Writer                = (*Decorator)(nil)
+	_ Importer              = (*Decorator)(nil)
+)
+
+// Call is a read call made to a decorated store
+type Call struct {
+	// Method is the name of the DataStore method called
+	Method string
+	// CodeListID is the code list the result belongs to, or empty for results spanning every code list
+	CodeListID string
+	// Key identifies the call from its method and arguments
+	Key string
+}
+
+// Fetch makes a call to the decorated store, with the provided context
+type Fetch func(ctx context.Context) (interface{}, error)
+
+// Do makes a read call to a decorated store, by calling fetch or by sharing the result of another call. The
+// value returned is copied before it is returned to the caller, so it may be shared.
+type Do func(ctx context.Context, call Call, fetch Fetch) (interface{}, error)
+
+// Decorator implements DataStore and the optional datastore interfaces in front of another store, e.g. to
+// cache or coalesce its calls. Every read call goes through do, and changed is called with the code list of
+// each change once it is made, if set. Optional methods that the decorated store does not implement fall back
+// to the DataStore methods of the decorator, so that their calls go through do as well.
+type Decorator struct {
+	store   DataStore
+	do      Do
+	changed func(ctx context.Context, codeListID string)
+}
+
+// NewDecorator returns a Decorator making the read calls to store through do
+func NewDecorator(store DataStore, do Do, changed func(ctx context.Context, codeListID string)) *Decorator {
+	return &Decorator{store: store, do: do, changed: changed}
+}
+
+// Unwrap returns the decorated store
+func (d *Decorator) Unwrap() DataStore {
+	return d.store
+}
+
+// call makes a read call through do, identified by the method and its arguments
+func (d *Decorator) call(ctx context.Context, method, codeListID string, args []string, fetch Fetch) (interface{}, error) {
+	return d.do(ctx, Call{Method: method, CodeListID: codeListID, Key: callKey(method, args...)}, fetch)
+}
+
+// callPage makes a paginated read call through do, identified by the method, its arguments and the page
+func (d *Decorator) callPage(ctx context.Context, method, codeListID string, p Page, args []string, fetch Fetch) (interface{}, error) {
+	return d.do(ctx, Call{Method: method, CodeListID: codeListID, Key: pageKey(method, p, args...)}, fetch)
+}
+
+// change calls changed once a change to a code list is made
+func (d *Decorator) change(ctx context.Context, codeListID string) {
+	if d.changed != nil {
+		d.changed(ctx, codeListID)
+	}
+}
+
+// GetCodeLists returns the code lists of the requested type
+func (d *Decorator) GetCodeLists(ctx context.Context, filterBy string) (*models.CodeListResults, error) {
+	value, err := d.call(ctx, "GetCodeLists", "", []string{filterBy}, func(ctx context.Context) (interface{}, error) {
+		return d.store.GetCodeLists(ctx, filterBy)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.CodeListResults{Items: CopyCodeLists(value.(*models.CodeListResults).Items)}, nil
+}
+
+// GetCodeList returns a code list
+func (d *Decorator) GetCodeList(ctx context.Context, codeListID string) (*models.CodeList, error) {
+	value, err := d.call(ctx, "GetCodeList", codeListID, []string{codeListID}, func(ctx context.Context) (interface{}, error) {
+		return d.store.GetCodeList(ctx, codeListID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	codeList := *value.(*models.CodeList)
+	return &codeList, nil
+}
+
+// GetCodeListMetadata returns the metadata of a code list. Stores that do not hold the metadata of code lists
+// are not called, and the metadata only holds the ID of the code list for them.
+func (d *Decorator) GetCodeListMetadata(ctx context.Context, codeListID string) (*CodeListMetadata, error) {
+	getter, ok := d.store.(MetadataGetter)
+	if !ok {
+		return &CodeListMetadata{ID: codeListID}, nil
+	}
+
+	value, err := d.call(ctx, "GetCodeListMetadata", codeListID, []string{codeListID}, func(ctx context.Context) (interface{}, error) {
+		return getter.GetCodeListMetadata(ctx, codeListID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return CopyCodeListMetadata(value.(*CodeListMetadata)), nil
+}
+
+// GetCodeListsMetadata returns the metadata of every code list. Stores that do not hold the metadata of code
+// lists are not called, and no metadata is returned for them, as with GetCodeListMetadata.
+func (d *Decorator) GetCodeListsMetadata(ctx context.Context) ([]CodeListMetadata, error) {
+	getter, ok := d.store.(MetadataGetter)
+	if !ok {
+		return []CodeListMetadata{}, nil
+	}
+
+	value, err := d.call(ctx, "GetCodeListsMetadata", "", nil, func(ctx context.Context) (interface{}, error) {
+		return getter.GetCodeListsMetadata(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return CopyCodeListsMetadata(value.([]CodeListMetadata)), nil
+}
+
+// GetEditions returns the editions of a code list
+func (d *Decorator) GetEditions(ctx context.Context, codeListID string) (*models.Editions, error) {
+	value, err := d.call(ctx, "GetEditions", codeListID, []string{codeListID}, func(ctx context.Context) (interface{}, error) {
+		return d.store.GetEditions(ctx, codeListID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.Editions{Items: CopyEditions(value.(*models.Editions).Items)}, nil
+}
+
+// GetEditionsMetadata returns the metadata of the editions of a code list. Stores that do not hold edition
+// metadata are not called, and ErrNotSupported is returned for them.
+func (d *Decorator) GetEditionsMetadata(ctx context.Context, codeListID string) ([]EditionMetadata, error) {
+	getter, ok := d.store.(EditionMetadataGetter)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+
+	value, err := d.call(ctx, "GetEditionsMetadata", codeListID, []string{codeListID}, func(ctx context.Context) (interface{}, error) {
+		return getter.GetEditionsMetadata(ctx, codeListID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return CopyEditionsMetadata(value.([]EditionMetadata)), nil
+}
+
+// GetEdition returns an edition of a code list
+func (d *Decorator) GetEdition(ctx context.Context, codeListID, editionID string) (*models.Edition, error) {
+	value, err := d.call(ctx, "GetEdition", codeListID, []string{codeListID, editionID}, func(ctx context.Context) (interface{}, error) {
+		return d.store.GetEdition(ctx, codeListID, editionID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	edition := *value.(*models.Edition)
+	return &edition, nil
+}
+
+// CountCodes returns the number of codes in an edition
+func (d *Decorator) CountCodes(ctx context.Context, codeListID, edition string) (int64, error) {
+	value, err := d.call(ctx, "CountCodes", codeListID, []string{codeListID, edition}, func(ctx context.Context) (interface{}, error) {
+		return d.store.CountCodes(ctx, codeListID, edition)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return value.(int64), nil
+}
+
+// GetCodes returns the codes of an edition
+func (d *Decorator) GetCodes(ctx context.Context, codeListID, editionID string) (*models.CodeResults, error) {
+	value, err := d.call(ctx, "GetCodes", codeListID, []string{codeListID, editionID}, func(ctx context.Context) (interface{}, error) {
+		return d.store.GetCodes(ctx, codeListID, editionID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.CodeResults{Items: CopyCodes(value.(*models.CodeResults).Items)}, nil
+}
+
+// GetCode returns a code of an edition
+func (d *Decorator) GetCode(ctx context.Context, codeListID, editionID string, codeID string) (*models.Code, error) {
+	value, err := d.call(ctx, "GetCode", codeListID, []string{codeListID, editionID, codeID}, func(ctx context.Context) (interface{}, error) {
+		return d.store.GetCode(ctx, codeListID, editionID, codeID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	code := *value.(*models.Code)
+	return &code, nil
+}
+
+// GetCodesByID returns the codes of an edition with the provided IDs. If the decorated store does not support
+// getting many codes at once, they are taken from the results of the DataStore methods of the decorator.
+func (d *Decorator) GetCodesByID(ctx context.Context, codeListID, editionID string, codeIDs []string) ([]models.Code, error) {
+	getter, ok := d.store.(MultiGetter)
+	if !ok {
+		return GetCodesByID(ctx, baseStore{d}, codeListID, editionID, codeIDs)
+	}
+
+	value, err := d.call(ctx, "GetCodesByID", codeListID, append([]string{codeListID, editionID}, codeIDs...), func(ctx context.Context) (interface{}, error) {
+		return getter.GetCodesByID(ctx, codeListID, editionID, codeIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return CopyCodes(value.([]models.Code)), nil
+}
+
+// GetCodeDatasets returns the datasets related to a code
+func (d *Decorator) GetCodeDatasets(ctx context.Context, codeListID, edition string, code string) (*models.Datasets, error) {
+	value, err := d.call(ctx, "GetCodeDatasets", codeListID, []string{codeListID, edition, code}, func(ctx context.Context) (interface{}, error) {
+		return d.store.GetCodeDatasets(ctx, codeListID, edition, code)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.Datasets{Items: CopyDatasets(value.(*models.Datasets).Items)}, nil
+}
+
+// GetCodeEditions returns the code list editions containing a code. As a code may be added to any code list,
+// the result belongs to every code list. Stores that cannot look codes up are not called, and ErrNotSupported
+// is returned for them.
+func (d *Decorator) GetCodeEditions(ctx context.Context, codeID string) ([]CodeEdition, error) {
+	lookup, ok := d.store.(ReverseLookup)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+
+	value, err := d.call(ctx, "GetCodeEditions", "", []string{codeID}, func(ctx context.Context) (interface{}, error) {
+		return lookup.GetCodeEditions(ctx, codeID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return CopyCodeEditions(value.([]CodeEdition)), nil
+}
+
+// GetParentCode returns the parent of a code, or nil if the code is at the top of the hierarchy. Stores that
+// do not hold hierarchies are not called, and ErrNotSupported is returned for them.
+func (d *Decorator) GetParentCode(ctx context.Context, codeListID, editionID, codeID string) (*models.Code, error) {
+	hierarchy, ok := d.store.(Hierarchy)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+
+	value, err := d.call(ctx, "GetParentCode", codeListID, []string{codeListID, editionID, codeID}, func(ctx context.Context) (interface{}, error) {
+		return hierarchy.GetParentCode(ctx, codeListID, editionID, codeID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	parent := value.(*models.Code)
+	if parent == nil {
+		return nil, nil
+	}
+	code := *parent
+	return &code, nil
+}
+
+// GetChildCodes returns the children of a code. Stores that do not hold hierarchies are not called, and
+// ErrNotSupported is returned for them.
+func (d *Decorator) GetChildCodes(ctx context.Context, codeListID, editionID, codeID string) ([]models.Code, error) {
+	hierarchy, ok := d.store.(Hierarchy)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+
+	value, err := d.call(ctx, "GetChildCodes", codeListID, []string{codeListID, editionID, codeID}, func(ctx context.Context) (interface{}, error) {
+		return hierarchy.GetChildCodes(ctx, codeListID, editionID, codeID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return CopyCodes(value.([]models.Code)), nil
+}
+
+// GetParentCodeIDs returns the ID of the parent of every code of an edition. Stores that do not hold
+// hierarchies are not called, and ErrNotSupported is returned for them.
+func (d *Decorator) GetParentCodeIDs(ctx context.Context, codeListID, editionID string) (map[string]string, error) {
+	hierarchy, ok := d.store.(Hierarchy)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+
+	value, err := d.call(ctx, "GetParentCodeIDs", codeListID, []string{codeListID, editionID}, func(ctx context.Context) (interface{}, error) {
+		return hierarchy.GetParentCodeIDs(ctx, codeListID, editionID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return CopyParentCodeIDs(value.(map[string]string)), nil
+}
+
+// GetMappings returns the mappings from and to a code. They belong to the code list of the code, so a change
+// to the code list of the other end of a mapping is not a change of the mappings. Stores that do not hold
+// mappings are not called, and ErrNotSupported is returned for them.
+func (d *Decorator) GetMappings(ctx context.Context, codeListID, editionID, codeID string) ([]Mapping, error) {
+	relationships, ok := d.store.(Relationships)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+
+	value, err := d.call(ctx, "GetMappings", codeListID, []string{codeListID, editionID, codeID}, func(ctx context.Context) (interface{}, error) {
+		return relationships.GetMappings(ctx, codeListID, editionID, codeID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return CopyMappings(value.([]Mapping)), nil
+}
+
+// CreateCodeList creates a code list in the decorated store
+func (d *Decorator) CreateCodeList(ctx context.Context, metadata CodeListMetadata) error {
+	defer d.change(ctx, metadata.ID)
+	return CreateCodeList(ctx, d.store, metadata)
+}
+
+// PutEdition creates or replaces an edition in the decorated store
+func (d *Decorator) PutEdition(ctx context.Context, codeListID string, edition EditionUpdate) (bool, error) {
+	defer d.change(ctx, codeListID)
+	return PutEdition(ctx, d.store, codeListID, edition)
+}
+
+// PutCode creates or replaces a code in the decorated store
+func (d *Decorator) PutCode(ctx context.Context, codeListID, editionID string, code CodeUpdate) (bool, error) {
+	defer d.change(ctx, codeListID)
+	return PutCode(ctx, d.store, codeListID, editionID, code)
+}
+
+// DeleteCode deletes a code in the decorated store
+func (d *Decorator) DeleteCode(ctx context.Context, codeListID, editionID, codeID string) error {
+	defer d.change(ctx, codeListID)
+	return DeleteCode(ctx, d.store, codeListID, editionID, codeID)
+}
+
+// ImportEdition creates an edition with its codes in the decorated store
+func (d *Decorator) ImportEdition(ctx context.Context, codeListID string, edition EditionUpdate, codes []CodeUpdate) error {
+	defer d.change(ctx, codeListID)
+	return ImportEdition(ctx, d.store, codeListID, edition, codes)
+}
+
+// page is the result of a paginated call, along with the total number of results
+type page struct {
+	items      interface{}
+	totalCount int
+}
+
+// GetCodeListsPage returns a page of code lists. If the decorated store does not support pagination, the page
+// is taken from the result of GetCodeLists instead.
+func (d *Decorator) GetCodeListsPage(ctx context.Context, filterBy string, p Page) (*models.CodeListResults, int, error) {
+	paginator, ok := d.store.(Paginator)
+	if !ok {
+		return GetCodeListsPage(ctx, baseStore{d}, filterBy, p)
+	}
+
+	value, err := d.callPage(ctx, "GetCodeListsPage", "", p, []string{filterBy}, func(ctx context.Context) (interface{}, error) {
+		codeLists, totalCount, err := paginator.GetCodeListsPage(ctx, filterBy, p)
+		if err != nil {
+			return nil, err
+		}
+		return page{items: codeLists.Items, totalCount: totalCount}, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	result := value.(page)
+	return &models.CodeListResults{Items: CopyCodeLists(result.items.([]models.CodeList))}, result.totalCount, nil
+}
+
+// GetEditionsPage returns a page of the editions of a code list. If the decorated store does not support
+// pagination, the page is taken from the result of GetEditions instead.
+func (d *Decorator) GetEditionsPage(ctx context.Context, codeListID string, p Page) (*models.Editions, int, error) {
+	paginator, ok := d.store.(Paginator)
+	if !ok {
+		return GetEditionsPage(ctx, baseStore{d}, codeListID, p)
+	}
+
+	value, err := d.callPage(ctx, "GetEditionsPage", codeListID, p, []string{codeListID}, func(ctx context.Context) (interface{}, error) {
+		editions, totalCount, err := paginator.GetEditionsPage(ctx, codeListID, p)
+		if err != nil {
+			return nil, err
+		}
+		return page{items: editions.Items, totalCount: totalCount}, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	result := value.(page)
+	return &models.Editions{Items: CopyEditions(result.items.([]models.Edition))}, result.totalCount, nil
+}
+
+// GetCodesPage returns a page of the codes of an edition. If the decorated store does not support pagination,
+// the page is taken from the results of CountCodes and GetCodes instead.
+func (d *Decorator) GetCodesPage(ctx context.Context, codeListID, editionID string, p Page) (*models.CodeResults, int, error) {
+	paginator, ok := d.store.(Paginator)
+	if !ok {
+		return GetCodesPage(ctx, baseStore{d}, codeListID, editionID, p)
+	}
+
+	value, err := d.callPage(ctx, "GetCodesPage", codeListID, p, []string{codeListID, editionID}, func(ctx context.Context) (interface{}, error) {
+		codes, totalCount, err := paginator.GetCodesPage(ctx, codeListID, editionID, p)
+		if err != nil {
+			return nil, err
+		}
+		return page{items: codes.Items, totalCount: totalCount}, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	result := value.(page)
+	return &models.CodeResults{Items: CopyCodes(result.items.([]models.Code))}, result.totalCount, nil
+}
+
+// GetCodeDatasetsPage returns a page of the datasets related to a code. If the decorated store does not
+// support pagination, the page is taken from the result of GetCodeDatasets instead.
+func (d *Decorator) GetCodeDatasetsPage(ctx context.Context, codeListID, edition string, code string, p Page) (*models.Datasets, int, error) {
+	paginator, ok := d.store.(Paginator)
+	if !ok {
+		return GetCodeDatasetsPage(ctx, baseStore{d}, codeListID, edition, code, p)
+	}
+
+	value, err := d.callPage(ctx, "GetCodeDatasetsPage", codeListID, p, []string{codeListID, edition, code}, func(ctx context.Context) (interface{}, error) {
+		datasets, totalCount, err := paginator.GetCodeDatasetsPage(ctx, codeListID, edition, code, p)
+		if err != nil {
+			return nil, err
+		}
+		return page{items: datasets.Items, totalCount: totalCount}, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	result := value.(page)
+	return &models.Datasets{Items: CopyDatasets(result.items.([]models.Dataset))}, result.totalCount, nil
+}
+
+// SearchCodes returns a page of the codes of an edition matching the query. If the decorated store does not
+// support searching, the codes are matched against the results of CountCodes and GetCodes instead.
+func (d *Decorator) SearchCodes(ctx context.Context, codeListID, editionID, query string, p Page) (*models.CodeResults, int, error) {
+	searcher, ok := d.store.(Searcher)
+	if !ok {
+		return SearchCodes(ctx, baseStore{d}, codeListID, editionID, query, p)
+	}
+
+	value, err := d.callPage(ctx, "SearchCodes", codeListID, p, []string{codeListID, editionID, query}, func(ctx context.Context) (interface{}, error) {
+		codes, totalCount, err := searcher.SearchCodes(ctx, codeListID, editionID, query, p)
+		if err != nil {
+			return nil, err
+		}
+		return page{items: codes.Items, totalCount: totalCount}, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	result := value.(page)
+	return &models.CodeResults{Items: CopyCodes(result.items.([]models.Code))}, result.totalCount, nil
+}
+
+// baseStore hides the optional datastore methods of a Decorator, so that the in-memory fallbacks go through
+// the decorated DataStore methods
+type baseStore struct {
+	DataStore
+}
+
+// callKey identifies a call from the method name and its arguments
+func callKey(method string, args ...string) string {
+	return method + "\x00" + strings.Join(args, "\x00")
+}
+
+// pageKey identifies a paginated call from the method name, its arguments and the page
+func pageKey(method string, p Page, args ...string) string {
+	return callKey(method, append(args, fmt.Sprintf("%d,%d,%s", p.Offset, p.Limit, p.Order))...)
+}
@@ -0,0 +1,141 @@
+package datastore
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	"github.com/ONSdigital/dp-graph/v2/models"
+)
+
+//go:generate moq -out datastoretest/editionmetadatagetter.go -pkg storetest . EditionMetadataGetter
+
+// EditionState is the publication state of an edition
+type EditionState string
+
+// Possible states of an edition
+const (
+	EditionDraft     EditionState = "draft"
+	EditionPublished EditionState = "published"
+	EditionRetired   EditionState = "retired"
+)
+
+// Valid reports whether the state is one of the possible states of an edition
+func (s EditionState) Valid() bool {
+	return s == EditionDraft || s == EditionPublished || s == EditionRetired
+}
+
+// Published reports whether an edition in the given state, with the given release date, is published. Editions
+// in the published state are, as are editions without a state that have a release date, for the stores and
+// files that do not set the state of editions.
+func Published(state EditionState, releaseDate *time.Time) bool {
+	return state == EditionPublished || (state == "" && releaseDate != nil)
+}
+
+// EditionMetadata describes the publication of an edition. Supersedes and SupersededBy are the IDs of the
+// previous and next editions of the same code list, if any. CodeCount is the number of codes in the edition,
+// returned with the metadata so that listing editions does not count the codes of each one.
+type EditionMetadata struct {
+	ID           string
+	ReleaseDate  *time.Time
+	State        EditionState
+	Supersedes   string
+	SupersededBy string
+	CodeCount    int
+}
+
+// EditionMetadataGetter is implemented by stores holding the publication metadata of editions
+type EditionMetadataGetter interface {
+	// GetEditionsMetadata returns the metadata of the editions of a code list, in no particular order
+	GetEditionsMetadata(ctx context.Context, codeListID string) ([]EditionMetadata, error)
+}
+
+// GetEditionsMetadata returns the metadata of the editions of a code list, keyed by edition ID. Stores that
+// do not implement EditionMetadataGetter, or return ErrNotSupported, do not hold any metadata, and an empty
+// map is returned.
+func GetEditionsMetadata(ctx context.Context, store DataStore, codeListID string) (map[string]EditionMetadata, error) {
+	metadata := map[string]EditionMetadata{}
+	getter, ok := store.(EditionMetadataGetter)
+	if !ok {
+		return metadata, nil
+	}
+
+	items, err := getter.GetEditionsMetadata(ctx, codeListID)
+	if err == ErrNotSupported {
+		return metadata, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		metadata[item.ID] = item
+	}
+	return metadata, nil
+}
+
+// SortEditionsByReleaseDate sorts editions in place by release date, oldest first unless newestFirst is
+// true. Editions without a release date are sorted last by ID.
+func SortEditionsByReleaseDate(items []models.Edition, metadata map[string]EditionMetadata, newestFirst bool) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := metadata[items[i].ID].ReleaseDate, metadata[items[j].ID].ReleaseDate
+		switch {
+		case a == nil && b == nil:
+			return items[i].ID < items[j].ID
+		case a == nil || b == nil:
+			return b == nil
+		case a.Equal(*b):
+			return items[i].ID < items[j].ID
+		case newestFirst:
+			return a.After(*b)
+		default:
+			return a.Before(*b)
+		}
+	})
+}
+
+// GetLatestEditionID returns the ID of the current edition of a code list: the published edition with the
+// latest release date. Editions without a state are only candidates if they have a release date, as the
+// current edition cannot be told from an edition ID. driver.ErrNotFound is returned if there is no candidate,
+// and ErrNotSupported for stores that do not implement EditionMetadataGetter.
+func GetLatestEditionID(ctx context.Context, store DataStore, codeListID string) (string, error) {
+	getter, ok := store.(EditionMetadataGetter)
+	if !ok {
+		return "", ErrNotSupported
+	}
+	metadata, err := getter.GetEditionsMetadata(ctx, codeListID)
+	if err != nil {
+		return "", err
+	}
+
+	latest := ""
+	var latestDate *time.Time
+	for _, m := range metadata {
+		if !Published(m.State, m.ReleaseDate) {
+			continue
+		}
+		if latest == "" || isLater(m.ReleaseDate, m.ID, latestDate, latest) {
+			latest, latestDate = m.ID, m.ReleaseDate
+		}
+	}
+
+	if latest == "" {
+		return "", driver.ErrNotFound
+	}
+	return latest, nil
+}
+
+// isLater reports whether an edition is later than another, by release date then by ID. An edition without
+// a release date is earlier than any edition with one.
+func isLater(date *time.Time, id string, otherDate *time.Time, otherID string) bool {
+	switch {
+	case date == nil && otherDate != nil:
+		return false
+	case date != nil && otherDate == nil:
+		return true
+	case date != nil && !date.Equal(*otherDate):
+		return date.After(*otherDate)
+	default:
+		return id > otherID
+	}
+}
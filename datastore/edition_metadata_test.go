@@ -0,0 +1,118 @@
+package datastore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	"github.com/ONSdigital/dp-graph/v2/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSortEditionsByReleaseDate(t *testing.T) {
+	Convey("Given editions with and without a release date", t, func() {
+		date2019 := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+		date2020 := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+		metadata := map[string]datastore.EditionMetadata{
+			"2019": {ID: "2019", ReleaseDate: &date2019},
+			"2020": {ID: "2020", ReleaseDate: &date2020},
+		}
+		editions := []models.Edition{{ID: "draft"}, {ID: "2020"}, {ID: "2019"}, {ID: "another-draft"}}
+
+		Convey("Editions are sorted oldest first, followed by the editions without a release date", func() {
+			datastore.SortEditionsByReleaseDate(editions, metadata, false)
+			So(editions, ShouldResemble, []models.Edition{{ID: "2019"}, {ID: "2020"}, {ID: "another-draft"}, {ID: "draft"}})
+		})
+
+		Convey("Editions are sorted newest first, followed by the editions without a release date", func() {
+			datastore.SortEditionsByReleaseDate(editions, metadata, true)
+			So(editions, ShouldResemble, []models.Edition{{ID: "2020"}, {ID: "2019"}, {ID: "another-draft"}, {ID: "draft"}})
+		})
+	})
+}
+
+func TestGetLatestEditionID(t *testing.T) {
+	ctx := context.Background()
+	date2019 := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+	date2020 := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	newStore := func(editions []models.Edition, metadata []datastore.EditionMetadata) datastore.DataStore {
+		return struct {
+			*storetest.DataStoreMock
+			*storetest.EditionMetadataGetterMock
+		}{
+			&storetest.DataStoreMock{
+				GetEditionsFunc: func(ctx context.Context, codeListID string) (*models.Editions, error) {
+					return &models.Editions{Items: editions}, nil
+				},
+			},
+			&storetest.EditionMetadataGetterMock{
+				GetEditionsMetadataFunc: func(ctx context.Context, codeListID string) ([]datastore.EditionMetadata, error) {
+					return metadata, nil
+				},
+			},
+		}
+	}
+
+	Convey("The latest edition is the published edition with the latest release date", t, func() {
+		store := newStore([]models.Edition{{ID: "2019"}, {ID: "2020"}, {ID: "2021"}}, []datastore.EditionMetadata{
+			{ID: "2019", ReleaseDate: &date2019, State: datastore.EditionRetired},
+			{ID: "2020", ReleaseDate: &date2020, State: datastore.EditionPublished},
+			{ID: "2021", State: datastore.EditionDraft},
+		})
+		latest, err := datastore.GetLatestEditionID(ctx, store, "codelist")
+		So(err, ShouldBeNil)
+		So(latest, ShouldEqual, "2020")
+	})
+
+	Convey("An edition without a state is a candidate if it has a release date", t, func() {
+		store := newStore([]models.Edition{{ID: "2019"}, {ID: "2020"}, {ID: "2021"}}, []datastore.EditionMetadata{
+			{ID: "2019", ReleaseDate: &date2019},
+			{ID: "2020", ReleaseDate: &date2020},
+			{ID: "2021"},
+		})
+		latest, err := datastore.GetLatestEditionID(ctx, store, "codelist")
+		So(err, ShouldBeNil)
+		So(latest, ShouldEqual, "2020")
+	})
+
+	Convey("GetLatestEditionID returns ErrNotFound when no edition has release metadata", t, func() {
+		store := newStore([]models.Edition{{ID: "2020"}, {ID: "2021"}}, []datastore.EditionMetadata{{ID: "2020"}, {ID: "2021"}})
+		_, err := datastore.GetLatestEditionID(ctx, store, "codelist")
+		So(err, ShouldEqual, driver.ErrNotFound)
+	})
+
+	Convey("GetLatestEditionID returns ErrNotSupported when the store does not hold edition metadata", t, func() {
+		store := &storetest.DataStoreMock{}
+		_, err := datastore.GetLatestEditionID(ctx, store, "codelist")
+		So(err, ShouldEqual, datastore.ErrNotSupported)
+	})
+
+	Convey("GetLatestEditionID returns ErrNotFound when no edition is published", t, func() {
+		store := newStore([]models.Edition{{ID: "2021"}}, []datastore.EditionMetadata{{ID: "2021", State: datastore.EditionDraft}})
+		_, err := datastore.GetLatestEditionID(ctx, store, "codelist")
+		So(err, ShouldEqual, driver.ErrNotFound)
+	})
+}
+
+func TestPublished(t *testing.T) {
+	releaseDate := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	Convey("Editions in the published state are published, with or without a release date", t, func() {
+		So(datastore.Published(datastore.EditionPublished, &releaseDate), ShouldBeTrue)
+		So(datastore.Published(datastore.EditionPublished, nil), ShouldBeTrue)
+	})
+
+	Convey("Editions without a state are published if they have a release date", t, func() {
+		So(datastore.Published("", &releaseDate), ShouldBeTrue)
+		So(datastore.Published("", nil), ShouldBeFalse)
+	})
+
+	Convey("Editions in the other states are not published", t, func() {
+		So(datastore.Published(datastore.EditionDraft, &releaseDate), ShouldBeFalse)
+		So(datastore.Published(datastore.EditionRetired, &releaseDate), ShouldBeFalse)
+	})
+}
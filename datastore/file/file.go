@@ -0,0 +1,514 @@
+package file
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/csvimport"
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/datastore/memory"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/pkg/errors"
+)
+
+const (
+	// metadataFile is the optional file, in a code list folder, holding the code list metadata
+	metadataFile = "codelist.json"
+	// editionExt is the extension of the edition files, in a code list folder
+	editionExt = ".csv"
+	// latestEdition is the edition ID used by the API as an alias of the current edition of a code list
+	latestEdition = "latest"
+)
+
+// Type check to ensure that Store implements the datastore.DataStore interface
+var _ datastore.DataStore = (*Store)(nil)
+
+// metadata is the content of a code list metadata file
+type metadata struct {
+	Label       string             `json:"label"`
+	Description string             `json:"description"`
+	Type        string             `json:"type"`
+	Licence     string             `json:"licence"`
+	Contact     *datastore.Contact `json:"contact"`
+	Editions    map[string]struct {
+		Label       string                 `json:"label"`
+		ReleaseDate *time.Time             `json:"release_date"`
+		State       datastore.EditionState `json:"state"`
+		Supersedes  string                 `json:"supersedes"`
+	} `json:"editions"`
+}
+
+// mappingHeader is the header of the mapping tables, which are the CSV files at the top of the directory
+var mappingHeader = []string{"source_code_list", "source_edition", "source_code", "target_code_list", "target_edition", "target_code", "type"}
+
+// Store is a datastore.DataStore serving the code lists found in a directory, which contains
+// one folder per code list and one CSV file (with a code,label header) per edition. CSV files at the
+// top of the directory are mapping tables, holding the mappings between codes.
+type Store struct {
+	*memory.Store
+	dir string
+
+	mutex       sync.RWMutex
+	fingerprint string
+	loadErr     error
+	onReload    func(ctx context.Context)
+
+	closeOnce sync.Once
+	closing   chan struct{}
+	closed    chan struct{}
+}
+
+// New loads the code lists found in dir and returns a Store serving them. If reloadInterval is
+// greater than zero, the directory is watched and the store reloaded when any file changes.
+func New(ctx context.Context, dir string, reloadInterval time.Duration) (*Store, error) {
+	s := &Store{
+		Store:   memory.New(nil),
+		dir:     dir,
+		closing: make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+
+	fingerprint, err := s.fingerprintDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.load(fingerprint); err != nil {
+		return nil, err
+	}
+
+	if reloadInterval <= 0 {
+		close(s.closed)
+		return s, nil
+	}
+
+	go s.watch(ctx, reloadInterval)
+	return s, nil
+}
+
+// OnReload registers a function called every time the directory has been reloaded, e.g. to drop the results
+// cached from the previous code lists
+func (s *Store) OnReload(fn func(ctx context.Context)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.onReload = fn
+}
+
+// Checker reports a warning if the last reload of the directory failed, in which case the
+// previously loaded code lists are still being served.
+func (s *Store) Checker(ctx context.Context, state *healthcheck.CheckState) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.loadErr != nil {
+		return state.Update(healthcheck.StatusWarning, fmt.Sprintf("failed to reload code lists: %s", s.loadErr), 0)
+	}
+	return state.Update(healthcheck.StatusOK, "code list files loaded", 0)
+}
+
+// Close stops watching the directory. It may be called again, e.g. after the context of a first call was done
+// before the watch stopped.
+func (s *Store) Close(ctx context.Context) error {
+	s.closeOnce.Do(func() { close(s.closing) })
+	select {
+	case <-s.closed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// CreateCodeList is not supported, as the files are the source of the code lists
+func (s *Store) CreateCodeList(ctx context.Context, metadata datastore.CodeListMetadata) error {
+	return datastore.ErrNotSupported
+}
+
+// PutEdition is not supported, as the files are the source of the code lists
+func (s *Store) PutEdition(ctx context.Context, codeListID string, edition datastore.EditionUpdate) (bool, error) {
+	return false, datastore.ErrNotSupported
+}
+
+// PutCode is not supported, as the files are the source of the code lists
+func (s *Store) PutCode(ctx context.Context, codeListID, editionID string, code datastore.CodeUpdate) (bool, error) {
+	return false, datastore.ErrNotSupported
+}
+
+// DeleteCode is not supported, as the files are the source of the code lists
+func (s *Store) DeleteCode(ctx context.Context, codeListID, editionID, codeID string) error {
+	return datastore.ErrNotSupported
+}
+
+// ImportEdition is not supported, as the files are the source of the code lists
+func (s *Store) ImportEdition(ctx context.Context, codeListID string, edition datastore.EditionUpdate, codes []datastore.CodeUpdate) error {
+	return datastore.ErrNotSupported
+}
+
+// watch polls the directory for changes until the store is closed
+func (s *Store) watch(ctx context.Context, interval time.Duration) {
+	defer close(s.closed)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.reload(ctx)
+		case <-s.closing:
+			return
+		}
+	}
+}
+
+// reload loads the directory again if any of its files changed since the last load
+func (s *Store) reload(ctx context.Context) {
+	logData := log.Data{"dir": s.dir}
+
+	fingerprint, err := s.fingerprintDir()
+	if err != nil {
+		log.Event(ctx, "failed to check code list files for changes", log.ERROR, log.Error(err), logData)
+		s.setLoadErr(err)
+		return
+	}
+
+	s.mutex.RLock()
+	unchanged := fingerprint == s.fingerprint
+	s.mutex.RUnlock()
+	if unchanged {
+		return
+	}
+
+	if err := s.load(fingerprint); err != nil {
+		log.Event(ctx, "failed to reload code list files, previous code lists are still served", log.ERROR, log.Error(err), logData)
+		return
+	}
+	log.Event(ctx, "code list files reloaded", log.INFO, logData)
+
+	s.mutex.RLock()
+	onReload := s.onReload
+	s.mutex.RUnlock()
+	if onReload != nil {
+		onReload(ctx)
+	}
+}
+
+// load reads the directory and replaces the content of the store. Nothing is replaced if any file is invalid.
+func (s *Store) load(fingerprint string) error {
+	fixtures, err := Load(s.dir)
+	if err != nil {
+		s.setLoadErr(err)
+		return err
+	}
+
+	s.Seed(fixtures)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.fingerprint = fingerprint
+	s.loadErr = nil
+	return nil
+}
+
+func (s *Store) setLoadErr(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.loadErr = err
+}
+
+// fingerprintDir summarises the name, size and modification time of every file in the directory
+func (s *Store) fingerprintDir() (string, error) {
+	var b strings.Builder
+	err := filepath.Walk(s.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "%s|%d|%d;", path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read code list directory %q", s.dir)
+	}
+	return b.String(), nil
+}
+
+// Load reads the code lists found in dir: each folder is a code list, and each CSV file in it an edition.
+// Each CSV file in dir itself is a mapping table.
+func Load(dir string) (*memory.Fixtures, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read code list directory %q", dir)
+	}
+
+	fixtures := &memory.Fixtures{CodeLists: []memory.CodeList{}, Mappings: []datastore.Mapping{}}
+	mappingTables := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			if strings.HasSuffix(entry.Name(), editionExt) {
+				mappingTables = append(mappingTables, filepath.Join(dir, entry.Name()))
+			}
+			continue
+		}
+		codeList, err := loadCodeList(filepath.Join(dir, entry.Name()), entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		fixtures.CodeLists = append(fixtures.CodeLists, *codeList)
+	}
+
+	// mappings can only be checked once every code list is loaded
+	codes := map[datastore.CodeRef]bool{}
+	for _, codeList := range fixtures.CodeLists {
+		for _, edition := range codeList.Editions {
+			for _, code := range edition.Codes {
+				codes[datastore.CodeRef{CodeListID: codeList.ID, EditionID: edition.ID, Code: code.Code}] = true
+			}
+		}
+	}
+	for _, path := range mappingTables {
+		mappings, err := loadMappings(path, codes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid mapping table %q", filepath.Base(path))
+		}
+		fixtures.Mappings = append(fixtures.Mappings, mappings...)
+	}
+	return fixtures, nil
+}
+
+// loadMappings reads a mapping table, checking that the source and target of each mapping are known codes
+func loadMappings(path string, codes map[datastore.CodeRef]bool) ([]datastore.Mapping, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = len(mappingHeader)
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, errors.Errorf("empty file, a %s header is required", strings.Join(mappingHeader, ","))
+	}
+	if err != nil {
+		return nil, err
+	}
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	for i := range header {
+		if !strings.EqualFold(strings.TrimSpace(header[i]), mappingHeader[i]) {
+			return nil, errors.Errorf("invalid header %q, expected %s", strings.Join(header, ","), strings.Join(mappingHeader, ","))
+		}
+	}
+
+	mappings := []datastore.Mapping{}
+	line := 1
+	for {
+		line++
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+
+		mapping := datastore.Mapping{
+			Source: datastore.CodeRef{CodeListID: record[0], EditionID: record[1], Code: record[2]},
+			Target: datastore.CodeRef{CodeListID: record[3], EditionID: record[4], Code: record[5]},
+			Type:   datastore.MappingType(record[6]),
+		}
+		if !codes[mapping.Source] {
+			return nil, errors.Errorf("unknown source code %q of edition %q of code list %q on line %d", mapping.Source.Code, mapping.Source.EditionID, mapping.Source.CodeListID, line)
+		}
+		if !codes[mapping.Target] {
+			return nil, errors.Errorf("unknown target code %q of edition %q of code list %q on line %d", mapping.Target.Code, mapping.Target.EditionID, mapping.Target.CodeListID, line)
+		}
+		if !mapping.Type.Valid() {
+			return nil, errors.Errorf("invalid mapping type %q on line %d", mapping.Type, line)
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings, nil
+}
+
+func loadCodeList(dir, codeListID string) (*memory.CodeList, error) {
+	meta := &metadata{}
+	b, err := ioutil.ReadFile(filepath.Join(dir, metadataFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "failed to read metadata of code list %q", codeListID)
+	}
+	if err == nil {
+		if err := json.Unmarshal(b, meta); err != nil {
+			return nil, errors.Wrapf(err, "invalid metadata for code list %q", codeListID)
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"+editionExt))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list editions of code list %q", codeListID)
+	}
+	sort.Strings(files)
+
+	codeList := &memory.CodeList{
+		ID:          codeListID,
+		Label:       meta.Label,
+		Description: meta.Description,
+		Type:        meta.Type,
+		Licence:     meta.Licence,
+		Contact:     meta.Contact,
+		Editions:    []memory.Edition{},
+	}
+	for _, path := range files {
+		editionID := strings.TrimSuffix(filepath.Base(path), editionExt)
+		if editionID == latestEdition {
+			return nil, errors.Errorf("invalid edition %q of code list %q, the edition ID is reserved", editionID, codeListID)
+		}
+		codes, err := loadCodes(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid edition %q of code list %q", editionID, codeListID)
+		}
+
+		editionMeta := meta.Editions[editionID]
+		if editionMeta.State != "" && !editionMeta.State.Valid() {
+			return nil, errors.Errorf("invalid state %q of edition %q of code list %q", editionMeta.State, editionID, codeListID)
+		}
+		label := editionMeta.Label
+		if label == "" {
+			label = editionID
+		}
+		codeList.Editions = append(codeList.Editions, memory.Edition{
+			ID:          editionID,
+			Label:       label,
+			ReleaseDate: editionMeta.ReleaseDate,
+			State:       editionMeta.State,
+			Supersedes:  editionMeta.Supersedes,
+			Codes:       codes,
+		})
+	}
+
+	for _, edition := range codeList.Editions {
+		if edition.Supersedes != "" && !hasEdition(codeList, edition.Supersedes) {
+			return nil, errors.Errorf("edition %q of code list %q supersedes unknown edition %q", edition.ID, codeListID, edition.Supersedes)
+		}
+	}
+	return codeList, nil
+}
+
+// hasEdition reports whether a code list has an edition with the provided ID
+func hasEdition(codeList *memory.CodeList, editionID string) bool {
+	for _, edition := range codeList.Editions {
+		if edition.ID == editionID {
+			return true
+		}
+	}
+	return false
+}
+
+// loadCodes reads a CSV file with a code,label header, keeping the order of its rows
+func loadCodes(path string) ([]memory.Code, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, errors.New("empty file, a code,label header is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !validHeader(header) {
+		return nil, errors.Errorf("invalid header %q, expected code,label or code,label,parent", strings.Join(header, ","))
+	}
+	r.FieldsPerRecord = len(header)
+
+	codes := []memory.Code{}
+	lines := map[string]int{}
+	line := 1
+	for {
+		line++
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		code := strings.TrimSpace(record[0])
+		if code == "" {
+			return nil, errors.Errorf("empty code on line %d", line)
+		}
+		if _, ok := lines[code]; ok {
+			return nil, errors.Errorf("duplicate code %q on line %d", code, line)
+		}
+		lines[code] = line
+
+		c := memory.Code{Code: code, Label: strings.TrimSpace(record[1])}
+		if len(record) > 2 {
+			c.Parent = strings.TrimSpace(record[2])
+		}
+		codes = append(codes, c)
+	}
+
+	if err := checkHierarchy(codes, lines); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// validHeader reports whether a CSV header is code,label, or code,label,parent for a hierarchy
+func validHeader(header []string) bool {
+	expected := []string{"code", "label", "parent"}
+	if len(header) < 2 || len(header) > len(expected) {
+		return false
+	}
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	for i := range header {
+		if !strings.EqualFold(header[i], expected[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkHierarchy checks that the parent of each code is a code of the edition, and that no code is its
+// own ancestor. lines holds the line number of each code.
+func checkHierarchy(codes []memory.Code, lines map[string]int) error {
+	parents := make(map[string]string, len(codes))
+	for _, code := range codes {
+		if code.Parent == "" {
+			continue
+		}
+		if _, ok := lines[code.Parent]; !ok {
+			return errors.Errorf("unknown parent %q of code %q on line %d", code.Parent, code.Code, lines[code.Code])
+		}
+		parents[code.Code] = code.Parent
+	}
+
+	inCycle := csvimport.Cycles(parents)
+	for _, code := range codes {
+		if inCycle[code.Code] {
+			return errors.Errorf("code %q on line %d is its own ancestor", code.Code, lines[code.Code])
+		}
+	}
+	return nil
+}
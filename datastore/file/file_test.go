@@ -0,0 +1,255 @@
+package file_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/datastore/file"
+	"github.com/ONSdigital/dp-code-list-api/datastore/memory"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	"github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func writeFile(dir, name, content string) {
+	path := filepath.Join(dir, name)
+	So(os.MkdirAll(filepath.Dir(path), 0755), ShouldBeNil)
+	So(ioutil.WriteFile(path, []byte(content), 0644), ShouldBeNil)
+}
+
+func TestLoad(t *testing.T) {
+	Convey("Given a directory of code list files", t, func() {
+		dir, err := ioutil.TempDir("", "codelists")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		writeFile(dir, "local-authority/codelist.json", `{"label":"Local authority","type":"geography","licence":"OGL","contact":{"email":"geography@ons.gov.uk"},"editions":{"2019":{"label":"Local authority 2019"}}}`)
+		writeFile(dir, "local-authority/2019.csv", "code,label\nE06000002,Middlesbrough\nE06000001,Hartlepool\n")
+		writeFile(dir, "local-authority/2020.csv", "\ufeffCode,Label\nE06000001,\"Hartlepool, Borough of\"\n")
+		writeFile(dir, "README.md", "not a code list")
+
+		Convey("Load returns one code list per folder and one edition per CSV file", func() {
+			fixtures, err := file.Load(dir)
+			So(err, ShouldBeNil)
+			So(fixtures.CodeLists, ShouldHaveLength, 1)
+
+			codeList := fixtures.CodeLists[0]
+			So(codeList.ID, ShouldEqual, "local-authority")
+			So(codeList.Label, ShouldEqual, "Local authority")
+			So(codeList.Type, ShouldEqual, "geography")
+			So(codeList.Licence, ShouldEqual, "OGL")
+			So(codeList.Contact, ShouldResemble, &datastore.Contact{Email: "geography@ons.gov.uk"})
+			So(codeList.Editions, ShouldHaveLength, 2)
+
+			So(codeList.Editions[0].ID, ShouldEqual, "2019")
+			So(codeList.Editions[0].Label, ShouldEqual, "Local authority 2019")
+			So(codeList.Editions[0].Codes, ShouldResemble, []memory.Code{
+				{Code: "E06000002", Label: "Middlesbrough"},
+				{Code: "E06000001", Label: "Hartlepool"},
+			})
+
+			So(codeList.Editions[1].ID, ShouldEqual, "2020")
+			So(codeList.Editions[1].Label, ShouldEqual, "2020")
+			So(codeList.Editions[1].Codes, ShouldResemble, []memory.Code{
+				{Code: "E06000001", Label: "Hartlepool, Borough of"},
+			})
+		})
+
+		Convey("Load fails if an edition contains a duplicate code", func() {
+			writeFile(dir, "local-authority/2021.csv", "code,label\nE06000001,Hartlepool\nE06000001,Hartlepool\n")
+			_, err := file.Load(dir)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, `duplicate code "E06000001" on line 3`)
+		})
+
+		Convey("Load reads the parent of each code from an optional parent column", func() {
+			writeFile(dir, "local-authority/2021.csv", "code,label,parent\nE92000001,England,\nE06000001,Hartlepool,E92000001\n")
+			fixtures, err := file.Load(dir)
+			So(err, ShouldBeNil)
+			So(fixtures.CodeLists[0].Editions[2].Codes, ShouldResemble, []memory.Code{
+				{Code: "E92000001", Label: "England"},
+				{Code: "E06000001", Label: "Hartlepool", Parent: "E92000001"},
+			})
+		})
+
+		Convey("Load fails if the parent of a code is not in the edition", func() {
+			writeFile(dir, "local-authority/2021.csv", "code,label,parent\nE06000001,Hartlepool,E92000001\n")
+			_, err := file.Load(dir)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, `unknown parent "E92000001" of code "E06000001" on line 2`)
+		})
+
+		Convey("Load fails if the hierarchy of an edition contains a cycle", func() {
+			writeFile(dir, "local-authority/2021.csv", "code,label,parent\nE92000001,England,E06000001\nE06000001,Hartlepool,E92000001\n")
+			_, err := file.Load(dir)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, `code "E92000001" on line 2 is its own ancestor`)
+		})
+
+		Convey("Load reads the release date, state and supersession of editions from the metadata file", func() {
+			writeFile(dir, "local-authority/codelist.json", `{"editions":{"2019":{"release_date":"2019-04-01T00:00:00Z","state":"retired"},"2020":{"state":"published","supersedes":"2019"}}}`)
+			fixtures, err := file.Load(dir)
+			So(err, ShouldBeNil)
+
+			editions := fixtures.CodeLists[0].Editions
+			So(editions[0].ReleaseDate.Equal(time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC)), ShouldBeTrue)
+			So(editions[0].State, ShouldEqual, datastore.EditionRetired)
+			So(editions[1].State, ShouldEqual, datastore.EditionPublished)
+			So(editions[1].Supersedes, ShouldEqual, "2019")
+		})
+
+		Convey("Load fails if an edition has an unknown state", func() {
+			writeFile(dir, "local-authority/codelist.json", `{"editions":{"2019":{"state":"archived"}}}`)
+			_, err := file.Load(dir)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, `invalid state "archived"`)
+		})
+
+		Convey("Load fails if an edition supersedes an unknown edition", func() {
+			writeFile(dir, "local-authority/codelist.json", `{"editions":{"2020":{"supersedes":"2018"}}}`)
+			_, err := file.Load(dir)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, `supersedes unknown edition "2018"`)
+		})
+
+		Convey("Load fails if an edition is named after the latest edition alias", func() {
+			writeFile(dir, "local-authority/latest.csv", "code,label\nE06000001,Hartlepool\n")
+			_, err := file.Load(dir)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "reserved")
+		})
+
+		Convey("Load reads the mappings between codes from the mapping tables", func() {
+			writeFile(dir, "local-authority-2019-2020.csv", "source_code_list,source_edition,source_code,target_code_list,target_edition,target_code,type\n"+
+				"local-authority,2019,E06000002,local-authority,2020,E06000001,successor\n")
+			fixtures, err := file.Load(dir)
+			So(err, ShouldBeNil)
+			So(fixtures.Mappings, ShouldResemble, []datastore.Mapping{{
+				Source: datastore.CodeRef{CodeListID: "local-authority", EditionID: "2019", Code: "E06000002"},
+				Target: datastore.CodeRef{CodeListID: "local-authority", EditionID: "2020", Code: "E06000001"},
+				Type:   datastore.MappingSuccessor,
+			}})
+		})
+
+		Convey("Load fails if a mapping table refers to an unknown code", func() {
+			writeFile(dir, "local-authority-2019-2020.csv", "source_code_list,source_edition,source_code,target_code_list,target_edition,target_code,type\n"+
+				"local-authority,2019,E06000002,local-authority,2020,E06000002,successor\n")
+			_, err := file.Load(dir)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, `unknown target code "E06000002" of edition "2020" of code list "local-authority" on line 2`)
+		})
+
+		Convey("Load fails if a mapping table has an unknown mapping type", func() {
+			writeFile(dir, "local-authority-2019-2020.csv", "source_code_list,source_edition,source_code,target_code_list,target_edition,target_code,type\n"+
+				"local-authority,2019,E06000001,local-authority,2020,E06000001,renamed\n")
+			_, err := file.Load(dir)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, `invalid mapping type "renamed" on line 2`)
+		})
+
+		Convey("Load fails if an edition does not have a code,label header", func() {
+			writeFile(dir, "local-authority/2021.csv", "id,name\nE06000001,Hartlepool\n")
+			_, err := file.Load(dir)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "invalid header")
+		})
+	})
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a file store watching a directory", t, func() {
+		dir, err := ioutil.TempDir("", "codelists")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		writeFile(dir, "local-authority/2019.csv", "code,label\nE06000001,Hartlepool\n")
+
+		store, err := file.New(ctx, dir, 10*time.Millisecond)
+		So(err, ShouldBeNil)
+		defer store.Close(ctx)
+
+		Convey("The code lists in the directory are served", func() {
+			code, err := store.GetCode(ctx, "local-authority", "2019", "E06000001")
+			So(err, ShouldBeNil)
+			So(code, ShouldResemble, &models.Code{Code: "E06000001", Label: "Hartlepool"})
+		})
+
+		Convey("When a new edition file is added, the store is reloaded", func() {
+			writeFile(dir, "local-authority/2020.csv", "code,label\nE06000002,Middlesbrough\n")
+
+			So(eventually(func() bool {
+				_, err := store.GetEdition(ctx, "local-authority", "2020")
+				return err == nil
+			}), ShouldBeTrue)
+		})
+
+		Convey("When the store is reloaded, the registered function is called", func() {
+			reloaded := make(chan struct{}, 1)
+			store.OnReload(func(ctx context.Context) {
+				select {
+				case reloaded <- struct{}{}:
+				default:
+				}
+			})
+			writeFile(dir, "local-authority/2020.csv", "code,label\nE06000002,Middlesbrough\n")
+
+			So(eventually(func() bool {
+				select {
+				case <-reloaded:
+					return true
+				default:
+					return false
+				}
+			}), ShouldBeTrue)
+		})
+
+		Convey("When an edition file becomes invalid, the previous code lists are still served", func() {
+			writeFile(dir, "local-authority/2019.csv", "code,label\nE06000001\n")
+
+			state := healthcheck.NewCheckState("file store")
+			So(eventually(func() bool {
+				store.Checker(ctx, state)
+				return state.Status() == healthcheck.StatusWarning
+			}), ShouldBeTrue)
+
+			_, err := store.GetCode(ctx, "local-authority", "2019", "E06000001")
+			So(err, ShouldBeNil)
+			_, err = store.GetCode(ctx, "local-authority", "2019", "E06000002")
+			So(err, ShouldEqual, driver.ErrNotFound)
+		})
+
+		Convey("The store may be closed again after a first call timed out", func() {
+			cancelled, cancel := context.WithCancel(ctx)
+			cancel()
+			store.Close(cancelled)
+
+			So(store.Close(ctx), ShouldBeNil)
+			So(store.Close(ctx), ShouldBeNil)
+		})
+	})
+
+	Convey("New fails for a directory that does not exist", t, func() {
+		_, err := file.New(ctx, "/non/existent/dir", 0)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+// eventually polls the condition until it is true, or a second has passed
+func eventually(condition func() bool) bool {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package datastore
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/pkg/errors"
+)
+
+//go:generate moq -out datastoretest/hierarchy.go -pkg storetest . Hierarchy
+
+// ErrNotSupported is returned for an operation that the store does not support
+var ErrNotSupported = errors.New("operation not supported by the code list store")
+
+// maxHierarchyDepth guards against cycles in a hierarchy when walking up from a code
+const maxHierarchyDepth = 100
+
+// Hierarchy is implemented by stores holding the parent of each code, for code lists that are hierarchies
+type Hierarchy interface {
+	// GetParentCode returns the parent of a code, or nil if the code is at the top of the hierarchy
+	GetParentCode(ctx context.Context, codeListID, editionID, codeID string) (*models.Code, error)
+	// GetChildCodes returns the codes whose parent is the provided code, in the order of the store
+	GetChildCodes(ctx context.Context, codeListID, editionID, codeID string) ([]models.Code, error)
+	// GetParentCodeIDs returns the ID of the parent of every code of an edition, keyed by code ID. Codes at
+	// the top of the hierarchy are not included.
+	GetParentCodeIDs(ctx context.Context, codeListID, editionID string) (map[string]string, error)
+}
+
+// GetParentCode returns the parent of a code, or nil if the code is at the top of the hierarchy.
+// ErrNotSupported is returned for stores that do not implement Hierarchy.
+func GetParentCode(ctx context.Context, store DataStore, codeListID, editionID, codeID string) (*models.Code, error) {
+	if h, ok := store.(Hierarchy); ok {
+		return h.GetParentCode(ctx, codeListID, editionID, codeID)
+	}
+	return nil, ErrNotSupported
+}
+
+// GetChildCodes returns the codes whose parent is the provided code. ErrNotSupported is returned for stores
+// that do not implement Hierarchy.
+func GetChildCodes(ctx context.Context, store DataStore, codeListID, editionID, codeID string) ([]models.Code, error) {
+	if h, ok := store.(Hierarchy); ok {
+		return h.GetChildCodes(ctx, codeListID, editionID, codeID)
+	}
+	return nil, ErrNotSupported
+}
+
+// GetParentCodeIDs returns the ID of the parent of every code of an edition, keyed by code ID.
+// ErrNotSupported is returned for stores that do not implement Hierarchy.
+func GetParentCodeIDs(ctx context.Context, store DataStore, codeListID, editionID string) (map[string]string, error) {
+	if h, ok := store.(Hierarchy); ok {
+		return h.GetParentCodeIDs(ctx, codeListID, editionID)
+	}
+	return nil, ErrNotSupported
+}
+
+// GetAncestorCodes returns the ancestors of a code, from the top of the hierarchy down to the parent of the
+// code. ErrNotSupported is returned for stores that do not implement Hierarchy.
+func GetAncestorCodes(ctx context.Context, store DataStore, codeListID, editionID, codeID string) ([]models.Code, error) {
+	ancestors := []models.Code{}
+	for {
+		parent, err := GetParentCode(ctx, store, codeListID, editionID, codeID)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			break
+		}
+		if len(ancestors) == maxHierarchyDepth {
+			return nil, errors.Errorf("hierarchy of code %q is deeper than %d levels", codeID, maxHierarchyDepth)
+		}
+		ancestors = append(ancestors, *parent)
+		codeID = parent.Code
+	}
+
+	for i, j := 0, len(ancestors)-1; i < j; i, j = i+1, j-1 {
+		ancestors[i], ancestors[j] = ancestors[j], ancestors[i]
+	}
+	return ancestors, nil
+}
+
+// TreeCode is a code of an edition, with its position in the hierarchy of the edition
+type TreeCode struct {
+	Code   models.Code
+	Parent string
+	Depth  int
+}
+
+// GetCodeTree returns every code of an edition in depth-first order: each code is followed by its
+// descendants, and codes at the top of the hierarchy have a depth of 0. ErrNotSupported is returned for
+// stores that do not implement Hierarchy.
+func GetCodeTree(ctx context.Context, store DataStore, codeListID, editionID string) ([]TreeCode, error) {
+	parents, err := GetParentCodeIDs(ctx, store, codeListID, editionID)
+	if err != nil {
+		return nil, err
+	}
+	codes, err := GetAllCodes(ctx, store, codeListID, editionID)
+	if err != nil {
+		return nil, err
+	}
+	return CodeTree(codes, parents), nil
+}
+
+// CodeTree orders codes depth-first, from their parent code IDs. Siblings keep the order of codes. Codes
+// whose parent is not one of the codes are at the top of the hierarchy, and codes whose ancestors form a
+// cycle are left out.
+func CodeTree(codes []models.Code, parents map[string]string) []TreeCode {
+	known := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		known[code.Code] = true
+	}
+
+	roots := []int{}
+	children := map[string][]int{}
+	for i, code := range codes {
+		parent := parents[code.Code]
+		if known[parent] {
+			children[parent] = append(children[parent], i)
+		} else {
+			roots = append(roots, i)
+		}
+	}
+
+	tree := make([]TreeCode, 0, len(codes))
+	type item struct {
+		index int
+		depth int
+	}
+	stack := make([]item, 0, len(roots))
+	for i := len(roots) - 1; i >= 0; i-- {
+		stack = append(stack, item{index: roots[i]})
+	}
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		code := codes[top.index]
+		node := TreeCode{Code: code, Depth: top.depth}
+		if known[parents[code.Code]] {
+			node.Parent = parents[code.Code]
+		}
+		tree = append(tree, node)
+
+		next := children[code.Code]
+		for i := len(next) - 1; i >= 0; i-- {
+			stack = append(stack, item{index: next[i], depth: top.depth + 1})
+		}
+	}
+	return tree
+}
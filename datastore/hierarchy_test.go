@@ -0,0 +1,65 @@
+package datastore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-graph/v2/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetAncestorCodes(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("GetAncestorCodes returns ErrNotSupported for a store that does not support hierarchies", t, func() {
+		_, err := datastore.GetAncestorCodes(ctx, &storetest.DataStoreMock{}, "codelist", "2019", "1")
+		So(err, ShouldEqual, datastore.ErrNotSupported)
+	})
+
+	Convey("Given a store where two codes are each other's parent", t, func() {
+		store := struct {
+			*storetest.DataStoreMock
+			*storetest.HierarchyMock
+		}{&storetest.DataStoreMock{}, &storetest.HierarchyMock{
+			GetParentCodeFunc: func(ctx context.Context, codeListID string, editionID string, codeID string) (*models.Code, error) {
+				if codeID == "1" {
+					return &models.Code{Code: "2"}, nil
+				}
+				return &models.Code{Code: "1"}, nil
+			},
+		}}
+
+		Convey("GetAncestorCodes fails instead of walking up the hierarchy forever", func() {
+			_, err := datastore.GetAncestorCodes(ctx, store, "codelist", "2019", "1")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestCodeTree(t *testing.T) {
+	Convey("Given the codes of an edition and their parents", t, func() {
+		codes := []models.Code{
+			{Code: "bread"}, {Code: "all"}, {Code: "food"}, {Code: "alcohol"}, {Code: "orphan"}, {Code: "a"}, {Code: "b"},
+		}
+		parents := map[string]string{
+			"bread":   "food",
+			"food":    "all",
+			"alcohol": "all",
+			"orphan":  "unknown",
+			"a":       "b",
+			"b":       "a",
+		}
+
+		Convey("CodeTree orders codes depth-first, keeping the order of siblings and leaving out cycles", func() {
+			So(datastore.CodeTree(codes, parents), ShouldResemble, []datastore.TreeCode{
+				{Code: models.Code{Code: "all"}, Depth: 0},
+				{Code: models.Code{Code: "food"}, Parent: "all", Depth: 1},
+				{Code: models.Code{Code: "bread"}, Parent: "food", Depth: 2},
+				{Code: models.Code{Code: "alcohol"}, Parent: "all", Depth: 1},
+				{Code: models.Code{Code: "orphan"}, Depth: 0},
+			})
+		})
+	})
+}
@@ -0,0 +1,21 @@
+package datastore
+
+import "context"
+
+//go:generate moq -out datastoretest/importer.go -pkg storetest . Importer
+
+// Importer is implemented by stores that can create an edition along with all of its codes at once
+type Importer interface {
+	// ImportEdition creates an edition with its codes, in their order, or leaves the store unchanged if any of
+	// them is rejected. ErrAlreadyExists is returned if the edition already exists.
+	ImportEdition(ctx context.Context, codeListID string, edition EditionUpdate, codes []CodeUpdate) error
+}
+
+// ImportEdition creates an edition with its codes. ErrNotSupported is returned for stores that do not
+// implement Importer.
+func ImportEdition(ctx context.Context, store DataStore, codeListID string, edition EditionUpdate, codes []CodeUpdate) error {
+	if i, ok := store.(Importer); ok {
+		return i.ImportEdition(ctx, codeListID, edition, codes)
+	}
+	return ErrNotSupported
+}
@@ -0,0 +1,42 @@
+package datastore
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ONSdigital/dp-graph/v2/models"
+)
+
+//go:generate moq -out datastoretest/reverselookup.go -pkg storetest . ReverseLookup
+
+// CodeEdition is a code list edition containing a code, along with the code as found in that edition
+type CodeEdition struct {
+	CodeListID string
+	EditionID  string
+	Code       models.Code
+}
+
+// ReverseLookup is implemented by stores able to find the code list editions containing a code
+type ReverseLookup interface {
+	GetCodeEditions(ctx context.Context, codeID string) ([]CodeEdition, error)
+}
+
+// GetCodeEditions returns every code list edition containing the code, sorted by code list then edition.
+// Finding a code would mean asking for it in every edition of every code list on stores that do not implement
+// ReverseLookup, for which ErrNotSupported is returned instead.
+func GetCodeEditions(ctx context.Context, store DataStore, codeID string) ([]CodeEdition, error) {
+	if l, ok := store.(ReverseLookup); ok {
+		return l.GetCodeEditions(ctx, codeID)
+	}
+	return nil, ErrNotSupported
+}
+
+// SortCodeEditions sorts code editions in place, by code list then edition
+func SortCodeEditions(items []CodeEdition) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].CodeListID != items[j].CodeListID {
+			return items[i].CodeListID < items[j].CodeListID
+		}
+		return items[i].EditionID < items[j].EditionID
+	})
+}
@@ -0,0 +1,129 @@
+package datastore
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+)
+
+//go:generate moq -out datastoretest/relationships.go -pkg storetest . Relationships
+
+// maxMappingDepth guards against long or cyclic chains of mappings when resolving a code
+const maxMappingDepth = 10
+
+// MappingType is the relationship between the source and target codes of a mapping
+type MappingType string
+
+// Possible types of mapping
+const (
+	// MappingSuccessor maps a code to a code replacing it in a later edition, e.g. local authorities merged
+	// into a new local authority
+	MappingSuccessor MappingType = "successor"
+	// MappingWithin maps a code to a code of another code list containing it, e.g. an LSOA to its local authority
+	MappingWithin MappingType = "within"
+)
+
+// Valid reports whether the type is one of the possible types of mapping
+func (t MappingType) Valid() bool {
+	return t == MappingSuccessor || t == MappingWithin
+}
+
+// CodeRef identifies a code of an edition of a code list
+type CodeRef struct {
+	CodeListID string `json:"code_list"`
+	EditionID  string `json:"edition"`
+	Code       string `json:"code"`
+}
+
+// Mapping is a relationship from a source code to a target code, of the same or another code list
+type Mapping struct {
+	Source CodeRef     `json:"source"`
+	Target CodeRef     `json:"target"`
+	Type   MappingType `json:"type"`
+}
+
+// Relationships is implemented by stores holding mappings between codes
+type Relationships interface {
+	// GetMappings returns the mappings from a code, followed by the mappings to the code
+	GetMappings(ctx context.Context, codeListID, editionID, codeID string) ([]Mapping, error)
+}
+
+// GetMappings returns the mappings from a code, followed by the mappings to the code. ErrNotSupported is
+// returned for stores that do not implement Relationships.
+func GetMappings(ctx context.Context, store DataStore, codeListID, editionID, codeID string) ([]Mapping, error) {
+	if r, ok := store.(Relationships); ok {
+		return r.GetMappings(ctx, codeListID, editionID, codeID)
+	}
+	return nil, ErrNotSupported
+}
+
+// ResolveMappings follows the mappings from a code until codes of the target code list, and of the target
+// edition unless it is empty, are reached. For instance an LSOA is resolved to a local authority of the latest
+// edition through the local authority containing it, then the successors of that local authority. The
+// resolved codes are sorted, and the source code resolves to itself if it belongs to the target edition. A
+// code of the target code list without successors resolves to the same code in the target edition, if any.
+// ErrNotSupported is returned for stores that do not implement Relationships.
+func ResolveMappings(ctx context.Context, store DataStore, source CodeRef, targetCodeListID, targetEditionID string) ([]CodeRef, error) {
+	isTarget := func(ref CodeRef) bool {
+		return ref.CodeListID == targetCodeListID && (targetEditionID == "" || ref.EditionID == targetEditionID)
+	}
+
+	resolved := []CodeRef{}
+	visited := map[CodeRef]bool{source: true}
+	refs := []CodeRef{source}
+	for depth := 0; len(refs) > 0 && depth <= maxMappingDepth; depth++ {
+		next := []CodeRef{}
+		for _, ref := range refs {
+			if isTarget(ref) {
+				resolved = append(resolved, ref)
+				continue
+			}
+			mappings, err := GetMappings(ctx, store, ref.CodeListID, ref.EditionID, ref.Code)
+			if err == driver.ErrNotFound && ref != source {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			successors := 0
+			for _, mapping := range mappings {
+				if mapping.Source != ref {
+					continue
+				}
+				if mapping.Type == MappingSuccessor {
+					successors++
+				}
+				if visited[mapping.Target] {
+					continue
+				}
+				visited[mapping.Target] = true
+				next = append(next, mapping.Target)
+			}
+
+			// a code of the target code list without successors is unchanged, if the target edition still has it
+			if successors == 0 && ref.CodeListID == targetCodeListID {
+				carried := CodeRef{CodeListID: ref.CodeListID, EditionID: targetEditionID, Code: ref.Code}
+				if _, err := store.GetCode(ctx, carried.CodeListID, carried.EditionID, carried.Code); err == nil && !visited[carried] {
+					visited[carried] = true
+					next = append(next, carried)
+				} else if err != nil && err != driver.ErrNotFound {
+					return nil, err
+				}
+			}
+		}
+		refs = next
+	}
+
+	sort.Slice(resolved, func(i, j int) bool {
+		a, b := resolved[i], resolved[j]
+		if a.CodeListID != b.CodeListID {
+			return a.CodeListID < b.CodeListID
+		}
+		if a.EditionID != b.EditionID {
+			return a.EditionID < b.EditionID
+		}
+		return a.Code < b.Code
+	})
+	return resolved, nil
+}
@@ -0,0 +1,91 @@
+package datastore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-code-list-api/datastore/memory"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// mappingFixtures holds local authorities merged between 2019 and 2021, and LSOAs within the 2019 local authorities
+var mappingFixtures = &memory.Fixtures{
+	CodeLists: []memory.CodeList{
+		{
+			ID: "lad",
+			Editions: []memory.Edition{
+				{ID: "2019", Codes: []memory.Code{{Code: "E07000004"}, {Code: "E07000005"}, {Code: "E06000001"}}},
+				{ID: "2021", Codes: []memory.Code{{Code: "E06000060"}, {Code: "E06000001"}}},
+			},
+		},
+		{
+			ID: "lsoa",
+			Editions: []memory.Edition{
+				{ID: "2011", Codes: []memory.Code{{Code: "E01017646"}, {Code: "E01011949"}}},
+			},
+		},
+	},
+	Mappings: []datastore.Mapping{
+		{Source: lad2019("E07000004"), Target: lad2021("E06000060"), Type: datastore.MappingSuccessor},
+		{Source: lad2019("E07000005"), Target: lad2021("E06000060"), Type: datastore.MappingSuccessor},
+		{Source: lsoa2011("E01017646"), Target: lad2019("E07000004"), Type: datastore.MappingWithin},
+		{Source: lsoa2011("E01011949"), Target: lad2019("E06000001"), Type: datastore.MappingWithin},
+	},
+}
+
+func lad2019(code string) datastore.CodeRef {
+	return datastore.CodeRef{CodeListID: "lad", EditionID: "2019", Code: code}
+}
+
+func lad2021(code string) datastore.CodeRef {
+	return datastore.CodeRef{CodeListID: "lad", EditionID: "2021", Code: code}
+}
+
+func lsoa2011(code string) datastore.CodeRef {
+	return datastore.CodeRef{CodeListID: "lsoa", EditionID: "2011", Code: code}
+}
+
+func TestResolveMappings(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("ResolveMappings returns ErrNotSupported for a store that does not support mappings", t, func() {
+		_, err := datastore.ResolveMappings(ctx, &storetest.DataStoreMock{}, lsoa2011("E01017646"), "lad", "2021")
+		So(err, ShouldEqual, datastore.ErrNotSupported)
+	})
+
+	Convey("Given a store holding mappings between codes", t, func() {
+		store := memory.New(mappingFixtures)
+
+		Convey("A code is resolved through the chain of mappings to the target edition", func() {
+			codes, err := datastore.ResolveMappings(ctx, store, lsoa2011("E01017646"), "lad", "2021")
+			So(err, ShouldBeNil)
+			So(codes, ShouldResemble, []datastore.CodeRef{lad2021("E06000060")})
+		})
+
+		Convey("A code of the target code list without successors is resolved to the same code in the target edition", func() {
+			codes, err := datastore.ResolveMappings(ctx, store, lsoa2011("E01011949"), "lad", "2021")
+			So(err, ShouldBeNil)
+			So(codes, ShouldResemble, []datastore.CodeRef{lad2021("E06000001")})
+		})
+
+		Convey("A code of the target code list is resolved to itself if no target edition is provided", func() {
+			codes, err := datastore.ResolveMappings(ctx, store, lad2019("E07000004"), "lad", "")
+			So(err, ShouldBeNil)
+			So(codes, ShouldResemble, []datastore.CodeRef{lad2019("E07000004")})
+		})
+
+		Convey("A code without a mapping to the target code list is resolved to no code", func() {
+			codes, err := datastore.ResolveMappings(ctx, store, lad2019("E07000004"), "lsoa", "")
+			So(err, ShouldBeNil)
+			So(codes, ShouldBeEmpty)
+		})
+
+		Convey("An unknown code results in ErrNotFound", func() {
+			_, err := datastore.ResolveMappings(ctx, store, lsoa2011("unknown"), "lad", "2021")
+			So(err, ShouldEqual, driver.ErrNotFound)
+		})
+	})
+}
@@ -0,0 +1,654 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/datastore/suggest"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	"github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/pkg/errors"
+)
+
+// Type check to ensure that Store implements the datastore.DataStore interface and the optional datastore interfaces
+var (
+	_ datastore.DataStore             = (*Store)(nil)
+	_ datastore.Paginator             = (*Store)(nil)
+	_ datastore.Searcher              = (*Store)(nil)
+	_ datastore.ReverseLookup         = (*Store)(nil)
+	_ datastore.Suggester             = (*Store)(nil)
+	_ datastore.MultiGetter           = (*Store)(nil)
+	_ datastore.Hierarchy             = (*Store)(nil)
+	_ datastore.MetadataGetter        = (*Store)(nil)
+	_ datastore.EditionMetadataGetter = (*Store)(nil)
+	_ datastore.Relationships         = (*Store)(nil)
+	_ datastore.Writer                = (*Store)(nil)
+	_ datastore.Importer              = (*Store)(nil)
+)
+
+// Fixtures is the seed data used to populate an in-memory Store
+type Fixtures struct {
+	CodeLists []CodeList          `json:"code_lists"`
+	Mappings  []datastore.Mapping `json:"mappings,omitempty"`
+}
+
+// CodeList is a code list fixture. Type is matched against the filterBy argument of GetCodeLists,
+// in the same way as the boolean type properties (e.g. "geography") of code list nodes in the graph.
+type CodeList struct {
+	ID          string             `json:"id"`
+	Label       string             `json:"label,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Licence     string             `json:"licence,omitempty"`
+	Contact     *datastore.Contact `json:"contact,omitempty"`
+	Editions    []Edition          `json:"editions"`
+}
+
+// Edition is a code list edition fixture. The order of Codes is preserved by the store. Supersedes is the
+// ID of the previous edition of the code list, which is in turn superseded by this edition.
+type Edition struct {
+	ID          string                 `json:"id"`
+	Label       string                 `json:"label"`
+	ReleaseDate *time.Time             `json:"release_date,omitempty"`
+	State       datastore.EditionState `json:"state,omitempty"`
+	Supersedes  string                 `json:"supersedes,omitempty"`
+	Codes       []Code                 `json:"codes"`
+
+	// index of each code in Codes, keyed by code ID
+	index map[string]int
+	// labels is the prefix index of the labels of Codes, or nil until suggestions are requested after a change
+	labels *suggest.Trie
+	// children holds the index in Codes of the children of each code, keyed by parent code ID
+	children map[string][]int
+}
+
+// Code is a code fixture, with the datasets that use it. Parent is the ID of the parent code within the
+// edition, for code lists that are hierarchies; a code without a known parent is at the top of the hierarchy.
+type Code struct {
+	Code     string    `json:"code"`
+	Label    string    `json:"label"`
+	Parent   string    `json:"parent,omitempty"`
+	Datasets []Dataset `json:"datasets,omitempty"`
+}
+
+// Dataset is a fixture for a dataset related to a code
+type Dataset struct {
+	ID             string           `json:"id"`
+	DimensionLabel string           `json:"dimension_label"`
+	Editions       []DatasetEdition `json:"editions"`
+}
+
+// DatasetEdition is a fixture for a dataset edition related to a code
+type DatasetEdition struct {
+	ID            string `json:"id"`
+	LatestVersion int    `json:"latest_version"`
+}
+
+// Store is a datastore.DataStore that holds all code lists in memory
+type Store struct {
+	mutex     sync.RWMutex
+	codeLists map[string]*CodeList
+	// mappings from and to each code, keyed by code
+	mappingsFrom map[datastore.CodeRef][]datastore.Mapping
+	mappingsTo   map[datastore.CodeRef][]datastore.Mapping
+}
+
+// New returns a Store seeded with the provided fixtures, which may be nil
+func New(fixtures *Fixtures) *Store {
+	s := &Store{}
+	s.Seed(fixtures)
+	return s
+}
+
+// NewFromFile returns a Store seeded with the JSON fixtures found in the provided file
+func NewFromFile(path string) (*Store, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open fixtures file")
+	}
+	defer f.Close()
+
+	fixtures := &Fixtures{}
+	if err := json.NewDecoder(f).Decode(fixtures); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode fixtures file %q", path)
+	}
+
+	return New(fixtures), nil
+}
+
+// Seed replaces the content of the store with the provided fixtures
+func (s *Store) Seed(fixtures *Fixtures) {
+	codeLists := map[string]*CodeList{}
+	if fixtures != nil {
+		for i := range fixtures.CodeLists {
+			codeList := fixtures.CodeLists[i]
+			codeList.Editions = make([]Edition, len(fixtures.CodeLists[i].Editions))
+			for j, edition := range fixtures.CodeLists[i].Editions {
+				// the codes are copied, so that writes leave the fixtures the store was seeded with unchanged
+				edition.Codes = append(make([]Code, 0, len(edition.Codes)), edition.Codes...)
+				edition.reindex()
+				codeList.Editions[j] = edition
+			}
+			codeLists[codeList.ID] = &codeList
+		}
+	}
+
+	mappingsFrom := map[datastore.CodeRef][]datastore.Mapping{}
+	mappingsTo := map[datastore.CodeRef][]datastore.Mapping{}
+	if fixtures != nil {
+		for _, mapping := range fixtures.Mappings {
+			mappingsFrom[mapping.Source] = append(mappingsFrom[mapping.Source], mapping)
+			mappingsTo[mapping.Target] = append(mappingsTo[mapping.Target], mapping)
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.codeLists = codeLists
+	s.mappingsFrom = mappingsFrom
+	s.mappingsTo = mappingsTo
+}
+
+// reindex builds the indexes of the codes of an edition. The prefix index of their labels is dropped, to be
+// built by the first SuggestCodes, as it is much slower to build.
+func (e *Edition) reindex() {
+	e.index = make(map[string]int, len(e.Codes))
+	for k, code := range e.Codes {
+		e.index[code.Code] = k
+	}
+	e.children = map[string][]int{}
+	for k, code := range e.Codes {
+		if _, ok := e.index[code.Parent]; ok {
+			e.children[code.Parent] = append(e.children[code.Parent], k)
+		}
+	}
+	e.labels = nil
+}
+
+// Checker reports the in-memory store as always healthy
+func (s *Store) Checker(ctx context.Context, state *healthcheck.CheckState) error {
+	return state.Update(healthcheck.StatusOK, "in-memory store is healthy", 0)
+}
+
+// Close is a no-op, as the store does not hold any external resources
+func (s *Store) Close(ctx context.Context) error {
+	return nil
+}
+
+// GetCodeLists returns all code lists, or only those of the provided type if filterBy is not empty
+func (s *Store) GetCodeLists(ctx context.Context, filterBy string) (*models.CodeListResults, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	results := &models.CodeListResults{}
+	for _, codeList := range s.codeLists {
+		if filterBy != "" && codeList.Type != filterBy {
+			continue
+		}
+		results.Items = append(results.Items, models.CodeList{ID: codeList.ID})
+	}
+	return results, nil
+}
+
+// GetCodeList returns the code list with the provided ID
+func (s *Store) GetCodeList(ctx context.Context, codeListID string) (*models.CodeList, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if _, ok := s.codeLists[codeListID]; !ok {
+		return nil, driver.ErrNotFound
+	}
+	return &models.CodeList{ID: codeListID}, nil
+}
+
+// GetCodeListMetadata returns the metadata of the code list with the provided ID
+func (s *Store) GetCodeListMetadata(ctx context.Context, codeListID string) (*datastore.CodeListMetadata, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	codeList, ok := s.codeLists[codeListID]
+	if !ok {
+		return nil, driver.ErrNotFound
+	}
+	return datastore.CopyCodeListMetadata(&datastore.CodeListMetadata{
+		ID:          codeList.ID,
+		Label:       codeList.Label,
+		Description: codeList.Description,
+		Type:        codeList.Type,
+		Licence:     codeList.Licence,
+		Contact:     codeList.Contact,
+	}), nil
+}
+
+// GetCodeListsMetadata returns the metadata of every code list
+func (s *Store) GetCodeListsMetadata(ctx context.Context) ([]datastore.CodeListMetadata, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	metadata := make([]datastore.CodeListMetadata, 0, len(s.codeLists))
+	for _, codeList := range s.codeLists {
+		metadata = append(metadata, *datastore.CopyCodeListMetadata(&datastore.CodeListMetadata{
+			ID:          codeList.ID,
+			Label:       codeList.Label,
+			Description: codeList.Description,
+			Type:        codeList.Type,
+			Licence:     codeList.Licence,
+			Contact:     codeList.Contact,
+		}))
+	}
+	return metadata, nil
+}
+
+// GetEditions returns all editions of the provided code list
+func (s *Store) GetEditions(ctx context.Context, codeListID string) (*models.Editions, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	codeList, ok := s.codeLists[codeListID]
+	if !ok {
+		return nil, driver.ErrNotFound
+	}
+
+	editions := &models.Editions{Items: []models.Edition{}}
+	for _, edition := range codeList.Editions {
+		editions.Items = append(editions.Items, models.Edition{ID: edition.ID, Label: edition.Label})
+	}
+	return editions, nil
+}
+
+// GetEditionsMetadata returns the metadata of the editions of a code list, in fixture order
+func (s *Store) GetEditionsMetadata(ctx context.Context, codeListID string) ([]datastore.EditionMetadata, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	codeList, ok := s.codeLists[codeListID]
+	if !ok {
+		return nil, driver.ErrNotFound
+	}
+
+	supersededBy := map[string]string{}
+	for _, edition := range codeList.Editions {
+		if edition.Supersedes != "" {
+			supersededBy[edition.Supersedes] = edition.ID
+		}
+	}
+
+	metadata := make([]datastore.EditionMetadata, 0, len(codeList.Editions))
+	for _, edition := range codeList.Editions {
+		metadata = append(metadata, datastore.EditionMetadata{
+			ID:           edition.ID,
+			ReleaseDate:  edition.ReleaseDate,
+			State:        edition.State,
+			Supersedes:   edition.Supersedes,
+			SupersededBy: supersededBy[edition.ID],
+			CodeCount:    len(edition.Codes),
+		})
+	}
+	return metadata, nil
+}
+
+// GetEdition returns the requested edition of a code list
+func (s *Store) GetEdition(ctx context.Context, codeListID, editionID string) (*models.Edition, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	edition, err := s.edition(codeListID, editionID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Edition{ID: edition.ID, Label: edition.Label}, nil
+}
+
+// CountCodes returns the number of codes in the requested edition of a code list
+func (s *Store) CountCodes(ctx context.Context, codeListID, editionID string) (int64, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	edition, err := s.edition(codeListID, editionID)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(edition.Codes)), nil
+}
+
+// GetCodes returns all codes in the requested edition of a code list, in fixture order.
+// An edition without codes results in driver.ErrNotFound, as it does for the graph store.
+func (s *Store) GetCodes(ctx context.Context, codeListID, editionID string) (*models.CodeResults, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	edition, err := s.edition(codeListID, editionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(edition.Codes) == 0 {
+		return nil, driver.ErrNotFound
+	}
+
+	codes := &models.CodeResults{Items: make([]models.Code, 0, len(edition.Codes))}
+	for _, code := range edition.Codes {
+		codes.Items = append(codes.Items, models.Code{Code: code.Code, Label: code.Label})
+	}
+	return codes, nil
+}
+
+// GetCode returns a single code from the requested edition of a code list
+func (s *Store) GetCode(ctx context.Context, codeListID, editionID, codeID string) (*models.Code, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	code, err := s.code(codeListID, editionID, codeID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Code{Code: code.Code, Label: code.Label}, nil
+}
+
+// GetCodesByID returns the codes of an edition with the provided IDs, in the requested order, skipping
+// unknown IDs
+func (s *Store) GetCodesByID(ctx context.Context, codeListID, editionID string, codeIDs []string) ([]models.Code, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	edition, err := s.edition(codeListID, editionID)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := []models.Code{}
+	for _, id := range codeIDs {
+		if i, ok := edition.index[id]; ok {
+			codes = append(codes, models.Code{Code: edition.Codes[i].Code, Label: edition.Codes[i].Label})
+		}
+	}
+	return codes, nil
+}
+
+// GetCodeDatasets returns the datasets that use the provided code
+func (s *Store) GetCodeDatasets(ctx context.Context, codeListID, editionID, codeID string) (*models.Datasets, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	code, err := s.code(codeListID, editionID, codeID)
+	if err != nil {
+		return nil, err
+	}
+
+	datasets := &models.Datasets{Items: []models.Dataset{}}
+	for _, dataset := range code.Datasets {
+		dbDataset := models.Dataset{
+			ID:             dataset.ID,
+			DimensionLabel: dataset.DimensionLabel,
+			Editions:       []models.DatasetEdition{},
+		}
+		for _, datasetEdition := range dataset.Editions {
+			dbDataset.Editions = append(dbDataset.Editions, models.DatasetEdition{
+				ID:            datasetEdition.ID,
+				CodeListID:    codeListID,
+				LatestVersion: datasetEdition.LatestVersion,
+			})
+		}
+		datasets.Items = append(datasets.Items, dbDataset)
+	}
+	return datasets, nil
+}
+
+// GetCodeListsPage returns a page of code lists, and the total number of code lists of the requested type
+func (s *Store) GetCodeListsPage(ctx context.Context, filterBy string, page datastore.Page) (*models.CodeListResults, int, error) {
+	codeLists, err := s.GetCodeLists(ctx, filterBy)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	datastore.SortCodeLists(codeLists.Items, page.Order)
+	start, end := page.Bounds(len(codeLists.Items))
+	return &models.CodeListResults{Items: codeLists.Items[start:end]}, len(codeLists.Items), nil
+}
+
+// GetEditionsPage returns a page of the editions of a code list, and the total number of editions
+func (s *Store) GetEditionsPage(ctx context.Context, codeListID string, page datastore.Page) (*models.Editions, int, error) {
+	editions, err := s.GetEditions(ctx, codeListID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	datastore.SortEditions(editions.Items, page.Order)
+	start, end := page.Bounds(len(editions.Items))
+	return &models.Editions{Items: editions.Items[start:end]}, len(editions.Items), nil
+}
+
+// GetCodesPage returns a page of the codes of an edition, and the total number of codes. Only the codes
+// in the page are copied, unless the page is sorted in an order other than the fixture order.
+func (s *Store) GetCodesPage(ctx context.Context, codeListID, editionID string, page datastore.Page) (*models.CodeResults, int, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	edition, err := s.edition(codeListID, editionID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	fixtures := edition.Codes
+	if page.Order == datastore.OrderDefault {
+		start, end := page.Bounds(len(fixtures))
+		fixtures = fixtures[start:end]
+	}
+
+	codes := make([]models.Code, 0, len(fixtures))
+	for _, code := range fixtures {
+		codes = append(codes, models.Code{Code: code.Code, Label: code.Label})
+	}
+
+	if page.Order != datastore.OrderDefault {
+		datastore.SortCodes(codes, page.Order)
+		start, end := page.Bounds(len(codes))
+		codes = codes[start:end]
+	}
+	return &models.CodeResults{Items: codes}, len(edition.Codes), nil
+}
+
+// GetCodeDatasetsPage returns a page of the datasets related to a code, and the total number of datasets
+func (s *Store) GetCodeDatasetsPage(ctx context.Context, codeListID, editionID, codeID string, page datastore.Page) (*models.Datasets, int, error) {
+	datasets, err := s.GetCodeDatasets(ctx, codeListID, editionID, codeID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	datastore.SortDatasets(datasets.Items, page.Order)
+	start, end := page.Bounds(len(datasets.Items))
+	return &models.Datasets{Items: datasets.Items[start:end]}, len(datasets.Items), nil
+}
+
+// SearchCodes returns a page of the codes of an edition matching the query, and the total number of
+// matching codes
+func (s *Store) SearchCodes(ctx context.Context, codeListID, editionID, query string, page datastore.Page) (*models.CodeResults, int, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	edition, err := s.edition(codeListID, editionID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	codes := make([]models.Code, 0, len(edition.Codes))
+	for _, code := range edition.Codes {
+		codes = append(codes, models.Code{Code: code.Code, Label: code.Label})
+	}
+
+	matches := datastore.MatchCodes(codes, query)
+	datastore.SortCodes(matches, page.Order)
+	start, end := page.Bounds(len(matches))
+	return &models.CodeResults{Items: matches[start:end]}, len(matches), nil
+}
+
+// GetCodeEditions returns every code list edition containing the code, sorted by code list then edition
+func (s *Store) GetCodeEditions(ctx context.Context, codeID string) ([]datastore.CodeEdition, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	found := []datastore.CodeEdition{}
+	for _, codeList := range s.codeLists {
+		for _, edition := range codeList.Editions {
+			i, ok := edition.index[codeID]
+			if !ok {
+				continue
+			}
+			found = append(found, datastore.CodeEdition{
+				CodeListID: codeList.ID,
+				EditionID:  edition.ID,
+				Code:       models.Code{Code: edition.Codes[i].Code, Label: edition.Codes[i].Label},
+			})
+		}
+	}
+
+	datastore.SortCodeEditions(found)
+	return found, nil
+}
+
+// SuggestCodes returns up to limit codes of an edition whose label starts with the prefix, from the
+// prefix index of the labels of the edition
+func (s *Store) SuggestCodes(ctx context.Context, codeListID, editionID, prefix string, limit int) ([]models.Code, error) {
+	labels, err := s.labels(codeListID, editionID)
+	if err != nil {
+		return nil, err
+	}
+	return labels.Suggest(prefix, limit), nil
+}
+
+// labels returns the prefix index of the labels of an edition, which is built on the first call after the
+// store was seeded or the codes of the edition changed. An index is never changed once built.
+func (s *Store) labels(codeListID, editionID string) (*suggest.Trie, error) {
+	s.mutex.RLock()
+	edition, err := s.edition(codeListID, editionID)
+	var labels *suggest.Trie
+	if err == nil {
+		labels = edition.labels
+	}
+	s.mutex.RUnlock()
+	if err != nil || labels != nil {
+		return labels, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	edition, err = s.edition(codeListID, editionID)
+	if err != nil {
+		return nil, err
+	}
+	if edition.labels == nil {
+		codes := make([]models.Code, 0, len(edition.Codes))
+		for _, code := range edition.Codes {
+			codes = append(codes, models.Code{Code: code.Code, Label: code.Label})
+		}
+		edition.labels = suggest.NewTrie(codes)
+	}
+	return edition.labels, nil
+}
+
+// GetParentCode returns the parent of a code, or nil if the code has no parent within its edition
+func (s *Store) GetParentCode(ctx context.Context, codeListID, editionID, codeID string) (*models.Code, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	edition, err := s.edition(codeListID, editionID)
+	if err != nil {
+		return nil, err
+	}
+	i, ok := edition.index[codeID]
+	if !ok {
+		return nil, driver.ErrNotFound
+	}
+	j, ok := edition.index[edition.Codes[i].Parent]
+	if !ok {
+		return nil, nil
+	}
+	return &models.Code{Code: edition.Codes[j].Code, Label: edition.Codes[j].Label}, nil
+}
+
+// GetChildCodes returns the codes whose parent is the provided code, in fixture order
+func (s *Store) GetChildCodes(ctx context.Context, codeListID, editionID, codeID string) ([]models.Code, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	edition, err := s.edition(codeListID, editionID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := edition.index[codeID]; !ok {
+		return nil, driver.ErrNotFound
+	}
+
+	codes := make([]models.Code, 0, len(edition.children[codeID]))
+	for _, i := range edition.children[codeID] {
+		codes = append(codes, models.Code{Code: edition.Codes[i].Code, Label: edition.Codes[i].Label})
+	}
+	return codes, nil
+}
+
+// GetParentCodeIDs returns the ID of the parent of every code of an edition with a parent within the edition
+func (s *Store) GetParentCodeIDs(ctx context.Context, codeListID, editionID string) (map[string]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	edition, err := s.edition(codeListID, editionID)
+	if err != nil {
+		return nil, err
+	}
+
+	parents := map[string]string{}
+	for parent, children := range edition.children {
+		for _, i := range children {
+			parents[edition.Codes[i].Code] = parent
+		}
+	}
+	return parents, nil
+}
+
+// GetMappings returns the mappings from a code, followed by the mappings to the code, in fixture order
+func (s *Store) GetMappings(ctx context.Context, codeListID, editionID, codeID string) ([]datastore.Mapping, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if _, err := s.code(codeListID, editionID, codeID); err != nil {
+		return nil, err
+	}
+
+	ref := datastore.CodeRef{CodeListID: codeListID, EditionID: editionID, Code: codeID}
+	mappings := make([]datastore.Mapping, 0, len(s.mappingsFrom[ref])+len(s.mappingsTo[ref]))
+	mappings = append(mappings, s.mappingsFrom[ref]...)
+	mappings = append(mappings, s.mappingsTo[ref]...)
+	return mappings, nil
+}
+
+// edition finds an edition fixture. The caller must hold the read lock.
+func (s *Store) edition(codeListID, editionID string) (*Edition, error) {
+	codeList, ok := s.codeLists[codeListID]
+	if !ok {
+		return nil, driver.ErrNotFound
+	}
+	for i := range codeList.Editions {
+		if codeList.Editions[i].ID == editionID {
+			return &codeList.Editions[i], nil
+		}
+	}
+	return nil, driver.ErrNotFound
+}
+
+// code finds a code fixture. The caller must hold the read lock.
+func (s *Store) code(codeListID, editionID, codeID string) (*Code, error) {
+	edition, err := s.edition(codeListID, editionID)
+	if err != nil {
+		return nil, err
+	}
+	i, ok := edition.index[codeID]
+	if !ok {
+		return nil, driver.ErrNotFound
+	}
+	return &edition.Codes[i], nil
+}
@@ -0,0 +1,437 @@
+package memory_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/datastore/memory"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	"github.com/ONSdigital/dp-graph/v2/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var releaseDate2019 = time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC)
+
+var testFixtures = &memory.Fixtures{
+	CodeLists: []memory.CodeList{
+		{
+			ID:      "local-authority",
+			Label:   "Local authority",
+			Type:    "geography",
+			Contact: &datastore.Contact{Name: "Geography"},
+			Editions: []memory.Edition{
+				{
+					ID:          "2019",
+					Label:       "Local authority 2019",
+					ReleaseDate: &releaseDate2019,
+					State:       datastore.EditionRetired,
+					Codes: []memory.Code{
+						{
+							Code:  "E06000001",
+							Label: "Hartlepool",
+							Datasets: []memory.Dataset{
+								{
+									ID:             "cpih01",
+									DimensionLabel: "geography",
+									Editions:       []memory.DatasetEdition{{ID: "time-series", LatestVersion: 3}},
+								},
+							},
+						},
+						{Code: "E06000002", Label: "Middlesbrough"},
+					},
+				},
+				{ID: "2020", Label: "Local authority 2020", State: datastore.EditionDraft, Supersedes: "2019", Codes: []memory.Code{}},
+			},
+		},
+		{
+			ID:       "aggregate",
+			Editions: []memory.Edition{},
+		},
+	},
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given an in-memory store seeded with fixtures", t, func() {
+		store := memory.New(testFixtures)
+
+		Convey("GetCodeLists returns all code lists when no filter is provided", func() {
+			codeLists, err := store.GetCodeLists(ctx, "")
+			So(err, ShouldBeNil)
+			So(codeLists.Items, ShouldHaveLength, 2)
+		})
+
+		Convey("GetCodeLists returns only the code lists of the requested type", func() {
+			codeLists, err := store.GetCodeLists(ctx, "geography")
+			So(err, ShouldBeNil)
+			So(codeLists.Items, ShouldResemble, []models.CodeList{{ID: "local-authority"}})
+		})
+
+		Convey("GetCodeList returns ErrNotFound for an unknown code list", func() {
+			_, err := store.GetCodeList(ctx, "unknown")
+			So(err, ShouldEqual, driver.ErrNotFound)
+		})
+
+		Convey("GetCodeListMetadata returns the metadata of a code list", func() {
+			metadata, err := store.GetCodeListMetadata(ctx, "local-authority")
+			So(err, ShouldBeNil)
+			So(metadata, ShouldResemble, &datastore.CodeListMetadata{
+				ID:      "local-authority",
+				Label:   "Local authority",
+				Type:    "geography",
+				Contact: &datastore.Contact{Name: "Geography"},
+			})
+
+			metadata.Contact.Name = "modified"
+			metadata, _ = store.GetCodeListMetadata(ctx, "local-authority")
+			So(metadata.Contact.Name, ShouldEqual, "Geography")
+		})
+
+		Convey("GetCodeListsMetadata returns the metadata of every code list", func() {
+			metadata, err := store.GetCodeListsMetadata(ctx)
+			So(err, ShouldBeNil)
+			So(metadata, ShouldHaveLength, len(testFixtures.CodeLists))
+			for _, item := range metadata {
+				expected, err := store.GetCodeListMetadata(ctx, item.ID)
+				So(err, ShouldBeNil)
+				So(item, ShouldResemble, *expected)
+			}
+		})
+
+		Convey("GetCodeListMetadata returns ErrNotFound for an unknown code list", func() {
+			_, err := store.GetCodeListMetadata(ctx, "unknown")
+			So(err, ShouldEqual, driver.ErrNotFound)
+		})
+
+		Convey("GetEditionsMetadata returns the metadata of the editions of a code list", func() {
+			metadata, err := store.GetEditionsMetadata(ctx, "local-authority")
+			So(err, ShouldBeNil)
+			So(metadata, ShouldResemble, []datastore.EditionMetadata{
+				{ID: "2019", ReleaseDate: &releaseDate2019, State: datastore.EditionRetired, SupersededBy: "2020", CodeCount: 2},
+				{ID: "2020", State: datastore.EditionDraft, Supersedes: "2019"},
+			})
+		})
+
+		Convey("GetEditions returns all editions of a code list", func() {
+			editions, err := store.GetEditions(ctx, "local-authority")
+			So(err, ShouldBeNil)
+			So(editions.Items, ShouldResemble, []models.Edition{
+				{ID: "2019", Label: "Local authority 2019"},
+				{ID: "2020", Label: "Local authority 2020"},
+			})
+		})
+
+		Convey("GetEditions returns an empty list for a code list without editions", func() {
+			editions, err := store.GetEditions(ctx, "aggregate")
+			So(err, ShouldBeNil)
+			So(editions.Items, ShouldBeEmpty)
+		})
+
+		Convey("GetEdition returns ErrNotFound for an unknown edition", func() {
+			_, err := store.GetEdition(ctx, "local-authority", "1999")
+			So(err, ShouldEqual, driver.ErrNotFound)
+		})
+
+		Convey("CountCodes returns the number of codes in an edition", func() {
+			count, err := store.CountCodes(ctx, "local-authority", "2019")
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 2)
+
+			count, err = store.CountCodes(ctx, "local-authority", "2020")
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 0)
+		})
+
+		Convey("CountCodes returns ErrNotFound for an unknown edition", func() {
+			_, err := store.CountCodes(ctx, "local-authority", "1999")
+			So(err, ShouldEqual, driver.ErrNotFound)
+		})
+
+		Convey("GetCodes returns the codes of an edition in fixture order", func() {
+			codes, err := store.GetCodes(ctx, "local-authority", "2019")
+			So(err, ShouldBeNil)
+			So(codes.Items, ShouldResemble, []models.Code{
+				{Code: "E06000001", Label: "Hartlepool"},
+				{Code: "E06000002", Label: "Middlesbrough"},
+			})
+		})
+
+		Convey("GetCodes returns ErrNotFound for an edition without codes", func() {
+			_, err := store.GetCodes(ctx, "local-authority", "2020")
+			So(err, ShouldEqual, driver.ErrNotFound)
+		})
+
+		Convey("GetCode returns the requested code", func() {
+			code, err := store.GetCode(ctx, "local-authority", "2019", "E06000002")
+			So(err, ShouldBeNil)
+			So(code, ShouldResemble, &models.Code{Code: "E06000002", Label: "Middlesbrough"})
+		})
+
+		Convey("GetCode returns ErrNotFound for an unknown code", func() {
+			_, err := store.GetCode(ctx, "local-authority", "2019", "W06000001")
+			So(err, ShouldEqual, driver.ErrNotFound)
+		})
+
+		Convey("GetCodeDatasets returns the datasets related to a code", func() {
+			datasets, err := store.GetCodeDatasets(ctx, "local-authority", "2019", "E06000001")
+			So(err, ShouldBeNil)
+			So(datasets.Items, ShouldResemble, []models.Dataset{
+				{
+					ID:             "cpih01",
+					DimensionLabel: "geography",
+					Editions:       []models.DatasetEdition{{ID: "time-series", CodeListID: "local-authority", LatestVersion: 3}},
+				},
+			})
+		})
+
+		Convey("GetCodeDatasets returns an empty list for a code without datasets", func() {
+			datasets, err := store.GetCodeDatasets(ctx, "local-authority", "2019", "E06000002")
+			So(err, ShouldBeNil)
+			So(datasets.Items, ShouldBeEmpty)
+		})
+
+		Convey("GetCodesPage returns a page of codes and the total number of codes", func() {
+			codes, totalCount, err := store.GetCodesPage(ctx, "local-authority", "2019", datastore.Page{Offset: 1, Limit: 10})
+			So(err, ShouldBeNil)
+			So(totalCount, ShouldEqual, 2)
+			So(codes.Items, ShouldResemble, []models.Code{{Code: "E06000002", Label: "Middlesbrough"}})
+		})
+
+		Convey("GetCodesPage sorts codes before taking the page when an order is requested", func() {
+			codes, _, err := store.GetCodesPage(ctx, "local-authority", "2019", datastore.Page{Offset: 0, Limit: 1, Order: datastore.OrderByLabel})
+			So(err, ShouldBeNil)
+			So(codes.Items, ShouldResemble, []models.Code{{Code: "E06000001", Label: "Hartlepool"}})
+		})
+
+		Convey("GetCodesPage returns ErrNotFound for an unknown edition", func() {
+			_, _, err := store.GetCodesPage(ctx, "local-authority", "1999", datastore.Page{Limit: 10})
+			So(err, ShouldEqual, driver.ErrNotFound)
+		})
+
+		Convey("GetCodeListsPage returns a page of code lists sorted by ID", func() {
+			codeLists, totalCount, err := store.GetCodeListsPage(ctx, "", datastore.Page{Offset: 0, Limit: 1, Order: datastore.OrderByID})
+			So(err, ShouldBeNil)
+			So(totalCount, ShouldEqual, 2)
+			So(codeLists.Items, ShouldResemble, []models.CodeList{{ID: "aggregate"}})
+		})
+
+		Convey("GetEditionsPage returns an empty page when the offset is greater than the total", func() {
+			editions, totalCount, err := store.GetEditionsPage(ctx, "local-authority", datastore.Page{Offset: 5, Limit: 1})
+			So(err, ShouldBeNil)
+			So(totalCount, ShouldEqual, 2)
+			So(editions.Items, ShouldBeEmpty)
+		})
+
+		Convey("SearchCodes returns a page of the codes matching the query, and the number of matching codes", func() {
+			codes, totalCount, err := store.SearchCodes(ctx, "local-authority", "2019", "BROUGH", datastore.Page{Limit: 10})
+			So(err, ShouldBeNil)
+			So(totalCount, ShouldEqual, 1)
+			So(codes.Items, ShouldResemble, []models.Code{{Code: "E06000002", Label: "Middlesbrough"}})
+		})
+
+		Convey("SearchCodes returns an empty page for an edition without codes", func() {
+			codes, totalCount, err := store.SearchCodes(ctx, "local-authority", "2020", "e06", datastore.Page{Limit: 10})
+			So(err, ShouldBeNil)
+			So(totalCount, ShouldEqual, 0)
+			So(codes.Items, ShouldBeEmpty)
+		})
+
+		Convey("GetCodeEditions returns the code list editions containing a code", func() {
+			codeEditions, err := store.GetCodeEditions(ctx, "E06000002")
+			So(err, ShouldBeNil)
+			So(codeEditions, ShouldResemble, []datastore.CodeEdition{
+				{CodeListID: "local-authority", EditionID: "2019", Code: models.Code{Code: "E06000002", Label: "Middlesbrough"}},
+			})
+		})
+
+		Convey("GetCodeEditions returns an empty list for an unknown code", func() {
+			codeEditions, err := store.GetCodeEditions(ctx, "E99999999")
+			So(err, ShouldBeNil)
+			So(codeEditions, ShouldBeEmpty)
+		})
+
+		Convey("SuggestCodes returns the codes whose label starts with the prefix", func() {
+			codes, err := store.SuggestCodes(ctx, "local-authority", "2019", "middles", 10)
+			So(err, ShouldBeNil)
+			So(codes, ShouldResemble, []models.Code{{Code: "E06000002", Label: "Middlesbrough"}})
+		})
+
+		Convey("SuggestCodes returns ErrNotFound for an unknown edition", func() {
+			_, err := store.SuggestCodes(ctx, "local-authority", "1999", "middles", 10)
+			So(err, ShouldEqual, driver.ErrNotFound)
+		})
+
+		Convey("GetCodesByID returns the requested codes in the requested order, skipping unknown codes", func() {
+			codes, err := store.GetCodesByID(ctx, "local-authority", "2019", []string{"E06000002", "E99999999", "E06000001"})
+			So(err, ShouldBeNil)
+			So(codes, ShouldResemble, []models.Code{{Code: "E06000002", Label: "Middlesbrough"}, {Code: "E06000001", Label: "Hartlepool"}})
+		})
+
+		Convey("GetCodesByID returns ErrNotFound for an unknown edition", func() {
+			_, err := store.GetCodesByID(ctx, "local-authority", "1999", []string{"E06000001"})
+			So(err, ShouldEqual, driver.ErrNotFound)
+		})
+
+		Convey("Seed replaces the content of the store", func() {
+			store.Seed(nil)
+			codeLists, err := store.GetCodeLists(ctx, "")
+			So(err, ShouldBeNil)
+			So(codeLists.Items, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestHierarchy(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given an in-memory store seeded with a hierarchy of codes", t, func() {
+		store := memory.New(&memory.Fixtures{CodeLists: []memory.CodeList{{
+			ID: "cpih1dim1aggid",
+			Editions: []memory.Edition{{
+				ID: "one-off",
+				Codes: []memory.Code{
+					{Code: "cpih1dim1A0", Label: "Overall Index"},
+					{Code: "cpih1dim1G10100", Label: "Food", Parent: "cpih1dim1A0"},
+					{Code: "cpih1dim1S10101", Label: "Bread", Parent: "cpih1dim1G10100"},
+					{Code: "cpih1dim1G20100", Label: "Alcohol", Parent: "cpih1dim1A0"},
+					{Code: "cpih1dim1X", Label: "Orphan", Parent: "unknown"},
+				},
+			}},
+		}}})
+
+		Convey("GetParentCode returns the parent of a code", func() {
+			code, err := store.GetParentCode(ctx, "cpih1dim1aggid", "one-off", "cpih1dim1S10101")
+			So(err, ShouldBeNil)
+			So(code, ShouldResemble, &models.Code{Code: "cpih1dim1G10100", Label: "Food"})
+		})
+
+		Convey("GetParentCode returns nil for codes at the top of the hierarchy, or with an unknown parent", func() {
+			code, err := store.GetParentCode(ctx, "cpih1dim1aggid", "one-off", "cpih1dim1A0")
+			So(err, ShouldBeNil)
+			So(code, ShouldBeNil)
+
+			code, err = store.GetParentCode(ctx, "cpih1dim1aggid", "one-off", "cpih1dim1X")
+			So(err, ShouldBeNil)
+			So(code, ShouldBeNil)
+		})
+
+		Convey("GetParentCode returns ErrNotFound for an unknown code", func() {
+			_, err := store.GetParentCode(ctx, "cpih1dim1aggid", "one-off", "unknown")
+			So(err, ShouldEqual, driver.ErrNotFound)
+		})
+
+		Convey("GetChildCodes returns the children of a code in fixture order", func() {
+			codes, err := store.GetChildCodes(ctx, "cpih1dim1aggid", "one-off", "cpih1dim1A0")
+			So(err, ShouldBeNil)
+			So(codes, ShouldResemble, []models.Code{
+				{Code: "cpih1dim1G10100", Label: "Food"},
+				{Code: "cpih1dim1G20100", Label: "Alcohol"},
+			})
+		})
+
+		Convey("GetChildCodes returns an empty list for a code without children", func() {
+			codes, err := store.GetChildCodes(ctx, "cpih1dim1aggid", "one-off", "cpih1dim1S10101")
+			So(err, ShouldBeNil)
+			So(codes, ShouldBeEmpty)
+		})
+
+		Convey("GetChildCodes returns ErrNotFound for an unknown code", func() {
+			_, err := store.GetChildCodes(ctx, "cpih1dim1aggid", "one-off", "unknown")
+			So(err, ShouldEqual, driver.ErrNotFound)
+		})
+
+		Convey("GetParentCodeIDs returns the parent of every code with a known parent", func() {
+			parents, err := store.GetParentCodeIDs(ctx, "cpih1dim1aggid", "one-off")
+			So(err, ShouldBeNil)
+			So(parents, ShouldResemble, map[string]string{
+				"cpih1dim1G10100": "cpih1dim1A0",
+				"cpih1dim1S10101": "cpih1dim1G10100",
+				"cpih1dim1G20100": "cpih1dim1A0",
+			})
+		})
+
+		Convey("GetAncestorCodes returns the ancestors of a code from the top of the hierarchy", func() {
+			codes, err := datastore.GetAncestorCodes(ctx, store, "cpih1dim1aggid", "one-off", "cpih1dim1S10101")
+			So(err, ShouldBeNil)
+			So(codes, ShouldResemble, []models.Code{
+				{Code: "cpih1dim1A0", Label: "Overall Index"},
+				{Code: "cpih1dim1G10100", Label: "Food"},
+			})
+		})
+	})
+}
+
+func TestNewFromFile(t *testing.T) {
+	Convey("Given a JSON fixtures file", t, func() {
+		dir, err := ioutil.TempDir("", "memory")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "fixtures.json")
+		fixtures := `{"code_lists":[{"id":"cpih1dim1aggid","editions":[{"id":"one-off","label":"CPIH","codes":[{"code":"cpih1dim1A0","label":"Overall Index"}]}]}]}`
+		So(ioutil.WriteFile(path, []byte(fixtures), 0644), ShouldBeNil)
+
+		Convey("NewFromFile returns a store seeded with its content", func() {
+			store, err := memory.NewFromFile(path)
+			So(err, ShouldBeNil)
+
+			code, err := store.GetCode(context.Background(), "cpih1dim1aggid", "one-off", "cpih1dim1A0")
+			So(err, ShouldBeNil)
+			So(code.Label, ShouldEqual, "Overall Index")
+		})
+	})
+
+	Convey("NewFromFile returns an error for a missing file", t, func() {
+		_, err := memory.NewFromFile("/non/existent/fixtures.json")
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestMappings(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given an in-memory store seeded with a mapping between two editions", t, func() {
+		mapping := datastore.Mapping{
+			Source: datastore.CodeRef{CodeListID: "local-authority", EditionID: "2019", Code: "E06000001"},
+			Target: datastore.CodeRef{CodeListID: "local-authority", EditionID: "2020", Code: "E06000001"},
+			Type:   datastore.MappingSuccessor,
+		}
+		store := memory.New(&memory.Fixtures{
+			CodeLists: []memory.CodeList{{
+				ID: "local-authority",
+				Editions: []memory.Edition{
+					{ID: "2019", Codes: []memory.Code{{Code: "E06000001"}, {Code: "E06000002"}}},
+					{ID: "2020", Codes: []memory.Code{{Code: "E06000001"}}},
+				},
+			}},
+			Mappings: []datastore.Mapping{mapping},
+		})
+
+		Convey("The mapping is returned for both its source and target codes", func() {
+			mappings, err := store.GetMappings(ctx, "local-authority", "2019", "E06000001")
+			So(err, ShouldBeNil)
+			So(mappings, ShouldResemble, []datastore.Mapping{mapping})
+
+			mappings, err = store.GetMappings(ctx, "local-authority", "2020", "E06000001")
+			So(err, ShouldBeNil)
+			So(mappings, ShouldResemble, []datastore.Mapping{mapping})
+		})
+
+		Convey("A code without mappings has no mappings", func() {
+			mappings, err := store.GetMappings(ctx, "local-authority", "2019", "E06000002")
+			So(err, ShouldBeNil)
+			So(mappings, ShouldBeEmpty)
+		})
+
+		Convey("An unknown code results in ErrNotFound", func() {
+			_, err := store.GetMappings(ctx, "local-authority", "2019", "unknown")
+			So(err, ShouldEqual, driver.ErrNotFound)
+		})
+	})
+}
@@ -0,0 +1,220 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-code-list-api/csvimport"
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+)
+
+// CreateCodeList creates a code list without editions
+func (s *Store) CreateCodeList(ctx context.Context, metadata datastore.CodeListMetadata) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.codeLists[metadata.ID]; ok {
+		return datastore.ErrAlreadyExists
+	}
+	copied := datastore.CopyCodeListMetadata(&metadata)
+	s.codeLists[metadata.ID] = &CodeList{
+		ID:          copied.ID,
+		Label:       copied.Label,
+		Description: copied.Description,
+		Type:        copied.Type,
+		Licence:     copied.Licence,
+		Contact:     copied.Contact,
+		Editions:    []Edition{},
+	}
+	return nil
+}
+
+// PutEdition creates an edition without codes, or replaces the label and metadata of an existing edition
+func (s *Store) PutEdition(ctx context.Context, codeListID string, update datastore.EditionUpdate) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	codeList, ok := s.codeLists[codeListID]
+	if !ok {
+		return false, driver.ErrNotFound
+	}
+	if update.Supersedes != "" {
+		if update.Supersedes == update.ID {
+			return false, datastore.Invalid("edition %q cannot supersede itself", update.ID)
+		}
+		if _, err := s.edition(codeListID, update.Supersedes); err != nil {
+			return false, datastore.Invalid("edition %q supersedes unknown edition %q", update.ID, update.Supersedes)
+		}
+	}
+
+	edition, err := s.edition(codeListID, update.ID)
+	created := err == driver.ErrNotFound
+	if created {
+		codeList.Editions = append(codeList.Editions, Edition{ID: update.ID, Codes: []Code{}})
+		edition = &codeList.Editions[len(codeList.Editions)-1]
+		edition.reindex()
+	}
+	edition.Label = update.Label
+	edition.ReleaseDate = update.ReleaseDate
+	edition.State = update.State
+	edition.Supersedes = update.Supersedes
+	return created, nil
+}
+
+// PutCode creates or replaces a code of an edition. The parent of the code must be a code of the edition,
+// other than the code itself or one of its descendants.
+func (s *Store) PutCode(ctx context.Context, codeListID, editionID string, update datastore.CodeUpdate) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	edition, err := s.edition(codeListID, editionID)
+	if err != nil {
+		return false, err
+	}
+	if update.Parent != "" {
+		if _, ok := edition.index[update.Parent]; !ok {
+			return false, datastore.Invalid("unknown parent %q of code %q", update.Parent, update.Code)
+		}
+		// only the ancestors of the new parent are walked up to the top of the hierarchy, stopping at any code
+		// seen twice, as the fixtures the store was seeded with are not checked
+		seen := map[string]bool{}
+		for parent := update.Parent; parent != "" && !seen[parent]; {
+			if parent == update.Code {
+				return false, datastore.Invalid("code %q cannot be its own ancestor", update.Code)
+			}
+			seen[parent] = true
+			i, ok := edition.index[parent]
+			if !ok {
+				break
+			}
+			parent = edition.Codes[i].Parent
+		}
+	}
+
+	i, ok := edition.index[update.Code]
+	if ok && edition.Codes[i].Parent == update.Parent {
+		// only the label changes, which is only indexed for suggestions
+		edition.Codes[i].Label = update.Label
+		edition.labels = nil
+		return false, nil
+	}
+	if ok {
+		edition.Codes[i].Label = update.Label
+		edition.Codes[i].Parent = update.Parent
+	} else {
+		edition.Codes = append(edition.Codes, Code{Code: update.Code, Label: update.Label, Parent: update.Parent})
+	}
+	edition.reindex()
+	return !ok, nil
+}
+
+// DeleteCode deletes a code of an edition, along with the mappings from and to it
+func (s *Store) DeleteCode(ctx context.Context, codeListID, editionID, codeID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	edition, err := s.edition(codeListID, editionID)
+	if err != nil {
+		return err
+	}
+	i, ok := edition.index[codeID]
+	if !ok {
+		return driver.ErrNotFound
+	}
+	if len(edition.children[codeID]) > 0 {
+		return datastore.Invalid("code %q cannot be deleted as it has children", codeID)
+	}
+
+	edition.Codes = append(edition.Codes[:i], edition.Codes[i+1:]...)
+	edition.reindex()
+
+	ref := datastore.CodeRef{CodeListID: codeListID, EditionID: editionID, Code: codeID}
+	for _, mapping := range s.mappingsFrom[ref] {
+		s.mappingsTo[mapping.Target] = withoutMapping(s.mappingsTo[mapping.Target], ref)
+	}
+	for _, mapping := range s.mappingsTo[ref] {
+		s.mappingsFrom[mapping.Source] = withoutMapping(s.mappingsFrom[mapping.Source], ref)
+	}
+	delete(s.mappingsFrom, ref)
+	delete(s.mappingsTo, ref)
+	return nil
+}
+
+// ImportEdition creates an edition along with its codes. The codes are checked as a whole before the edition
+// is added, so that the store is left unchanged if any of them is rejected.
+func (s *Store) ImportEdition(ctx context.Context, codeListID string, update datastore.EditionUpdate, codes []datastore.CodeUpdate) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	codeList, ok := s.codeLists[codeListID]
+	if !ok {
+		return driver.ErrNotFound
+	}
+	if _, err := s.edition(codeListID, update.ID); err == nil {
+		return datastore.ErrAlreadyExists
+	}
+	if update.Supersedes != "" {
+		if _, err := s.edition(codeListID, update.Supersedes); err != nil {
+			return datastore.Invalid("edition %q supersedes unknown edition %q", update.ID, update.Supersedes)
+		}
+	}
+
+	edition := Edition{
+		ID:          update.ID,
+		Label:       update.Label,
+		ReleaseDate: update.ReleaseDate,
+		State:       update.State,
+		Supersedes:  update.Supersedes,
+		Codes:       make([]Code, 0, len(codes)),
+	}
+	for _, code := range codes {
+		edition.Codes = append(edition.Codes, Code{Code: code.Code, Label: code.Label, Parent: code.Parent})
+	}
+	if err := checkCodes(edition.Codes); err != nil {
+		return err
+	}
+
+	edition.reindex()
+	codeList.Editions = append(codeList.Editions, edition)
+	return nil
+}
+
+// checkCodes checks that the codes of an edition are unique, and that the parent of each code is another code
+// of the edition which does not make it its own ancestor
+func checkCodes(codes []Code) error {
+	parents := make(map[string]string, len(codes))
+	for _, code := range codes {
+		if _, ok := parents[code.Code]; ok {
+			return datastore.Invalid("duplicate code %q", code.Code)
+		}
+		parents[code.Code] = code.Parent
+	}
+
+	for _, code := range codes {
+		if code.Parent == "" {
+			continue
+		}
+		if _, ok := parents[code.Parent]; !ok {
+			return datastore.Invalid("unknown parent %q of code %q", code.Parent, code.Code)
+		}
+	}
+
+	inCycle := csvimport.Cycles(parents)
+	for _, code := range codes {
+		if inCycle[code.Code] {
+			return datastore.Invalid("code %q cannot be its own ancestor", code.Code)
+		}
+	}
+	return nil
+}
+
+// withoutMapping returns a copy of the mappings, without those from or to the code
+func withoutMapping(mappings []datastore.Mapping, ref datastore.CodeRef) []datastore.Mapping {
+	kept := make([]datastore.Mapping, 0, len(mappings))
+	for _, mapping := range mappings {
+		if mapping.Source != ref && mapping.Target != ref {
+			kept = append(kept, mapping)
+		}
+	}
+	return kept
+}
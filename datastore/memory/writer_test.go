@@ -0,0 +1,238 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/datastore/memory"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	"github.com/ONSdigital/dp-graph/v2/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWriter(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given an in-memory store seeded with fixtures", t, func() {
+		store := memory.New(testFixtures)
+
+		Convey("CreateCodeList creates a code list without editions", func() {
+			err := store.CreateCodeList(ctx, datastore.CodeListMetadata{ID: "country", Label: "Country"})
+			So(err, ShouldBeNil)
+
+			metadata, err := store.GetCodeListMetadata(ctx, "country")
+			So(err, ShouldBeNil)
+			So(metadata.Label, ShouldEqual, "Country")
+
+			editions, err := store.GetEditions(ctx, "country")
+			So(err, ShouldBeNil)
+			So(editions.Items, ShouldBeEmpty)
+		})
+
+		Convey("CreateCodeList returns ErrAlreadyExists for an existing code list", func() {
+			err := store.CreateCodeList(ctx, datastore.CodeListMetadata{ID: "local-authority"})
+			So(err, ShouldEqual, datastore.ErrAlreadyExists)
+		})
+
+		Convey("PutEdition creates an edition without codes", func() {
+			created, err := store.PutEdition(ctx, "local-authority", datastore.EditionUpdate{ID: "2021", Label: "Local authority 2021", Supersedes: "2020"})
+			So(err, ShouldBeNil)
+			So(created, ShouldBeTrue)
+
+			edition, err := store.GetEdition(ctx, "local-authority", "2021")
+			So(err, ShouldBeNil)
+			So(edition.Label, ShouldEqual, "Local authority 2021")
+
+			count, err := store.CountCodes(ctx, "local-authority", "2021")
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 0)
+		})
+
+		Convey("PutEdition replaces the label of an existing edition, keeping its codes", func() {
+			created, err := store.PutEdition(ctx, "local-authority", datastore.EditionUpdate{ID: "2019", Label: "LA 2019"})
+			So(err, ShouldBeNil)
+			So(created, ShouldBeFalse)
+
+			edition, err := store.GetEdition(ctx, "local-authority", "2019")
+			So(err, ShouldBeNil)
+			So(edition.Label, ShouldEqual, "LA 2019")
+
+			count, err := store.CountCodes(ctx, "local-authority", "2019")
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 2)
+		})
+
+		Convey("PutEdition returns ErrNotFound for an unknown code list", func() {
+			_, err := store.PutEdition(ctx, "unknown", datastore.EditionUpdate{ID: "2021"})
+			So(err, ShouldEqual, driver.ErrNotFound)
+		})
+
+		Convey("PutEdition rejects an edition superseding an unknown edition", func() {
+			_, err := store.PutEdition(ctx, "local-authority", datastore.EditionUpdate{ID: "2021", Supersedes: "1999"})
+			So(err, ShouldHaveSameTypeAs, &datastore.InvalidError{})
+		})
+
+		Convey("PutCode creates a code in an edition", func() {
+			created, err := store.PutCode(ctx, "local-authority", "2020", datastore.CodeUpdate{Code: "E06000003", Label: "Redcar and Cleveland"})
+			So(err, ShouldBeNil)
+			So(created, ShouldBeTrue)
+
+			code, err := store.GetCode(ctx, "local-authority", "2020", "E06000003")
+			So(err, ShouldBeNil)
+			So(code, ShouldResemble, &models.Code{Code: "E06000003", Label: "Redcar and Cleveland"})
+		})
+
+		Convey("PutCode replaces the label of an existing code, leaving the fixtures unchanged", func() {
+			created, err := store.PutCode(ctx, "local-authority", "2019", datastore.CodeUpdate{Code: "E06000001", Label: "Hartlepool UA"})
+			So(err, ShouldBeNil)
+			So(created, ShouldBeFalse)
+
+			code, err := store.GetCode(ctx, "local-authority", "2019", "E06000001")
+			So(err, ShouldBeNil)
+			So(code.Label, ShouldEqual, "Hartlepool UA")
+			So(testFixtures.CodeLists[0].Editions[0].Codes[0].Label, ShouldEqual, "Hartlepool")
+		})
+
+		Convey("PutCode changes the codes suggested for a prefix", func() {
+			codes, err := store.SuggestCodes(ctx, "local-authority", "2019", "hart", 10)
+			So(err, ShouldBeNil)
+			So(codes, ShouldHaveLength, 1)
+
+			_, err = store.PutCode(ctx, "local-authority", "2019", datastore.CodeUpdate{Code: "E06000001", Label: "West Hartlepool"})
+			So(err, ShouldBeNil)
+			_, err = store.PutCode(ctx, "local-authority", "2019", datastore.CodeUpdate{Code: "E06000099", Label: "Hartlepool"})
+			So(err, ShouldBeNil)
+
+			codes, err = store.SuggestCodes(ctx, "local-authority", "2019", "hart", 10)
+			So(err, ShouldBeNil)
+			So(codes, ShouldResemble, []models.Code{
+				{Code: "E06000099", Label: "Hartlepool"},
+				{Code: "E06000001", Label: "West Hartlepool"},
+			})
+		})
+
+		Convey("PutCode rejects a code whose parent is not in the edition", func() {
+			_, err := store.PutCode(ctx, "local-authority", "2019", datastore.CodeUpdate{Code: "E06000001", Parent: "E92000001"})
+			So(err, ShouldHaveSameTypeAs, &datastore.InvalidError{})
+		})
+
+		Convey("PutCode rejects a code which would be its own ancestor", func() {
+			_, err := store.PutCode(ctx, "local-authority", "2019", datastore.CodeUpdate{Code: "E06000002", Parent: "E06000001"})
+			So(err, ShouldBeNil)
+
+			_, err = store.PutCode(ctx, "local-authority", "2019", datastore.CodeUpdate{Code: "E06000001", Parent: "E06000002"})
+			So(err, ShouldHaveSameTypeAs, &datastore.InvalidError{})
+		})
+
+		Convey("DeleteCode deletes a code from an edition", func() {
+			err := store.DeleteCode(ctx, "local-authority", "2019", "E06000002")
+			So(err, ShouldBeNil)
+
+			_, err = store.GetCode(ctx, "local-authority", "2019", "E06000002")
+			So(err, ShouldEqual, driver.ErrNotFound)
+
+			code, err := store.GetCode(ctx, "local-authority", "2019", "E06000001")
+			So(err, ShouldBeNil)
+			So(code.Label, ShouldEqual, "Hartlepool")
+		})
+
+		Convey("DeleteCode rejects a code which has children", func() {
+			_, err := store.PutCode(ctx, "local-authority", "2019", datastore.CodeUpdate{Code: "E06000002", Parent: "E06000001"})
+			So(err, ShouldBeNil)
+
+			err = store.DeleteCode(ctx, "local-authority", "2019", "E06000001")
+			So(err, ShouldHaveSameTypeAs, &datastore.InvalidError{})
+		})
+
+		Convey("DeleteCode returns ErrNotFound for an unknown code", func() {
+			err := store.DeleteCode(ctx, "local-authority", "2019", "E99999999")
+			So(err, ShouldEqual, driver.ErrNotFound)
+		})
+
+		Convey("ImportEdition creates an edition with its codes", func() {
+			err := store.ImportEdition(ctx, "local-authority", datastore.EditionUpdate{ID: "2021", Label: "2021", Supersedes: "2020"}, []datastore.CodeUpdate{
+				{Code: "E92000001", Label: "England"},
+				{Code: "E06000001", Label: "Hartlepool", Parent: "E92000001"},
+			})
+			So(err, ShouldBeNil)
+
+			codes, err := store.GetCodes(ctx, "local-authority", "2021")
+			So(err, ShouldBeNil)
+			So(codes.Items, ShouldResemble, []models.Code{{Code: "E92000001", Label: "England"}, {Code: "E06000001", Label: "Hartlepool"}})
+
+			parent, err := store.GetParentCode(ctx, "local-authority", "2021", "E06000001")
+			So(err, ShouldBeNil)
+			So(parent.Code, ShouldEqual, "E92000001")
+		})
+
+		Convey("ImportEdition returns ErrAlreadyExists for an existing edition", func() {
+			err := store.ImportEdition(ctx, "local-authority", datastore.EditionUpdate{ID: "2019"}, []datastore.CodeUpdate{{Code: "E06000001", Label: "Hartlepool"}})
+			So(err, ShouldEqual, datastore.ErrAlreadyExists)
+		})
+
+		Convey("ImportEdition leaves the store unchanged if a code is rejected", func() {
+			err := store.ImportEdition(ctx, "local-authority", datastore.EditionUpdate{ID: "2021"}, []datastore.CodeUpdate{
+				{Code: "E06000001", Label: "Hartlepool"},
+				{Code: "E06000001", Label: "Hartlepool"},
+			})
+			So(err, ShouldHaveSameTypeAs, &datastore.InvalidError{})
+
+			_, err = store.GetEdition(ctx, "local-authority", "2021")
+			So(err, ShouldEqual, driver.ErrNotFound)
+		})
+
+		Convey("ImportEdition rejects codes which are their own ancestor", func() {
+			err := store.ImportEdition(ctx, "local-authority", datastore.EditionUpdate{ID: "2021"}, []datastore.CodeUpdate{
+				{Code: "E06000001", Label: "Hartlepool", Parent: "E06000002"},
+				{Code: "E06000002", Label: "Middlesbrough", Parent: "E06000001"},
+			})
+			So(err, ShouldHaveSameTypeAs, &datastore.InvalidError{})
+			So(err.Error(), ShouldContainSubstring, `"E06000001" cannot be its own ancestor`)
+		})
+	})
+
+	Convey("Given an in-memory store seeded with codes which are their own ancestor", t, func() {
+		store := memory.New(&memory.Fixtures{
+			CodeLists: []memory.CodeList{{
+				ID: "local-authority",
+				Editions: []memory.Edition{{ID: "2019", Codes: []memory.Code{
+					{Code: "E06000001", Parent: "E06000002"},
+					{Code: "E06000002", Parent: "E06000001"},
+				}}},
+			}},
+		})
+
+		Convey("PutCode stops walking up the ancestors of the parent once the cycle is found", func() {
+			created, err := store.PutCode(ctx, "local-authority", "2019", datastore.CodeUpdate{Code: "E06000003", Parent: "E06000001"})
+			So(err, ShouldBeNil)
+			So(created, ShouldBeTrue)
+		})
+	})
+
+	Convey("Given an in-memory store seeded with a mapping between two editions", t, func() {
+		store := memory.New(&memory.Fixtures{
+			CodeLists: []memory.CodeList{{
+				ID: "local-authority",
+				Editions: []memory.Edition{
+					{ID: "2019", Codes: []memory.Code{{Code: "E06000001"}}},
+					{ID: "2020", Codes: []memory.Code{{Code: "E06000001"}}},
+				},
+			}},
+			Mappings: []datastore.Mapping{{
+				Source: datastore.CodeRef{CodeListID: "local-authority", EditionID: "2019", Code: "E06000001"},
+				Target: datastore.CodeRef{CodeListID: "local-authority", EditionID: "2020", Code: "E06000001"},
+				Type:   datastore.MappingSuccessor,
+			}},
+		})
+
+		Convey("DeleteCode deletes the mappings of the code", func() {
+			err := store.DeleteCode(ctx, "local-authority", "2019", "E06000001")
+			So(err, ShouldBeNil)
+
+			mappings, err := store.GetMappings(ctx, "local-authority", "2020", "E06000001")
+			So(err, ShouldBeNil)
+			So(mappings, ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,59 @@
+package datastore
+
+import (
+	"context"
+)
+
+//go:generate moq -out datastoretest/metadatagetter.go -pkg storetest . MetadataGetter
+
+// CodeListMetadata describes a code list. Type is the type used to filter code lists in GetCodeLists.
+type CodeListMetadata struct {
+	ID          string
+	Label       string
+	Description string
+	Type        string
+	Licence     string
+	Contact     *Contact
+}
+
+// Contact holds the contact details of the team responsible for a code list
+type Contact struct {
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Telephone string `json:"telephone,omitempty"`
+}
+
+// MetadataGetter is implemented by stores holding the metadata of code lists
+type MetadataGetter interface {
+	GetCodeListMetadata(ctx context.Context, codeListID string) (*CodeListMetadata, error)
+	// GetCodeListsMetadata returns the metadata of every code list, in no particular order
+	GetCodeListsMetadata(ctx context.Context) ([]CodeListMetadata, error)
+}
+
+// GetCodeListMetadata returns the metadata of a code list. For stores that do not implement MetadataGetter,
+// the metadata only holds the ID of the code list, and the existence of the code list is not checked.
+func GetCodeListMetadata(ctx context.Context, store DataStore, codeListID string) (*CodeListMetadata, error) {
+	if getter, ok := store.(MetadataGetter); ok {
+		return getter.GetCodeListMetadata(ctx, codeListID)
+	}
+	return &CodeListMetadata{ID: codeListID}, nil
+}
+
+// GetCodeListsMetadata returns the metadata of every code list, keyed by code list ID, so that a list of code
+// lists is described with a single call. For stores that do not implement MetadataGetter, the map is empty.
+func GetCodeListsMetadata(ctx context.Context, store DataStore) (map[string]CodeListMetadata, error) {
+	metadata := map[string]CodeListMetadata{}
+	getter, ok := store.(MetadataGetter)
+	if !ok {
+		return metadata, nil
+	}
+
+	items, err := getter.GetCodeListsMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		metadata[item.ID] = item
+	}
+	return metadata, nil
+}
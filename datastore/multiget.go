@@ -0,0 +1,77 @@
+package datastore
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	"github.com/ONSdigital/dp-graph/v2/models"
+)
+
+//go:generate moq -out datastoretest/multigetter.go -pkg storetest . MultiGetter
+
+// MultiGetter is implemented by stores able to get many codes of an edition at once
+type MultiGetter interface {
+	GetCodesByID(ctx context.Context, codeListID, editionID string, codeIDs []string) ([]models.Code, error)
+}
+
+// maxCodeLookups is the largest number of IDs looked up one by one on stores that do not implement
+// MultiGetter. Codes are filtered from every code of the edition for more IDs.
+const maxCodeLookups = 20
+
+// GetCodesByID returns the codes of an edition with the provided IDs, in the requested order. IDs not found
+// in the edition are skipped, but an unknown edition results in an error. Stores that do not implement
+// MultiGetter are asked for each code, or for every code of the edition, which are then filtered in memory,
+// if many codes are requested.
+func GetCodesByID(ctx context.Context, store DataStore, codeListID, editionID string, codeIDs []string) ([]models.Code, error) {
+	if g, ok := store.(MultiGetter); ok {
+		return g.GetCodesByID(ctx, codeListID, editionID, codeIDs)
+	}
+
+	byID, err := getCodesByID(ctx, store, codeListID, editionID, codeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	found := []models.Code{}
+	for _, id := range codeIDs {
+		if code, ok := byID[id]; ok {
+			found = append(found, code)
+		}
+	}
+	return found, nil
+}
+
+// getCodesByID returns the codes of an edition found with the provided IDs, by ID
+func getCodesByID(ctx context.Context, store DataStore, codeListID, editionID string, codeIDs []string) (map[string]models.Code, error) {
+	byID := map[string]models.Code{}
+
+	if len(codeIDs) > maxCodeLookups {
+		codes, err := GetAllCodes(ctx, store, codeListID, editionID)
+		if err != nil {
+			return nil, err
+		}
+		for _, code := range codes {
+			byID[code.Code] = code
+		}
+		return byID, nil
+	}
+
+	// codes of an unknown edition are not found either, so the edition is checked first
+	if _, err := store.GetEdition(ctx, codeListID, editionID); err != nil {
+		return nil, err
+	}
+	for _, id := range codeIDs {
+		if _, ok := byID[id]; ok {
+			continue
+		}
+		code, err := store.GetCode(ctx, codeListID, editionID, id)
+		if err == driver.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		byID[id] = *code
+	}
+	return byID, nil
+}
@@ -0,0 +1,68 @@
+package datastore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	"github.com/ONSdigital/dp-graph/v2/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetCodesByID(t *testing.T) {
+	ctx := context.Background()
+	codes := []models.Code{{Code: "1", Label: "one"}, {Code: "2", Label: "two"}}
+
+	Convey("Given a store that cannot get many codes at once", t, func() {
+		mockStore := &storetest.DataStoreMock{
+			GetEditionFunc: func(ctx context.Context, codeListID string, editionID string) (*models.Edition, error) {
+				if editionID != "2019" {
+					return nil, driver.ErrNotFound
+				}
+				return &models.Edition{ID: editionID}, nil
+			},
+			GetCodeFunc: func(ctx context.Context, codeListID string, editionID string, codeID string) (*models.Code, error) {
+				for _, code := range codes {
+					if code.Code == codeID {
+						return &code, nil
+					}
+				}
+				return nil, driver.ErrNotFound
+			},
+			CountCodesFunc: func(ctx context.Context, codeListID string, editionID string) (int64, error) {
+				return int64(len(codes)), nil
+			},
+			GetCodesFunc: func(ctx context.Context, codeListID string, editionID string) (*models.CodeResults, error) {
+				return &models.CodeResults{Items: codes}, nil
+			},
+		}
+
+		Convey("A few codes are got one by one, in the requested order, skipping unknown codes", func() {
+			found, err := datastore.GetCodesByID(ctx, mockStore, "codelist", "2019", []string{"2", "3", "1", "2"})
+			So(err, ShouldBeNil)
+			So(found, ShouldResemble, []models.Code{codes[1], codes[0], codes[1]})
+			So(mockStore.GetCodeCalls(), ShouldHaveLength, 3)
+			So(mockStore.GetCodesCalls(), ShouldBeEmpty)
+		})
+
+		Convey("A few codes of an unknown edition are not found", func() {
+			_, err := datastore.GetCodesByID(ctx, mockStore, "codelist", "2018", []string{"1"})
+			So(err, ShouldEqual, driver.ErrNotFound)
+			So(mockStore.GetCodeCalls(), ShouldBeEmpty)
+		})
+
+		Convey("Many codes are filtered from every code of the edition", func() {
+			ids := make([]string, 100)
+			for i := range ids {
+				ids[i] = "1"
+			}
+			found, err := datastore.GetCodesByID(ctx, mockStore, "codelist", "2019", ids)
+			So(err, ShouldBeNil)
+			So(found, ShouldHaveLength, 100)
+			So(mockStore.GetCodesCalls(), ShouldHaveLength, 1)
+			So(mockStore.GetCodeCalls(), ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,184 @@
+package datastore
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ONSdigital/dp-graph/v2/models"
+)
+
+//go:generate moq -out datastoretest/paginator.go -pkg storetest . Paginator
+
+// Order defines how the items of a page are sorted before the page is taken
+type Order string
+
+// Possible orders of a page
+const (
+	// OrderDefault keeps the order of the store, e.g. the defined order of the codes of an edition
+	OrderDefault Order = ""
+	// OrderByID sorts items by ID (the code value for codes)
+	OrderByID Order = "id"
+	// OrderByLabel sorts items by label, for the items that have one
+	OrderByLabel Order = "label"
+)
+
+// Page describes the page of results requested from a store
+type Page struct {
+	Offset int
+	Limit  int
+	Order  Order
+}
+
+// Bounds returns the start and end indexes of the page within n items, so that items[start:end] is the page
+func (p Page) Bounds(n int) (start, end int) {
+	start = p.Offset
+	if start > n {
+		start = n
+	}
+	end = start + p.Limit
+	if end > n {
+		end = n
+	}
+	return start, end
+}
+
+// Paginator is implemented by stores able to return a single page of results, along with the total
+// number of results, so that the full result set does not need to be fetched for every request.
+type Paginator interface {
+	GetCodeListsPage(ctx context.Context, filterBy string, page Page) (*models.CodeListResults, int, error)
+	GetEditionsPage(ctx context.Context, codeListID string, page Page) (*models.Editions, int, error)
+	GetCodesPage(ctx context.Context, codeListID, editionID string, page Page) (*models.CodeResults, int, error)
+	GetCodeDatasetsPage(ctx context.Context, codeListID, edition string, code string, page Page) (*models.Datasets, int, error)
+}
+
+// GetCodeListsPage returns a page of code lists and the total number of code lists. Stores that do not
+// implement Paginator are asked for every code list, which are then sorted and sliced in memory.
+func GetCodeListsPage(ctx context.Context, store DataStore, filterBy string, page Page) (*models.CodeListResults, int, error) {
+	if p, ok := store.(Paginator); ok {
+		return p.GetCodeListsPage(ctx, filterBy, page)
+	}
+
+	codeLists, err := store.GetCodeLists(ctx, filterBy)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalCount := len(codeLists.Items)
+	SortCodeLists(codeLists.Items, page.Order)
+	start, end := page.Bounds(totalCount)
+	return &models.CodeListResults{Items: codeLists.Items[start:end]}, totalCount, nil
+}
+
+// GetEditionsPage returns a page of the editions of a code list and the total number of editions. Stores
+// that do not implement Paginator are asked for every edition, which are then sorted and sliced in memory.
+func GetEditionsPage(ctx context.Context, store DataStore, codeListID string, page Page) (*models.Editions, int, error) {
+	if p, ok := store.(Paginator); ok {
+		return p.GetEditionsPage(ctx, codeListID, page)
+	}
+
+	editions, err := store.GetEditions(ctx, codeListID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalCount := len(editions.Items)
+	SortEditions(editions.Items, page.Order)
+	start, end := page.Bounds(totalCount)
+	return &models.Editions{Items: editions.Items[start:end]}, totalCount, nil
+}
+
+// GetCodesPage returns a page of the codes of an edition and the total number of codes. Stores that do not
+// implement Paginator are asked for the number of codes, then for every code (unless the page is empty),
+// which are then sorted and sliced in memory.
+func GetCodesPage(ctx context.Context, store DataStore, codeListID, editionID string, page Page) (*models.CodeResults, int, error) {
+	if p, ok := store.(Paginator); ok {
+		return p.GetCodesPage(ctx, codeListID, editionID, page)
+	}
+
+	totalCount, err := store.CountCodes(ctx, codeListID, editionID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	codes := &models.CodeResults{Items: []models.Code{}}
+	if page.Limit > 0 && totalCount > 0 {
+		codes, err = store.GetCodes(ctx, codeListID, editionID)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	SortCodes(codes.Items, page.Order)
+	start, end := page.Bounds(len(codes.Items))
+	return &models.CodeResults{Items: codes.Items[start:end]}, int(totalCount), nil
+}
+
+// GetCodeDatasetsPage returns a page of the datasets related to a code and the total number of datasets.
+// Stores that do not implement Paginator are asked for every dataset, which are then sorted and sliced in memory.
+func GetCodeDatasetsPage(ctx context.Context, store DataStore, codeListID, edition string, code string, page Page) (*models.Datasets, int, error) {
+	if p, ok := store.(Paginator); ok {
+		return p.GetCodeDatasetsPage(ctx, codeListID, edition, code, page)
+	}
+
+	datasets, err := store.GetCodeDatasets(ctx, codeListID, edition, code)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalCount := len(datasets.Items)
+	SortDatasets(datasets.Items, page.Order)
+	start, end := page.Bounds(totalCount)
+	return &models.Datasets{Items: datasets.Items[start:end]}, totalCount, nil
+}
+
+// SortCodeLists sorts code lists in place. Code lists do not have a label, so OrderByLabel sorts them by ID.
+func SortCodeLists(items []models.CodeList, order Order) {
+	if order == OrderDefault {
+		return
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+}
+
+// SortEditions sorts editions in place
+func SortEditions(items []models.Edition, order Order) {
+	switch order {
+	case OrderByID:
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].ID < items[j].ID
+		})
+	case OrderByLabel:
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].Label < items[j].Label
+		})
+	}
+}
+
+// SortCodes sorts codes in place. OrderByID sorts them by code value.
+func SortCodes(items []models.Code, order Order) {
+	switch order {
+	case OrderByID:
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].Code < items[j].Code
+		})
+	case OrderByLabel:
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].Label < items[j].Label
+		})
+	}
+}
+
+// SortDatasets sorts datasets in place. OrderByLabel sorts them by dimension label.
+func SortDatasets(items []models.Dataset, order Order) {
+	switch order {
+	case OrderByID:
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].ID < items[j].ID
+		})
+	case OrderByLabel:
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].DimensionLabel < items[j].DimensionLabel
+		})
+	}
+}
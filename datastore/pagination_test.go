@@ -0,0 +1,128 @@
+package datastore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-graph/v2/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// paginatedStore is a store implementing both datastore.DataStore and datastore.Paginator
+type paginatedStore struct {
+	*storetest.DataStoreMock
+	*storetest.PaginatorMock
+}
+
+var testCodes = []models.Code{
+	{Code: "c", Label: "one"},
+	{Code: "a", Label: "two"},
+	{Code: "b", Label: "three"},
+}
+
+func TestGetCodesPage(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a store that does not implement Paginator", t, func() {
+		store := &storetest.DataStoreMock{
+			CountCodesFunc: func(ctx context.Context, codeListID string, edition string) (int64, error) {
+				return int64(len(testCodes)), nil
+			},
+			GetCodesFunc: func(ctx context.Context, codeListID string, editionID string) (*models.CodeResults, error) {
+				items := make([]models.Code, len(testCodes))
+				copy(items, testCodes)
+				return &models.CodeResults{Items: items}, nil
+			},
+		}
+
+		Convey("GetCodesPage slices every code in store order", func() {
+			codes, totalCount, err := datastore.GetCodesPage(ctx, store, "codelist", "edition", datastore.Page{Offset: 1, Limit: 5})
+			So(err, ShouldBeNil)
+			So(totalCount, ShouldEqual, 3)
+			So(codes.Items, ShouldResemble, testCodes[1:])
+		})
+
+		Convey("GetCodesPage sorts every code before slicing when an order is requested", func() {
+			codes, _, err := datastore.GetCodesPage(ctx, store, "codelist", "edition", datastore.Page{Offset: 0, Limit: 2, Order: datastore.OrderByID})
+			So(err, ShouldBeNil)
+			So(codes.Items, ShouldResemble, []models.Code{testCodes[1], testCodes[2]})
+
+			codes, _, err = datastore.GetCodesPage(ctx, store, "codelist", "edition", datastore.Page{Offset: 0, Limit: 2, Order: datastore.OrderByLabel})
+			So(err, ShouldBeNil)
+			So(codes.Items, ShouldResemble, []models.Code{testCodes[0], testCodes[2]})
+		})
+
+		Convey("GetCodesPage only counts codes when the limit is zero", func() {
+			codes, totalCount, err := datastore.GetCodesPage(ctx, store, "codelist", "edition", datastore.Page{Limit: 0})
+			So(err, ShouldBeNil)
+			So(totalCount, ShouldEqual, 3)
+			So(codes.Items, ShouldBeEmpty)
+			So(store.GetCodesCalls(), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a store that implements Paginator", t, func() {
+		store := paginatedStore{
+			DataStoreMock: &storetest.DataStoreMock{},
+			PaginatorMock: &storetest.PaginatorMock{
+				GetCodesPageFunc: func(ctx context.Context, codeListID string, editionID string, page datastore.Page) (*models.CodeResults, int, error) {
+					return &models.CodeResults{Items: testCodes[:1]}, 3, nil
+				},
+			},
+		}
+
+		Convey("GetCodesPage only asks the store for the requested page", func() {
+			page := datastore.Page{Offset: 0, Limit: 1, Order: datastore.OrderByLabel}
+			codes, totalCount, err := datastore.GetCodesPage(ctx, store, "codelist", "edition", page)
+			So(err, ShouldBeNil)
+			So(totalCount, ShouldEqual, 3)
+			So(codes.Items, ShouldResemble, testCodes[:1])
+
+			So(store.GetCodesPageCalls(), ShouldHaveLength, 1)
+			So(store.GetCodesPageCalls()[0].Page, ShouldResemble, page)
+			So(store.CountCodesCalls(), ShouldBeEmpty)
+			So(store.GetCodesCalls(), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestGetCodeListsPage(t *testing.T) {
+	Convey("Given a store that does not implement Paginator", t, func() {
+		store := &storetest.DataStoreMock{
+			GetCodeListsFunc: func(ctx context.Context, filterBy string) (*models.CodeListResults, error) {
+				return &models.CodeListResults{Items: []models.CodeList{{ID: "b"}, {ID: "c"}, {ID: "a"}}}, nil
+			},
+		}
+
+		Convey("GetCodeListsPage sorts and slices every code list", func() {
+			codeLists, totalCount, err := datastore.GetCodeListsPage(context.Background(), store, "", datastore.Page{Offset: 1, Limit: 1, Order: datastore.OrderByID})
+			So(err, ShouldBeNil)
+			So(totalCount, ShouldEqual, 3)
+			So(codeLists.Items, ShouldResemble, []models.CodeList{{ID: "b"}})
+		})
+
+		Convey("GetCodeListsPage returns no code list when the offset is greater than the total", func() {
+			codeLists, totalCount, err := datastore.GetCodeListsPage(context.Background(), store, "", datastore.Page{Offset: 4, Limit: 1})
+			So(err, ShouldBeNil)
+			So(totalCount, ShouldEqual, 3)
+			So(codeLists.Items, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestPageBounds(t *testing.T) {
+	Convey("The bounds of a page are within the items", t, func() {
+		start, end := datastore.Page{Offset: 1, Limit: 2}.Bounds(5)
+		So([]int{start, end}, ShouldResemble, []int{1, 3})
+
+		start, end = datastore.Page{Offset: 4, Limit: 2}.Bounds(5)
+		So([]int{start, end}, ShouldResemble, []int{4, 5})
+	})
+
+	Convey("A page starting after the last item is empty", t, func() {
+		start, end := datastore.Page{Offset: 7, Limit: 2}.Bounds(5)
+		So([]int{start, end}, ShouldResemble, []int{5, 5})
+	})
+}
@@ -0,0 +1,82 @@
+package datastore
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ONSdigital/dp-graph/v2/models"
+)
+
+//go:generate moq -out datastoretest/searcher.go -pkg storetest . Searcher
+
+// Searcher is implemented by stores able to search the codes of an edition
+type Searcher interface {
+	SearchCodes(ctx context.Context, codeListID, editionID, query string, page Page) (*models.CodeResults, int, error)
+}
+
+// SearchCodes returns a page of the codes of an edition matching the query, and the total number of
+// matching codes. See MatchCodes for how codes are matched and ordered. Stores that do not implement
+// Searcher are asked for every code, which are then matched, sorted and sliced in memory.
+func SearchCodes(ctx context.Context, store DataStore, codeListID, editionID, query string, page Page) (*models.CodeResults, int, error) {
+	if s, ok := store.(Searcher); ok {
+		return s.SearchCodes(ctx, codeListID, editionID, query, page)
+	}
+
+	totalCount, err := store.CountCodes(ctx, codeListID, editionID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	codes := &models.CodeResults{}
+	if totalCount > 0 {
+		codes, err = store.GetCodes(ctx, codeListID, editionID)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	matches := MatchCodes(codes.Items, query)
+	SortCodes(matches, page.Order)
+	start, end := page.Bounds(len(matches))
+	return &models.CodeResults{Items: matches[start:end]}, len(matches), nil
+}
+
+// MatchCodes returns the codes whose code value or label contains the query, ignoring case. Codes whose
+// code value or label starts with the query come first, otherwise the order of the codes is kept.
+func MatchCodes(items []models.Code, query string) []models.Code {
+	query = strings.ToLower(query)
+
+	prefixed := []models.Code{}
+	contained := []models.Code{}
+	for _, code := range items {
+		switch matchCode(code.Code, code.Label, query) {
+		case matchPrefix:
+			prefixed = append(prefixed, code)
+		case matchSubstring:
+			contained = append(contained, code)
+		}
+	}
+	return append(prefixed, contained...)
+}
+
+// match describes how well a code matches a search query
+type match int
+
+const (
+	matchNone match = iota
+	matchSubstring
+	matchPrefix
+)
+
+// matchCode returns how the code value or label matches the query, which must be lower case
+func matchCode(code, label, query string) match {
+	code, label = strings.ToLower(code), strings.ToLower(label)
+	switch {
+	case strings.HasPrefix(code, query) || strings.HasPrefix(label, query):
+		return matchPrefix
+	case strings.Contains(code, query) || strings.Contains(label, query):
+		return matchSubstring
+	default:
+		return matchNone
+	}
+}
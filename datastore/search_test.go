@@ -0,0 +1,39 @@
+package datastore_test
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-graph/v2/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMatchCodes(t *testing.T) {
+	Convey("Given some codes", t, func() {
+		codes := []models.Code{
+			{Code: "E09000001", Label: "City of London"},
+			{Code: "E08000003", Label: "Manchester"},
+			{Code: "W06000015", Label: "Cardiff"},
+			{Code: "E06000023", Label: "Bristol, City of"},
+		}
+
+		Convey("Codes whose value or label contains the query are matched, ignoring case", func() {
+			So(datastore.MatchCodes(codes, "CHESTER"), ShouldResemble, []models.Code{codes[1]})
+			So(datastore.MatchCodes(codes, "w06"), ShouldResemble, []models.Code{codes[2]})
+		})
+
+		Convey("Codes starting with the query come before codes only containing it", func() {
+			So(datastore.MatchCodes(codes, "city"), ShouldResemble, []models.Code{codes[0], codes[3]})
+			So(datastore.MatchCodes(codes, "of"), ShouldResemble, []models.Code{codes[0], codes[3]})
+			So(datastore.MatchCodes(codes, "bristol"), ShouldResemble, []models.Code{codes[3]})
+			So(datastore.MatchCodes(codes, "000"), ShouldResemble, []models.Code{codes[0], codes[1], codes[2], codes[3]})
+			So(datastore.MatchCodes(codes, "london"), ShouldResemble, []models.Code{codes[0]})
+			So(datastore.MatchCodes(codes, "e0"), ShouldResemble, []models.Code{codes[0], codes[1], codes[3]})
+			So(datastore.MatchCodes(codes, "ff"), ShouldResemble, []models.Code{codes[2]})
+		})
+
+		Convey("No codes are matched by a query that is not found", func() {
+			So(datastore.MatchCodes(codes, "leeds"), ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,14 @@
+package datastore
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-graph/v2/models"
+)
+
+//go:generate moq -out datastoretest/suggester.go -pkg storetest . Suggester
+
+// Suggester is implemented by stores holding their own prefix index of the code labels of each edition
+type Suggester interface {
+	SuggestCodes(ctx context.Context, codeListID, editionID, prefix string, limit int) ([]models.Code, error)
+}
@@ -0,0 +1,164 @@
+package suggest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/pkg/errors"
+)
+
+// buildTimeout bounds how long an index is built for, as it is not built with the context of any caller
+const buildTimeout = time.Minute
+
+// Indexes holds a Trie for up to size editions that suggestions were requested for, the least recently used
+// being dropped first. Each Trie is built from the codes of the edition, and rebuilt once it is older than the
+// TTL or has been invalidated.
+type Indexes struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mutex   sync.Mutex
+	indexes map[string]*index
+}
+
+// index is the Trie of an edition, which is being built until ready is closed
+type index struct {
+	codeListID string
+	ready      chan struct{}
+	trie       *Trie
+	err        error
+	built      time.Time
+	used       time.Time
+}
+
+// NewIndexes returns an empty set of up to size indexes, each kept for the provided ttl. At least one index is
+// kept, as suggestions are made from an index.
+func NewIndexes(size int, ttl time.Duration) *Indexes {
+	if size < 1 {
+		size = 1
+	}
+	return &Indexes{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		indexes: map[string]*index{},
+	}
+}
+
+// Suggest returns up to limit codes of an edition whose label starts with the prefix, as described by
+// Trie.Suggest. If the store, or a store it decorates, implements datastore.Suggester, it is used instead.
+func (i *Indexes) Suggest(ctx context.Context, store datastore.DataStore, codeListID, editionID, prefix string, limit int) ([]models.Code, error) {
+	for _, layer := range datastore.Layers(store) {
+		if suggester, ok := layer.(datastore.Suggester); ok {
+			return suggester.SuggestCodes(ctx, codeListID, editionID, prefix, limit)
+		}
+	}
+
+	trie, err := i.get(ctx, store, codeListID, editionID)
+	if err != nil {
+		return nil, err
+	}
+	return trie.Suggest(prefix, limit), nil
+}
+
+// Invalidate drops the indexes of the editions of a code list, so that they are rebuilt when next used,
+// and returns how many were dropped
+func (i *Indexes) Invalidate(codeListID string) int {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	dropped := 0
+	for key, idx := range i.indexes {
+		if idx.codeListID == codeListID {
+			delete(i.indexes, key)
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// get returns the index of an edition, building it if it does not exist or has expired. The index is built
+// in the background with a context of its own, so that it is not cancelled along with the caller which
+// requested it first. Concurrent callers wait for the index being built, until their context is done.
+func (i *Indexes) get(ctx context.Context, store datastore.DataStore, codeListID, editionID string) (*Trie, error) {
+	key := codeListID + "\x00" + editionID
+
+	i.mutex.Lock()
+	idx, ok := i.indexes[key]
+	if !ok || i.expired(idx) {
+		i.evict()
+		idx = &index{codeListID: codeListID, ready: make(chan struct{})}
+		i.indexes[key] = idx
+		go i.build(key, idx, store, editionID)
+	}
+	idx.used = i.now()
+	i.mutex.Unlock()
+
+	select {
+	case <-idx.ready:
+		return idx.trie, idx.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// build builds the index of an edition, and drops it if building it failed
+func (i *Indexes) build(key string, idx *index, store datastore.DataStore, editionID string) {
+	defer func() {
+		if r := recover(); r != nil {
+			idx.trie, idx.err = nil, errors.Errorf("building the index of edition %s panicked: %v", editionID, r)
+		}
+
+		i.mutex.Lock()
+		idx.built = i.now()
+		if idx.err != nil && i.indexes[key] == idx {
+			delete(i.indexes, key)
+		}
+		i.mutex.Unlock()
+		close(idx.ready)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), buildTimeout)
+	defer cancel()
+
+	codes, err := datastore.GetAllCodes(ctx, store, idx.codeListID, editionID)
+	if err != nil {
+		idx.err = err
+		return
+	}
+	idx.trie = NewTrie(codes)
+}
+
+// evict drops the expired indexes, then the least recently used ones until there is room for a new index. The
+// caller must hold the lock.
+func (i *Indexes) evict() {
+	for key, idx := range i.indexes {
+		if i.expired(idx) {
+			delete(i.indexes, key)
+		}
+	}
+
+	for len(i.indexes) >= i.size {
+		var oldest string
+		for key, idx := range i.indexes {
+			if oldest == "" || idx.used.Before(i.indexes[oldest].used) {
+				oldest = key
+			}
+		}
+		delete(i.indexes, oldest)
+	}
+}
+
+// expired returns whether an index is older than the TTL. The caller must hold the lock.
+func (i *Indexes) expired(idx *index) bool {
+	select {
+	case <-idx.ready:
+		return !i.now().Before(idx.built.Add(i.ttl))
+	default:
+		return false
+	}
+}
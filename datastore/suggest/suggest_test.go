@@ -0,0 +1,139 @@
+package suggest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	storetest "github.com/ONSdigital/dp-code-list-api/datastore/datastoretest"
+	"github.com/ONSdigital/dp-graph/v2/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var errStore = errors.New("store error")
+
+func TestIndexes(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a store that does not hold its own prefix index", t, func() {
+		mockStore := &storetest.DataStoreMock{
+			CountCodesFunc: func(ctx context.Context, codeListID string, editionID string) (int64, error) {
+				if editionID == "unknown" {
+					return 0, errStore
+				}
+				return int64(len(testCodes)), nil
+			},
+			GetCodesFunc: func(ctx context.Context, codeListID string, editionID string) (*models.CodeResults, error) {
+				return &models.CodeResults{Items: testCodes}, nil
+			},
+		}
+		indexes := NewIndexes(10, time.Minute)
+		now := time.Now()
+		indexes.now = func() time.Time { return now }
+
+		Convey("Codes are suggested from an index built from the codes of the edition", func() {
+			codes, err := indexes.Suggest(ctx, mockStore, "local-authority", "2019", "leeds", 10)
+			So(err, ShouldBeNil)
+			So(codes, ShouldResemble, []models.Code{testCodes[2]})
+		})
+
+		Convey("The index of an edition is only built once", func() {
+			indexes.Suggest(ctx, mockStore, "local-authority", "2019", "leeds", 10)
+			indexes.Suggest(ctx, mockStore, "local-authority", "2019", "york", 10)
+			So(mockStore.GetCodesCalls(), ShouldHaveLength, 1)
+		})
+
+		Convey("The index of an edition is rebuilt once it has expired", func() {
+			indexes.Suggest(ctx, mockStore, "local-authority", "2019", "leeds", 10)
+			now = now.Add(time.Minute)
+			indexes.Suggest(ctx, mockStore, "local-authority", "2019", "leeds", 10)
+			So(mockStore.GetCodesCalls(), ShouldHaveLength, 2)
+		})
+
+		Convey("The index of an edition is rebuilt once its code list has been invalidated", func() {
+			indexes.Suggest(ctx, mockStore, "local-authority", "2019", "leeds", 10)
+			So(indexes.Invalidate("local-authority"), ShouldEqual, 1)
+			indexes.Suggest(ctx, mockStore, "local-authority", "2019", "leeds", 10)
+			So(mockStore.GetCodesCalls(), ShouldHaveLength, 2)
+		})
+
+		Convey("Errors returned when building an index are returned, and not kept", func() {
+			_, err := indexes.Suggest(ctx, mockStore, "local-authority", "unknown", "leeds", 10)
+			So(err, ShouldEqual, errStore)
+			So(indexes.indexes, ShouldBeEmpty)
+		})
+
+		Convey("The least recently used index is dropped once the size is reached", func() {
+			indexes := NewIndexes(2, time.Minute)
+			clock := now
+			indexes.now = func() time.Time { clock = clock.Add(time.Second); return clock }
+
+			indexes.Suggest(ctx, mockStore, "local-authority", "2018", "leeds", 10)
+			indexes.Suggest(ctx, mockStore, "local-authority", "2019", "leeds", 10)
+			indexes.Suggest(ctx, mockStore, "local-authority", "2018", "leeds", 10)
+			indexes.Suggest(ctx, mockStore, "local-authority", "2020", "leeds", 10)
+
+			So(indexes.indexes, ShouldHaveLength, 2)
+			So(indexes.indexes, ShouldContainKey, "local-authority\x002018")
+			So(indexes.indexes, ShouldContainKey, "local-authority\x002020")
+		})
+	})
+
+	Convey("Given a store whose codes are blocked until released", t, func() {
+		release := make(chan struct{})
+		mockStore := &storetest.DataStoreMock{
+			CountCodesFunc: func(ctx context.Context, codeListID string, editionID string) (int64, error) {
+				return int64(len(testCodes)), nil
+			},
+			GetCodesFunc: func(ctx context.Context, codeListID string, editionID string) (*models.CodeResults, error) {
+				<-release
+				return &models.CodeResults{Items: testCodes}, ctx.Err()
+			},
+		}
+		indexes := NewIndexes(10, time.Minute)
+
+		Convey("When the caller building an index gives up, the index is still built for the next callers", func() {
+			cancelled, cancel := context.WithCancel(ctx)
+			cancel()
+			_, err := indexes.Suggest(cancelled, mockStore, "local-authority", "2019", "leeds", 10)
+			So(err, ShouldEqual, context.Canceled)
+
+			close(release)
+			codes, err := indexes.Suggest(ctx, mockStore, "local-authority", "2019", "leeds", 10)
+			So(err, ShouldBeNil)
+			So(codes, ShouldResemble, []models.Code{testCodes[2]})
+			So(mockStore.GetCodesCalls(), ShouldHaveLength, 1)
+		})
+	})
+
+	Convey("Given a store decorating a store that holds its own prefix index", t, func() {
+		mockSuggester := &storetest.SuggesterMock{
+			SuggestCodesFunc: func(ctx context.Context, codeListID string, editionID string, prefix string, limit int) ([]models.Code, error) {
+				return []models.Code{testCodes[0]}, nil
+			},
+		}
+		store := wrapper{&storetest.DataStoreMock{}, struct {
+			*storetest.DataStoreMock
+			*storetest.SuggesterMock
+		}{&storetest.DataStoreMock{}, mockSuggester}}
+
+		Convey("Codes are suggested by the decorated store", func() {
+			codes, err := NewIndexes(10, time.Minute).Suggest(ctx, store, "local-authority", "2019", "york", 10)
+			So(err, ShouldBeNil)
+			So(codes, ShouldResemble, []models.Code{testCodes[0]})
+			So(mockSuggester.SuggestCodesCalls(), ShouldHaveLength, 1)
+		})
+	})
+}
+
+// wrapper is a store decorating another store
+type wrapper struct {
+	*storetest.DataStoreMock
+	store datastore.DataStore
+}
+
+func (w wrapper) Unwrap() datastore.DataStore {
+	return w.store
+}
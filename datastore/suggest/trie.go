@@ -0,0 +1,130 @@
+package suggest
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ONSdigital/dp-graph/v2/models"
+)
+
+// Trie is a prefix index over the labels of the codes of an edition. Each label is indexed as a whole,
+// and from the start of each of its words, so that e.g. "york" suggests "North Yorkshire".
+type Trie struct {
+	root  *node
+	codes []models.Code
+}
+
+// node is a node of a Trie, whose children are sorted by rune
+type node struct {
+	r        rune
+	children []*node
+	entries  []entry
+}
+
+// entry is a code whose label, or one of the words of its label, ends at a node
+type entry struct {
+	code  int
+	whole bool
+}
+
+// NewTrie returns a Trie indexing the labels of the provided codes
+func NewTrie(codes []models.Code) *Trie {
+	t := &Trie{root: &node{}, codes: codes}
+	for i, code := range codes {
+		label := []rune(strings.ToLower(code.Label))
+		for start := range label {
+			if start == 0 || isWordStart(label, start) {
+				t.root.insert(label[start:], entry{code: i, whole: start == 0})
+			}
+		}
+	}
+	return t
+}
+
+// Len returns the number of indexed codes
+func (t *Trie) Len() int {
+	return len(t.codes)
+}
+
+// Suggest returns up to limit codes whose label, or one of the words of their label, starts with the prefix,
+// ignoring case. Codes whose label starts with the prefix come first, in label order.
+func (t *Trie) Suggest(prefix string, limit int) []models.Code {
+	suggestions := []models.Code{}
+	if limit <= 0 {
+		return suggestions
+	}
+
+	n := t.root
+	for _, r := range strings.ToLower(prefix) {
+		if n = n.child(r); n == nil {
+			return suggestions
+		}
+	}
+
+	// Entries are collected in label order, as children are sorted, and the walk stops once limit codes are
+	// collected: codes whose label starts with the prefix first, then codes with a word starting with it
+	seen := map[int]bool{}
+	collect := func(whole bool) {
+		n.walk(func(e entry) bool {
+			if e.whole == whole && !seen[e.code] {
+				seen[e.code] = true
+				suggestions = append(suggestions, t.codes[e.code])
+			}
+			return len(suggestions) < limit
+		})
+	}
+	collect(true)
+	if len(suggestions) < limit {
+		collect(false)
+	}
+	return suggestions
+}
+
+func (n *node) insert(key []rune, e entry) {
+	for _, r := range key {
+		child := n.child(r)
+		if child == nil {
+			child = &node{r: r}
+			i := sort.Search(len(n.children), func(i int) bool { return n.children[i].r >= r })
+			n.children = append(n.children, nil)
+			copy(n.children[i+1:], n.children[i:])
+			n.children[i] = child
+		}
+		n = child
+	}
+	n.entries = append(n.entries, e)
+}
+
+func (n *node) child(r rune) *node {
+	i := sort.Search(len(n.children), func(i int) bool { return n.children[i].r >= r })
+	if i < len(n.children) && n.children[i].r == r {
+		return n.children[i]
+	}
+	return nil
+}
+
+// walk calls fn for every entry of the node and its descendants, in key order, until fn returns false. It
+// returns whether the walk completed.
+func (n *node) walk(fn func(entry) bool) bool {
+	for _, e := range n.entries {
+		if !fn(e) {
+			return false
+		}
+	}
+	for _, child := range n.children {
+		if !child.walk(fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// isWordStart returns whether a word starts at index i of the label
+func isWordStart(label []rune, i int) bool {
+	return !isWordRune(label[i-1]) && isWordRune(label[i])
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,56 @@
+package suggest
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-graph/v2/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var testCodes = []models.Code{
+	{Code: "E06000014", Label: "York"},
+	{Code: "E10000023", Label: "North Yorkshire"},
+	{Code: "E08000035", Label: "Leeds"},
+	{Code: "E06000046", Label: "Isle of Wight"},
+	{Code: "E07000164", Label: "Hambleton"},
+	{Code: "E06000001", Label: "Hartlepool"},
+}
+
+func TestTrie(t *testing.T) {
+	Convey("Given a trie indexing the labels of some codes", t, func() {
+		trie := NewTrie(testCodes)
+		So(trie.Len(), ShouldEqual, len(testCodes))
+
+		Convey("Codes whose label starts with the prefix are suggested in label order, ignoring case", func() {
+			So(trie.Suggest("HA", 10), ShouldResemble, []models.Code{testCodes[4], testCodes[5]})
+		})
+
+		Convey("Codes with a word starting with the prefix are suggested after codes whose label starts with it", func() {
+			So(trie.Suggest("york", 10), ShouldResemble, []models.Code{testCodes[0], testCodes[1]})
+			So(trie.Suggest("wig", 10), ShouldResemble, []models.Code{testCodes[3]})
+		})
+
+		Convey("Prefixes within a word do not suggest anything", func() {
+			So(trie.Suggest("ork", 10), ShouldBeEmpty)
+		})
+
+		Convey("No more than limit codes are suggested", func() {
+			So(trie.Suggest("h", 1), ShouldResemble, []models.Code{testCodes[4]})
+			So(trie.Suggest("h", 0), ShouldBeEmpty)
+		})
+
+		Convey("The trie is no longer walked once limit codes are collected", func() {
+			visited := 0
+			trie.root.walk(func(e entry) bool {
+				visited++
+				return visited < 2
+			})
+			So(visited, ShouldEqual, 2)
+		})
+
+		Convey("A code is only suggested once", func() {
+			trie := NewTrie([]models.Code{{Code: "1", Label: "Hart and Hartlepool"}})
+			So(trie.Suggest("hart", 10), ShouldHaveLength, 1)
+		})
+	})
+}
@@ -0,0 +1,94 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//go:generate moq -out datastoretest/writer.go -pkg storetest . Writer
+
+// ErrAlreadyExists is returned when creating a resource that already exists
+var ErrAlreadyExists = errors.New("resource already exists in the code list store")
+
+// InvalidError is returned for a change that would leave the store inconsistent, e.g. a code whose parent is
+// not in its edition
+type InvalidError struct {
+	msg string
+}
+
+// Invalid returns an InvalidError with a formatted message
+func Invalid(format string, args ...interface{}) error {
+	return &InvalidError{msg: fmt.Sprintf(format, args...)}
+}
+
+func (e *InvalidError) Error() string {
+	return e.msg
+}
+
+// EditionUpdate is an edition to create or replace, along with its publication metadata
+type EditionUpdate struct {
+	ID          string
+	Label       string
+	ReleaseDate *time.Time
+	State       EditionState
+	Supersedes  string
+}
+
+// CodeUpdate is a code to create or replace. Parent is the ID of the parent code within the edition, for code
+// lists that are hierarchies.
+type CodeUpdate struct {
+	Code   string
+	Label  string
+	Parent string
+}
+
+// Writer is implemented by stores whose code lists can be changed through the API
+type Writer interface {
+	// CreateCodeList creates a code list without editions. ErrAlreadyExists is returned if it already exists.
+	CreateCodeList(ctx context.Context, metadata CodeListMetadata) error
+	// PutEdition creates an edition without codes, or replaces the label and metadata of an existing edition
+	// while keeping its codes, and reports whether the edition was created.
+	PutEdition(ctx context.Context, codeListID string, edition EditionUpdate) (bool, error)
+	// PutCode creates or replaces a code of an edition, and reports whether the code was created
+	PutCode(ctx context.Context, codeListID, editionID string, code CodeUpdate) (bool, error)
+	// DeleteCode deletes a code of an edition. Codes with children cannot be deleted.
+	DeleteCode(ctx context.Context, codeListID, editionID, codeID string) error
+}
+
+// CreateCodeList creates a code list without editions. ErrNotSupported is returned for stores that do not
+// implement Writer.
+func CreateCodeList(ctx context.Context, store DataStore, metadata CodeListMetadata) error {
+	if w, ok := store.(Writer); ok {
+		return w.CreateCodeList(ctx, metadata)
+	}
+	return ErrNotSupported
+}
+
+// PutEdition creates or replaces an edition, and reports whether it was created. ErrNotSupported is returned
+// for stores that do not implement Writer.
+func PutEdition(ctx context.Context, store DataStore, codeListID string, edition EditionUpdate) (bool, error) {
+	if w, ok := store.(Writer); ok {
+		return w.PutEdition(ctx, codeListID, edition)
+	}
+	return false, ErrNotSupported
+}
+
+// PutCode creates or replaces a code, and reports whether it was created. ErrNotSupported is returned for
+// stores that do not implement Writer.
+func PutCode(ctx context.Context, store DataStore, codeListID, editionID string, code CodeUpdate) (bool, error) {
+	if w, ok := store.(Writer); ok {
+		return w.PutCode(ctx, codeListID, editionID, code)
+	}
+	return false, ErrNotSupported
+}
+
+// DeleteCode deletes a code. ErrNotSupported is returned for stores that do not implement Writer.
+func DeleteCode(ctx context.Context, store DataStore, codeListID, editionID, codeID string) error {
+	if w, ok := store.(Writer); ok {
+		return w.DeleteCode(ctx, codeListID, editionID, codeID)
+	}
+	return ErrNotSupported
+}
@@ -0,0 +1,63 @@
+// Package diff compares the codes of two editions of a code list, e.g. to find the geographies added,
+// removed or renamed between two releases of a geography code list.
+package diff
+
+import (
+	"sort"
+
+	"github.com/ONSdigital/dp-graph/v2/models"
+)
+
+// ChangeType is the way a code changed between two editions
+type ChangeType string
+
+// Possible types of change of a code
+const (
+	// Added is a code of the new edition which is not in the previous edition
+	Added ChangeType = "added"
+	// Removed is a code of the previous edition which is not in the new edition
+	Removed ChangeType = "removed"
+	// Relabelled is a code of both editions, with a different label in each of them
+	Relabelled ChangeType = "relabelled"
+)
+
+// Change is a code that changed between two editions. Label is the label of the code in the new edition,
+// or in the previous edition for a removed code, and PreviousLabel the label of a relabelled code in the
+// previous edition.
+type Change struct {
+	Type          ChangeType
+	Code          string
+	Label         string
+	PreviousLabel string
+}
+
+// Codes returns the changes from the codes of a previous edition to the codes of a new edition, sorted by
+// code value so that the changes can be paginated
+func Codes(previous, codes []models.Code) []Change {
+	previousLabels := make(map[string]string, len(previous))
+	for _, code := range previous {
+		previousLabels[code.Code] = code.Label
+	}
+
+	changes := []Change{}
+	current := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		current[code.Code] = true
+		previousLabel, ok := previousLabels[code.Code]
+		if !ok {
+			changes = append(changes, Change{Type: Added, Code: code.Code, Label: code.Label})
+		} else if previousLabel != code.Label {
+			changes = append(changes, Change{Type: Relabelled, Code: code.Code, Label: code.Label, PreviousLabel: previousLabel})
+		}
+	}
+	for _, code := range previous {
+		if !current[code.Code] {
+			changes = append(changes, Change{Type: Removed, Code: code.Code, Label: code.Label})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Code < changes[j].Code
+	})
+	return changes
+}
@@ -0,0 +1,40 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-graph/v2/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCodes(t *testing.T) {
+	Convey("Given the codes of two editions", t, func() {
+		previous := []models.Code{
+			{Code: "E07000004", Label: "Aylesbury Vale"},
+			{Code: "E06000001", Label: "Hartlepool"},
+			{Code: "E06000002", Label: "Middlesbrough"},
+		}
+		codes := []models.Code{
+			{Code: "E06000060", Label: "Buckinghamshire"},
+			{Code: "E06000002", Label: "Middlesbrough"},
+			{Code: "E06000001", Label: "Hartlepool, Borough of"},
+		}
+
+		Convey("The added, removed and relabelled codes are returned by code value", func() {
+			So(Codes(previous, codes), ShouldResemble, []Change{
+				{Type: Relabelled, Code: "E06000001", Label: "Hartlepool, Borough of", PreviousLabel: "Hartlepool"},
+				{Type: Added, Code: "E06000060", Label: "Buckinghamshire"},
+				{Type: Removed, Code: "E07000004", Label: "Aylesbury Vale"},
+			})
+		})
+
+		Convey("The codes of an edition compared with themselves have no changes", func() {
+			So(Codes(codes, codes), ShouldBeEmpty)
+		})
+
+		Convey("Every code is added from, or removed to, an edition without codes", func() {
+			So(Codes(nil, codes), ShouldHaveLength, 3)
+			So(Codes(codes, nil), ShouldHaveLength, 3)
+		})
+	})
+}
@@ -0,0 +1,196 @@
+package events
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/diff"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	"github.com/ONSdigital/dp-graph/v2/models"
+	"github.com/ONSdigital/log.go/log"
+)
+
+// snapshot is the state of every edition of a store, keyed by code list ID then edition ID
+type snapshot map[string]map[string]editionSnapshot
+
+// editionSnapshot is whether an edition is published, and its codes
+type editionSnapshot struct {
+	published bool
+	codes     []models.Code
+}
+
+// Detector publishes the changes made to the code lists of a store outside the API, e.g. by editing the
+// files of the file store, which it finds by comparing the code lists of the store at regular intervals. As
+// every code of every edition is read at each interval, and the changes made through the API would be found
+// as well, it is meant for small stores that cannot be changed through the API, such as the file store.
+type Detector struct {
+	store     datastore.DataStore
+	publisher Publisher
+	previous  snapshot
+
+	closeOnce sync.Once
+	closing   chan struct{}
+	closed    chan struct{}
+}
+
+// NewDetector takes a first snapshot of the code lists of a store, without publishing any events, then
+// starts looking for changes every interval
+func NewDetector(ctx context.Context, store datastore.DataStore, publisher Publisher, interval time.Duration) (*Detector, error) {
+	d := &Detector{
+		store:     store,
+		publisher: publisher,
+		closing:   make(chan struct{}),
+		closed:    make(chan struct{}),
+	}
+
+	previous, err := d.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d.previous = previous
+
+	go d.watch(ctx, interval)
+	return d, nil
+}
+
+// Close stops looking for changes. It may be called again, e.g. after the context of a first call was done
+// before the detector stopped.
+func (d *Detector) Close(ctx context.Context) error {
+	d.closeOnce.Do(func() { close(d.closing) })
+	select {
+	case <-d.closed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// watch looks for changes until the detector is closed
+func (d *Detector) watch(ctx context.Context, interval time.Duration) {
+	defer close(d.closed)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			d.detect(ctx)
+		case <-d.closing:
+			return
+		}
+	}
+}
+
+// detect publishes the changes made since the previous snapshot. If any event cannot be published, the
+// previous snapshot is kept so that the changes are published again with the next snapshot.
+func (d *Detector) detect(ctx context.Context) {
+	current, err := d.snapshot(ctx)
+	if err != nil {
+		log.Event(ctx, "failed to take a snapshot of the code lists to detect changes", log.ERROR, log.Error(err))
+		return
+	}
+
+	for _, event := range changes(d.previous, current) {
+		if err := d.publisher.Publish(ctx, event); err != nil {
+			log.Event(ctx, "failed to publish detected change", log.ERROR, log.Error(err), log.Data{"event": event})
+			return
+		}
+		log.Event(ctx, "detected change published", log.INFO, log.Data{"type": event.Type, "codelist_id": event.CodeListID, "edition": event.EditionID})
+	}
+	d.previous = current
+}
+
+// snapshot returns the state and codes of every edition of the store
+func (d *Detector) snapshot(ctx context.Context) (snapshot, error) {
+	codeLists, err := d.store.GetCodeLists(ctx, "")
+	if err == driver.ErrNotFound {
+		return snapshot{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s := make(snapshot, len(codeLists.Items))
+	for _, codeList := range codeLists.Items {
+		editions, err := d.store.GetEditions(ctx, codeList.ID)
+		if err != nil && err != driver.ErrNotFound {
+			return nil, err
+		}
+		metadata, err := datastore.GetEditionsMetadata(ctx, d.store, codeList.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		s[codeList.ID] = map[string]editionSnapshot{}
+		if editions == nil {
+			continue
+		}
+		for _, edition := range editions.Items {
+			// editions without codes are not found by some stores
+			codes, err := d.store.GetCodes(ctx, codeList.ID, edition.ID)
+			if err != nil && err != driver.ErrNotFound {
+				return nil, err
+			}
+			e := editionSnapshot{published: datastore.Published(metadata[edition.ID].State, metadata[edition.ID].ReleaseDate)}
+			if codes != nil {
+				e.codes = codes.Items
+			}
+			s[codeList.ID][edition.ID] = e
+		}
+	}
+	return s, nil
+}
+
+// changes returns the events of the changes from a previous snapshot to the current one, by code list then
+// edition ID. Code lists, editions and codes removed from the store are not published.
+func changes(previous, current snapshot) []Event {
+	events := []Event{}
+	for _, codeListID := range sortedKeys(current) {
+		previousEditions, ok := previous[codeListID]
+		if !ok {
+			events = append(events, New(CodeListCreated, codeListID, ""))
+		}
+
+		editions := current[codeListID]
+		editionIDs := make([]string, 0, len(editions))
+		for id := range editions {
+			editionIDs = append(editionIDs, id)
+		}
+		sort.Strings(editionIDs)
+
+		for _, editionID := range editionIDs {
+			edition := editions[editionID]
+			previousEdition, existed := previousEditions[editionID]
+			if edition.published && (!existed || !previousEdition.published) {
+				events = append(events, New(EditionPublished, codeListID, editionID))
+			}
+			if !existed {
+				continue
+			}
+
+			codeChanges := diff.Codes(previousEdition.codes, edition.codes)
+			if len(codeChanges) == 0 {
+				continue
+			}
+			codes := make([]string, 0, len(codeChanges))
+			for _, change := range codeChanges {
+				codes = append(codes, change.Code)
+			}
+			events = append(events, New(CodesChanged, codeListID, editionID, codes...))
+		}
+	}
+	return events
+}
+
+func sortedKeys(s snapshot) []string {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,80 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/datastore/memory"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// eventTypes returns the type, code list and edition of each event, ignoring their time
+func eventTypes(events []Event) [][3]string {
+	types := [][3]string{}
+	for _, event := range events {
+		types = append(types, [3]string{string(event.Type), event.CodeListID, event.EditionID})
+	}
+	return types
+}
+
+func TestDetector(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a change detector watching a store", t, func() {
+		store := memory.New(&memory.Fixtures{
+			CodeLists: []memory.CodeList{{
+				ID: "local-authority",
+				Editions: []memory.Edition{
+					{ID: "2019", State: datastore.EditionPublished, Codes: []memory.Code{{Code: "E06000001", Label: "Hartlepool"}}},
+					{ID: "2020", State: datastore.EditionDraft, Codes: []memory.Code{{Code: "E06000001", Label: "Hartlepool"}}},
+				},
+			}},
+		})
+		publisher := NewMemory()
+
+		detector, err := NewDetector(ctx, store, publisher, time.Hour)
+		So(err, ShouldBeNil)
+		defer detector.Close(ctx)
+
+		Convey("No events are published for the code lists found at first", func() {
+			detector.detect(ctx)
+			So(publisher.Events(), ShouldBeEmpty)
+		})
+
+		Convey("The changes made to the store are published", func() {
+			So(store.CreateCodeList(ctx, datastore.CodeListMetadata{ID: "country"}), ShouldBeNil)
+			releaseDate := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+			_, err := store.PutEdition(ctx, "country", datastore.EditionUpdate{ID: "2021", ReleaseDate: &releaseDate})
+			So(err, ShouldBeNil)
+			_, err = store.PutEdition(ctx, "local-authority", datastore.EditionUpdate{ID: "2020", State: datastore.EditionPublished})
+			So(err, ShouldBeNil)
+			_, err = store.PutCode(ctx, "local-authority", "2019", datastore.CodeUpdate{Code: "E06000002", Label: "Middlesbrough"})
+			So(err, ShouldBeNil)
+
+			detector.detect(ctx)
+			So(eventTypes(publisher.Events()), ShouldResemble, [][3]string{
+				{string(CodeListCreated), "country", ""},
+				{string(EditionPublished), "country", "2021"},
+				{string(CodesChanged), "local-authority", "2019"},
+				{string(EditionPublished), "local-authority", "2020"},
+			})
+			So(publisher.Events()[2].Codes, ShouldResemble, []string{"E06000002"})
+
+			Convey("And they are only published once", func() {
+				detector.detect(ctx)
+				So(publisher.Events(), ShouldHaveLength, 4)
+			})
+		})
+
+		Convey("The detector may be closed again after a first call timed out", func() {
+			cancelled, cancel := context.WithCancel(ctx)
+			cancel()
+			detector.Close(cancelled)
+
+			So(detector.Close(ctx), ShouldBeNil)
+			So(detector.Close(ctx), ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,55 @@
+// Package events publishes the changes made to code lists, so that downstream services (e.g. the dataset
+// API, filter API or search indexer) can act on them.
+package events
+
+import (
+	"context"
+	"time"
+)
+
+// Type is the type of change of an event
+type Type string
+
+// Possible types of event
+const (
+	// CodeListCreated is published when a code list is created
+	CodeListCreated Type = "code-list-created"
+	// EditionPublished is published when an edition becomes published
+	EditionPublished Type = "edition-published"
+	// CodesChanged is published when codes of an edition are created, changed or deleted
+	CodesChanged Type = "codes-changed"
+)
+
+// Valid reports whether an event type is one of the types published
+func (t Type) Valid() bool {
+	return t == CodeListCreated || t == EditionPublished || t == CodesChanged
+}
+
+// Event is a change of a code list. EditionID is empty for the changes of the code list itself. Codes holds
+// the codes changed by a CodesChanged event, and is empty if any code of the edition may have changed.
+type Event struct {
+	Type       Type      `json:"type"`
+	CodeListID string    `json:"code_list_id"`
+	EditionID  string    `json:"edition,omitempty"`
+	Codes      []string  `json:"codes,omitempty"`
+	Time       time.Time `json:"time"`
+}
+
+// New returns an event of a change made now
+func New(eventType Type, codeListID, editionID string, codes ...string) Event {
+	return Event{
+		Type:       eventType,
+		CodeListID: codeListID,
+		EditionID:  editionID,
+		Codes:      codes,
+		Time:       time.Now().UTC(),
+	}
+}
+
+// Publisher publishes events to downstream services
+type Publisher interface {
+	// Publish publishes an event, after the change it describes was made
+	Publish(ctx context.Context, event Event) error
+	// Close releases the resources held by the publisher, once no more events are published
+	Close(ctx context.Context) error
+}
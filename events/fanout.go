@@ -0,0 +1,33 @@
+package events
+
+import (
+	"context"
+)
+
+// Type check to ensure that Fanout implements the Publisher interface
+var _ Publisher = (Fanout)(nil)
+
+// Fanout is a Publisher publishing each event to several publishers, e.g. to Kafka and to webhooks
+type Fanout []Publisher
+
+// Publish publishes an event to every publisher, and returns the first error if any of them failed
+func (f Fanout) Publish(ctx context.Context, event Event) error {
+	var first error
+	for _, publisher := range f {
+		if err := publisher.Publish(ctx, event); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// Close closes every publisher, and returns the first error if any of them failed
+func (f Fanout) Close(ctx context.Context) error {
+	var first error
+	for _, publisher := range f {
+		if err := publisher.Close(ctx); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,31 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// failing is a Publisher failing to publish any event
+type failing struct{}
+
+func (failing) Publish(ctx context.Context, event Event) error {
+	return errors.New("broker unavailable")
+}
+func (failing) Close(ctx context.Context) error { return nil }
+
+func TestFanout(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("An event is published to every publisher, even if one of them fails", t, func() {
+		first, second := NewMemory(), NewMemory()
+		fanout := Fanout{first, failing{}, second}
+
+		So(fanout.Publish(ctx, New(CodeListCreated, "local-authority", "")), ShouldNotBeNil)
+		So(first.Events(), ShouldHaveLength, 1)
+		So(second.Events(), ShouldHaveLength, 1)
+		So(fanout.Close(ctx), ShouldBeNil)
+	})
+}
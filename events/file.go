@@ -0,0 +1,49 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Type check to ensure that File implements the Publisher interface
+var _ Publisher = (*File)(nil)
+
+// File is a Publisher appending the published events to a file, one JSON object per line, as a stand-in
+// for a message broker in local environments and tests
+type File struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+// NewFile returns a File publisher appending events to the file at path, which is created if needed
+func NewFile(path string) (*File, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open events file %q", path)
+	}
+	return &File{file: f}, nil
+}
+
+// Publish appends an event to the file
+func (f *File) Publish(ctx context.Context, event Event) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	_, err = f.file.Write(append(b, '\n'))
+	return err
+}
+
+// Close closes the file
+func (f *File) Close(ctx context.Context) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.file.Close()
+}
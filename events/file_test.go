@@ -0,0 +1,49 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFile(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a file publisher", t, func() {
+		dir, err := ioutil.TempDir("", "events")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "events.jsonl")
+
+		publisher, err := NewFile(path)
+		So(err, ShouldBeNil)
+
+		Convey("Published events are appended to the file, one per line", func() {
+			So(publisher.Publish(ctx, New(CodeListCreated, "local-authority", "")), ShouldBeNil)
+			So(publisher.Publish(ctx, New(CodesChanged, "local-authority", "2019", "E06000001")), ShouldBeNil)
+			So(publisher.Close(ctx), ShouldBeNil)
+
+			content, err := ioutil.ReadFile(path)
+			So(err, ShouldBeNil)
+			lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+			So(lines, ShouldHaveLength, 2)
+
+			var event Event
+			So(json.Unmarshal([]byte(lines[1]), &event), ShouldBeNil)
+			So(event.Type, ShouldEqual, CodesChanged)
+			So(event.EditionID, ShouldEqual, "2019")
+			So(event.Codes, ShouldResemble, []string{"E06000001"})
+		})
+	})
+
+	Convey("NewFile fails for a file in a directory that does not exist", t, func() {
+		_, err := NewFile("/non/existent/dir/events.jsonl")
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,107 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	dphttp "github.com/ONSdigital/dp-net/http"
+	"github.com/pkg/errors"
+)
+
+// contentTypeKafkaJSON is the content type of the JSON records produced through a Kafka REST proxy
+const contentTypeKafkaJSON = "application/vnd.kafka.json.v2+json"
+
+// Type check to ensure that Kafka implements the Publisher interface
+var _ Publisher = (*Kafka)(nil)
+
+// Kafka is a Publisher producing events to a Kafka topic, through the v2 API of a Kafka REST proxy. Events
+// are keyed by code list ID, so that the events of a code list are consumed in the order they were published.
+type Kafka struct {
+	client   dphttp.Clienter
+	topicURL string
+}
+
+// record is a message produced to a topic
+type record struct {
+	Key   string `json:"key"`
+	Value Event  `json:"value"`
+}
+
+// produceResponse is the response of the proxy, with the partition and offset of each produced record
+type produceResponse struct {
+	Offsets []struct {
+		Partition int    `json:"partition"`
+		Offset    int64  `json:"offset"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+// NewKafka returns a Kafka publisher producing events to the topic through the REST proxy at proxyURL
+func NewKafka(client dphttp.Clienter, proxyURL, topic string) *Kafka {
+	return &Kafka{
+		client:   client,
+		topicURL: fmt.Sprintf("%s/topics/%s", strings.TrimSuffix(proxyURL, "/"), url.PathEscape(topic)),
+	}
+}
+
+// Publish produces an event to the topic
+func (k *Kafka) Publish(ctx context.Context, event Event) error {
+	b, err := json.Marshal(struct {
+		Records []record `json:"records"`
+	}{[]record{{Key: event.CodeListID, Value: event}}})
+	if err != nil {
+		return err
+	}
+
+	resp, err := k.client.Post(ctx, k.topicURL, contentTypeKafkaJSON, bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "failed to produce event")
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response of kafka rest proxy")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("kafka rest proxy returned %d: %s", resp.StatusCode, body)
+	}
+
+	var produced produceResponse
+	if err := json.Unmarshal(body, &produced); err != nil {
+		return errors.Wrap(err, "invalid response of kafka rest proxy")
+	}
+	for _, offset := range produced.Offsets {
+		if offset.Error != "" {
+			return errors.Errorf("failed to produce event: %s", offset.Error)
+		}
+	}
+	return nil
+}
+
+// Checker reports a warning if the topic cannot be found through the proxy, as events cannot be published
+// but code lists can still be served
+func (k *Kafka) Checker(ctx context.Context, state *healthcheck.CheckState) error {
+	resp, err := k.client.Get(ctx, k.topicURL)
+	if err != nil {
+		return state.Update(healthcheck.StatusWarning, fmt.Sprintf("kafka rest proxy is unavailable: %s", err), 0)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return state.Update(healthcheck.StatusWarning, "events topic not found through kafka rest proxy", resp.StatusCode)
+	}
+	return state.Update(healthcheck.StatusOK, "events topic found through kafka rest proxy", resp.StatusCode)
+}
+
+// Close is a no-op, as events are produced synchronously
+func (k *Kafka) Close(ctx context.Context) error {
+	return nil
+}
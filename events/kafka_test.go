@@ -0,0 +1,76 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	dphttp "github.com/ONSdigital/dp-net/http"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestKafka(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a Kafka publisher producing through a REST proxy", t, func() {
+		var contentType string
+		var produced struct {
+			Records []struct {
+				Key   string `json:"key"`
+				Value Event  `json:"value"`
+			} `json:"records"`
+		}
+		response := `{"offsets":[{"partition":0,"offset":1}]}`
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/topics/code-list-changed" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if r.Method == http.MethodPost {
+				contentType = r.Header.Get("Content-Type")
+				body, _ := ioutil.ReadAll(r.Body)
+				json.Unmarshal(body, &produced)
+			}
+			w.Write([]byte(response))
+		}))
+		defer proxy.Close()
+
+		client := dphttp.NewClient()
+		client.SetMaxRetries(0)
+
+		Convey("Events are produced to the topic, keyed by code list ID", func() {
+			publisher := NewKafka(client, proxy.URL+"/", "code-list-changed")
+			So(publisher.Publish(ctx, New(EditionPublished, "local-authority", "2020")), ShouldBeNil)
+
+			So(contentType, ShouldEqual, contentTypeKafkaJSON)
+			So(produced.Records, ShouldHaveLength, 1)
+			So(produced.Records[0].Key, ShouldEqual, "local-authority")
+			So(produced.Records[0].Value.Type, ShouldEqual, EditionPublished)
+			So(produced.Records[0].Value.EditionID, ShouldEqual, "2020")
+		})
+
+		Convey("An error is returned if the proxy fails to produce an event", func() {
+			response = `{"offsets":[{"error_code":50002,"error":"Kafka error"}]}`
+			publisher := NewKafka(client, proxy.URL, "code-list-changed")
+			So(publisher.Publish(ctx, New(CodeListCreated, "local-authority", "")), ShouldNotBeNil)
+		})
+
+		Convey("An error is returned for an unknown topic", func() {
+			publisher := NewKafka(client, proxy.URL, "unknown")
+			So(publisher.Publish(ctx, New(CodeListCreated, "local-authority", "")), ShouldNotBeNil)
+		})
+
+		Convey("The checker reports a warning for an unknown topic", func() {
+			state := healthcheck.NewCheckState("kafka")
+			So(NewKafka(client, proxy.URL, "code-list-changed").Checker(ctx, state), ShouldBeNil)
+			So(state.Status(), ShouldEqual, healthcheck.StatusOK)
+
+			So(NewKafka(client, proxy.URL, "unknown").Checker(ctx, state), ShouldBeNil)
+			So(state.Status(), ShouldEqual, healthcheck.StatusWarning)
+		})
+	})
+}
@@ -0,0 +1,40 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// Type check to ensure that Memory implements the Publisher interface
+var _ Publisher = (*Memory)(nil)
+
+// Memory is a Publisher holding the published events in memory, as a stand-in for tests
+type Memory struct {
+	mutex  sync.Mutex
+	events []Event
+}
+
+// NewMemory returns a Memory publisher without events
+func NewMemory() *Memory {
+	return &Memory{events: []Event{}}
+}
+
+// Publish adds an event to the published events
+func (m *Memory) Publish(ctx context.Context, event Event) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.events = append(m.events, event)
+	return nil
+}
+
+// Events returns the published events, in the order they were published
+func (m *Memory) Events() []Event {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]Event{}, m.events...)
+}
+
+// Close is a no-op, as the publisher does not hold any external resources
+func (m *Memory) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,251 @@
+// Package match finds the codes whose labels best match free-text labels, e.g. labels found in a
+// spreadsheet that do not exactly match the labels of a code list.
+package match
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ONSdigital/dp-graph/v2/models"
+)
+
+// containmentWeight scales the score of a label containing every token of the input, so that such a label
+// scores less than a label equal to the input
+const containmentWeight = 0.9
+
+// prefixLength is the number of leading runes of the tokens that codes are indexed by. Only the codes sharing
+// a token prefix with the input are scored, so that matching a label does not compute the edit distance to
+// every code of a large edition.
+const prefixLength = 3
+
+// tokenSimilarity is the minimum similarity of two tokens for them to be considered the same token,
+// so that a typo in a word still matches it
+const tokenSimilarity = 0.8
+
+// Candidate is a code matching a label, with a score between 0 (no similarity) and 1 (same label)
+type Candidate struct {
+	Code  models.Code
+	Score float64
+}
+
+// Matcher matches labels against the codes of an edition
+type Matcher struct {
+	codes    []code
+	prefixes map[string][]int
+	values   map[string]int
+}
+
+// code is a code along with its normalised label and label tokens
+type code struct {
+	models.Code
+	value  string
+	label  string
+	tokens []string
+}
+
+// NewMatcher returns a Matcher for the provided codes
+func NewMatcher(codes []models.Code) *Matcher {
+	m := &Matcher{
+		codes:    make([]code, 0, len(codes)),
+		prefixes: map[string][]int{},
+		values:   make(map[string]int, len(codes)),
+	}
+	for i, c := range codes {
+		label := Normalise(c.Label)
+		m.codes = append(m.codes, code{
+			Code:   c,
+			value:  strings.ToLower(c.Code),
+			label:  label,
+			tokens: strings.Fields(label),
+		})
+		m.values[m.codes[i].value] = i
+
+		indexed := map[string]bool{}
+		for _, token := range m.codes[i].tokens {
+			if p := prefix(token); !indexed[p] {
+				indexed[p] = true
+				m.prefixes[p] = append(m.prefixes[p], i)
+			}
+		}
+	}
+	return m
+}
+
+// Match returns up to limit codes whose score for the label is at least threshold, from the best to the
+// worst match, then by label. An input equal to a code value, ignoring case, is scored 1. Only the codes with
+// a token starting like a token of the input are scored.
+func (m *Matcher) Match(label string, limit int, threshold float64) []Candidate {
+	input := Normalise(label)
+	tokens := strings.Fields(input)
+	value := strings.ToLower(strings.TrimSpace(label))
+
+	candidates := []Candidate{}
+	for _, i := range m.lookup(value, tokens) {
+		c := m.codes[i]
+		s := 1.0
+		if value != c.value {
+			s = score(input, tokens, c.label, c.tokens)
+		}
+		if s >= threshold && s > 0 {
+			candidates = append(candidates, Candidate{Code: c.Code, Score: s})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Code.Label < candidates[j].Code.Label
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates
+}
+
+// lookup returns the codes whose value is the input, or which share a token prefix with the input, in code order
+func (m *Matcher) lookup(value string, tokens []string) []int {
+	found := map[int]bool{}
+	if i, ok := m.values[value]; ok {
+		found[i] = true
+	}
+	for _, token := range tokens {
+		for _, i := range m.prefixes[prefix(token)] {
+			found[i] = true
+		}
+	}
+
+	indexes := make([]int, 0, len(found))
+	for i := range found {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
+
+// prefix returns the leading runes of a token that codes are indexed by
+func prefix(token string) string {
+	runes := []rune(token)
+	if len(runes) > prefixLength {
+		runes = runes[:prefixLength]
+	}
+	return string(runes)
+}
+
+// Score returns the similarity of two labels, between 0 and 1
+func Score(a, b string) float64 {
+	a, b = Normalise(a), Normalise(b)
+	return score(a, strings.Fields(a), b, strings.Fields(b))
+}
+
+// score returns the best of the similarity of the normalised labels, the similarity of their sorted tokens,
+// the overlap of their tokens, and how much of the input is contained in the label
+func score(input string, inputTokens []string, label string, labelTokens []string) float64 {
+	if input == label {
+		return 1
+	}
+	if len(inputTokens) == 0 || len(labelTokens) == 0 {
+		return 0
+	}
+
+	best := similarity(input, label)
+
+	sortedInput := strings.Join(sortedCopy(inputTokens), " ")
+	sortedLabel := strings.Join(sortedCopy(labelTokens), " ")
+	if s := similarity(sortedInput, sortedLabel); s > best {
+		best = s
+	}
+
+	common := commonTokens(inputTokens, labelTokens)
+	if dice := 2 * common / float64(len(inputTokens)+len(labelTokens)); dice > best {
+		best = dice
+	}
+	if contained := containmentWeight * common / float64(len(inputTokens)); contained > best {
+		best = contained
+	}
+	return best
+}
+
+// Normalise lower cases a label, replaces "&" by "and", and replaces punctuation by spaces, so that labels
+// only differing by case or punctuation are equal
+func Normalise(label string) string {
+	label = strings.ToLower(label)
+	label = strings.Replace(label, "&", " and ", -1)
+	label = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, label)
+	return strings.Join(strings.Fields(label), " ")
+}
+
+// commonTokens returns the number of input tokens found in the label tokens, allowing for typos. A token
+// found with a typo only counts as the similarity of the two tokens.
+func commonTokens(input, label []string) float64 {
+	used := make([]bool, len(label))
+	common := 0.0
+	for _, a := range input {
+		for i, b := range label {
+			if s := similarity(a, b); !used[i] && s >= tokenSimilarity {
+				used[i] = true
+				common += s
+				break
+			}
+		}
+	}
+	return common
+}
+
+// similarity returns 1 minus the edit distance of two strings relative to the length of the longest one
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// levenshtein returns the minimum number of single rune insertions, deletions and substitutions
+// turning a into b
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+func sortedCopy(tokens []string) []string {
+	sorted := append([]string{}, tokens...)
+	sort.Strings(sorted)
+	return sorted
+}
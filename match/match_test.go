@@ -0,0 +1,77 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-graph/v2/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var testCodes = []models.Code{
+	{Code: "E06000023", Label: "Bristol, City of"},
+	{Code: "E06000022", Label: "Bath and North East Somerset"},
+	{Code: "E08000035", Label: "Leeds"},
+	{Code: "E06000014", Label: "York"},
+	{Code: "E10000023", Label: "North Yorkshire"},
+}
+
+func TestNormalise(t *testing.T) {
+	Convey("Labels are lower cased, with punctuation removed and ampersands spelled out", t, func() {
+		So(Normalise("  Bristol,  City of "), ShouldEqual, "bristol city of")
+		So(Normalise("Bath & North-East Somerset"), ShouldEqual, "bath and north east somerset")
+	})
+}
+
+func TestScore(t *testing.T) {
+	Convey("Labels equal once normalised score 1", t, func() {
+		So(Score("BRISTOL city-of", "Bristol, City of"), ShouldEqual, 1)
+	})
+
+	Convey("Labels contained in another label score highly", t, func() {
+		So(Score("Bristol", "Bristol, City of"), ShouldEqual, containmentWeight)
+	})
+
+	Convey("Labels with reordered words score highly", t, func() {
+		So(Score("City of Bristol", "Bristol, City of"), ShouldEqual, 1)
+	})
+
+	Convey("Labels with typos score highly", t, func() {
+		So(Score("Leds", "Leeds"), ShouldEqual, 0.8)
+		So(Score("Bristl City", "Bristol, City of"), ShouldBeGreaterThan, 0.5)
+	})
+
+	Convey("Unrelated labels score low", t, func() {
+		So(Score("Leeds", "York"), ShouldBeLessThan, 0.5)
+		So(Score("", "York"), ShouldEqual, 0)
+	})
+}
+
+func TestMatcher(t *testing.T) {
+	Convey("Given a matcher for some codes", t, func() {
+		m := NewMatcher(testCodes)
+
+		Convey("Candidates are returned from the best to the worst match", func() {
+			candidates := m.Match("Yorks", 10, 0.3)
+			So(candidates, ShouldHaveLength, 2)
+			So(candidates[0], ShouldResemble, Candidate{Code: testCodes[3], Score: 0.8})
+			So(candidates[1].Code, ShouldResemble, testCodes[4])
+		})
+
+		Convey("No more than limit candidates are returned", func() {
+			So(m.Match("Yorks", 1, 0.3), ShouldHaveLength, 1)
+		})
+
+		Convey("Candidates scoring less than the threshold are not returned", func() {
+			So(m.Match("Manchester", 10, 0.5), ShouldBeEmpty)
+		})
+
+		Convey("Codes without a token starting like a token of the input are not scored", func() {
+			So(m.Match("Ork", 10, 0), ShouldBeEmpty)
+			So(m.lookup("north", []string{"north"}), ShouldResemble, []int{1, 4})
+		})
+
+		Convey("An input equal to a code value is matched to that code", func() {
+			So(m.Match("e08000035", 10, 0.9), ShouldResemble, []Candidate{{Code: testCodes[2], Score: 1}})
+		})
+	})
+}
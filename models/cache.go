@@ -0,0 +1,9 @@
+package models
+
+// CacheStats represents the state of the code list store cache
+type CacheStats struct {
+	Entries   int    `json:"entries"`
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+}
@@ -0,0 +1,7 @@
+package models
+
+// CoalesceStats represents how many code list store calls were made, and how many of them were coalesced
+type CoalesceStats struct {
+	Calls     uint64 `json:"calls"`
+	Coalesced uint64 `json:"coalesced"`
+}
@@ -28,6 +28,8 @@ type CodeLinks struct {
 	CodeList *Link `json:"code_list"`
 	Datasets *Link `json:"datasets"`
 	Self     *Link `json:"self"`
+	Parent   *Link `json:"parent,omitempty"`
+	Children *Link `json:"children,omitempty"`
 }
 
 // UpdateLinks updates the links for a code list
@@ -48,6 +50,26 @@ func (c *Code) UpdateLinks(host, codeListID, edition string) error {
 	return nil
 }
 
+// UpdateHierarchyLinks adds the children link of a code, and the link to its parent if parentID is not empty,
+// for code lists that are hierarchies
+func (c *Code) UpdateHierarchyLinks(host, codeListID, edition, parentID string) error {
+
+	if c.ID == "" {
+		return errors.New("unable to create links - code ID not provided")
+	}
+
+	if c.Links == nil {
+		c.Links = &CodeLinks{}
+	}
+
+	c.Links.Children = CreateLink("", fmt.Sprintf(childrenURI, codeListID, edition, c.ID), host)
+	if parentID != "" {
+		c.Links.Parent = CreateLink(parentID, fmt.Sprintf(codeURI, codeListID, edition, parentID), host)
+	}
+
+	return nil
+}
+
 // NewCode creates a new Code struct from a Code DB model
 func NewCode(dbCode *dbmodels.Code) *Code {
 	if dbCode == nil {
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CodeEditions contains the code list editions containing a code, which can be paginated
+type CodeEditions struct {
+	Items      []CodeEdition `json:"items"`
+	Count      int           `json:"count"`
+	Offset     int           `json:"offset"`
+	Limit      int           `json:"limit"`
+	TotalCount int           `json:"total_count"`
+}
+
+// CodeEdition represents a code as found in a code list edition
+type CodeEdition struct {
+	ID         string            `json:"code"`
+	Label      string            `json:"label"`
+	CodeListID string            `json:"code_list"`
+	Edition    string            `json:"edition"`
+	Links      *CodeEditionLinks `json:"links"`
+}
+
+// CodeEditionLinks contains links for a code found in a code list edition
+type CodeEditionLinks struct {
+	Self     *Link `json:"self"`
+	CodeList *Link `json:"code_list"`
+	Edition  *Link `json:"edition"`
+}
+
+// UpdateLinks updates the links for a code found in a code list edition
+func (c *CodeEdition) UpdateLinks(host string) error {
+
+	if c.ID == "" || c.CodeListID == "" || c.Edition == "" {
+		return errors.New("unable to create links - code, codelist id or edition not provided")
+	}
+
+	if c.Links == nil {
+		c.Links = &CodeEditionLinks{}
+	}
+
+	c.Links.Self = CreateLink(c.ID, fmt.Sprintf(codeURI, c.CodeListID, c.Edition, c.ID), host)
+	c.Links.CodeList = CreateLink(c.CodeListID, fmt.Sprintf(codeListURI, c.CodeListID), host)
+	c.Links.Edition = CreateLink(c.Edition, fmt.Sprintf(editionURI, c.CodeListID, c.Edition), host)
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/models"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCodeEditionUpdateLinks(t *testing.T) {
+
+	Convey("Given a code found in a code list edition", t, func() {
+		codeEdition := models.CodeEdition{ID: "testCode", CodeListID: "testCodeList", Edition: "testEdition"}
+
+		Convey("UpdateLinks creates the links to the code, its code list and its edition", func() {
+			So(codeEdition.UpdateLinks("http://localhost:22400/v1"), ShouldBeNil)
+			So(codeEdition.Links, ShouldResemble, &models.CodeEditionLinks{
+				Self:     &models.Link{ID: "testCode", Href: "http://localhost:22400/v1/code-lists/testCodeList/editions/testEdition/codes/testCode"},
+				CodeList: &models.Link{ID: "testCodeList", Href: "http://localhost:22400/v1/code-lists/testCodeList"},
+				Edition:  &models.Link{ID: "testEdition", Href: "http://localhost:22400/v1/code-lists/testCodeList/editions/testEdition"},
+			})
+		})
+	})
+
+	Convey("UpdateLinks returns an error when the code list is not provided", t, func() {
+		codeEdition := models.CodeEdition{ID: "testCode", Edition: "testEdition"}
+		So(codeEdition.UpdateLinks("http://localhost:22400"), ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
+)
+
+// CodeLookup contains the codes found for the requested IDs, in the requested order, and the IDs not found
+type CodeLookup struct {
+	Items    []Code   `json:"items"`
+	Count    int      `json:"count"`
+	NotFound []string `json:"not_found"`
+}
+
+// NewCodeLookup creates a CodeLookup struct from the requested IDs and the DB Code models found for them
+func NewCodeLookup(codeIDs []string, dbCodes []dbmodels.Code) *CodeLookup {
+	lookup := &CodeLookup{
+		Items:    []Code{},
+		NotFound: []string{},
+	}
+	for _, dbCode := range dbCodes {
+		lookup.Items = append(lookup.Items, *NewCode(&dbCode))
+	}
+	lookup.NotFound = append(lookup.NotFound, notFound(codeIDs, dbCodes)...)
+	lookup.Count = len(lookup.Items)
+	return lookup
+}
+
+// notFound returns the requested IDs that none of the DB Code models found has, in the requested order
+func notFound(codeIDs []string, dbCodes []dbmodels.Code) []string {
+	found := make(map[string]bool, len(dbCodes))
+	for _, dbCode := range dbCodes {
+		found[dbCode.Code] = true
+	}
+	var ids []string
+	for _, id := range codeIDs {
+		if !found[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -106,3 +106,47 @@ func TestCodeUpdateLinks(t *testing.T) {
 		})
 	})
 }
+
+func TestCodeUpdateHierarchyLinks(t *testing.T) {
+
+	Convey("Given a Code struct without ID", t, func() {
+		code := models.Code{}
+
+		Convey("UpdateHierarchyLinks fails with the expected error", func() {
+			err := code.UpdateHierarchyLinks("host1", "codelist1", "edition1", "parentCode")
+			So(err, ShouldResemble, errors.New("unable to create links - code ID not provided"))
+		})
+	})
+
+	Convey("Given a valid Code struct", t, func() {
+
+		code := models.Code{
+			ID:    "testCode",
+			Label: "testLabel",
+		}
+
+		Convey("UpdateHierarchyLinks generates the children and parent links", func() {
+			err := code.UpdateHierarchyLinks("host1", "codelist1", "edition1", "parentCode")
+			So(err, ShouldBeNil)
+			So(code.Links, ShouldResemble, &models.CodeLinks{
+				Children: &models.Link{
+					Href: "host1/code-lists/codelist1/editions/edition1/codes/testCode/children",
+				},
+				Parent: &models.Link{
+					ID:   "parentCode",
+					Href: "host1/code-lists/codelist1/editions/edition1/codes/parentCode",
+				},
+			})
+		})
+
+		Convey("UpdateHierarchyLinks only generates the children link for a code without parent", func() {
+			err := code.UpdateHierarchyLinks("host1", "codelist1", "edition1", "")
+			So(err, ShouldBeNil)
+			So(code.Links, ShouldResemble, &models.CodeLinks{
+				Children: &models.Link{
+					Href: "host1/code-lists/codelist1/editions/edition1/codes/testCode/children",
+				},
+			})
+		})
+	})
+}
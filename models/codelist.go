@@ -16,16 +16,29 @@ type CodeListResults struct {
 	TotalCount int        `json:"total_count"`
 }
 
-// CodeList containing links to all possible codes
+// CodeList describes a code list, with links to all possible codes
 type CodeList struct {
-	ID    string        `json:"-"`
-	Links *CodeListLink `json:"links,omitempty"`
+	ID          string        `json:"id"`
+	Label       string        `json:"label,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Type        string        `json:"type,omitempty"`
+	Licence     string        `json:"licence,omitempty"`
+	Contact     *Contact      `json:"contact,omitempty"`
+	Links       *CodeListLink `json:"links,omitempty"`
+}
+
+// Contact contains the contact details of the team responsible for a code list
+type Contact struct {
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Telephone string `json:"telephone,omitempty"`
 }
 
 // CodeListLink contains links for a code list resource
 type CodeListLink struct {
-	Self     *Link `json:"self,omitempty"`
-	Editions *Link `json:"editions,omitempty"`
+	Self          *Link `json:"self,omitempty"`
+	Editions      *Link `json:"editions,omitempty"`
+	LatestEdition *Link `json:"latest_edition,omitempty"`
 }
 
 // UpdateLinks updates the links from
@@ -41,6 +54,7 @@ func (c *CodeList) UpdateLinks(url string) error {
 
 	c.Links.Self = CreateLink(c.ID, fmt.Sprintf(codeListURI, c.ID), url)
 	c.Links.Editions = CreateLink("", fmt.Sprintf(editionsURI, c.ID), url)
+	c.Links.LatestEdition = CreateLink("", fmt.Sprintf(latestEditionURI, c.ID), url)
 
 	return nil
 }
@@ -86,6 +86,9 @@ func TestCodeListUpdateLinks(t *testing.T) {
 				Editions: &models.Link{
 					Href: "testURL/code-lists/codelistID/editions",
 				},
+				LatestEdition: &models.Link{
+					Href: "testURL/code-lists/codelistID/editions/latest",
+				},
 			},
 		}
 
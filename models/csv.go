@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/csv"
+	"strconv"
+	"time"
+)
+
+// Headers of the CSV representations of the resources which can be downloaded as CSV
+var (
+	codesCSVHeader       = []string{"code", "label"}
+	editionsCSVHeader    = []string{"edition", "label", "release_date", "state", "code_count"}
+	codeListsCSVHeader   = []string{"id", "label", "type"}
+	codeChangesCSVHeader = []string{"change", "code", "label", "previous_label"}
+)
+
+// WriteCSV writes the codes as rows of a CSV document with a code,label header
+func (c *CodeResults) WriteCSV(w *csv.Writer) error {
+	if err := w.Write(codesCSVHeader); err != nil {
+		return err
+	}
+	for _, code := range c.Items {
+		if err := w.Write([]string{code.ID, code.Label}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteCSV writes the editions as rows of a CSV document with an edition,label,release_date,state,code_count
+// header
+func (e *Editions) WriteCSV(w *csv.Writer) error {
+	if err := w.Write(editionsCSVHeader); err != nil {
+		return err
+	}
+	for _, edition := range e.Items {
+		releaseDate := ""
+		if edition.ReleaseDate != nil {
+			releaseDate = edition.ReleaseDate.Format(time.RFC3339)
+		}
+		codeCount := ""
+		if edition.CodeCount > 0 {
+			codeCount = strconv.Itoa(edition.CodeCount)
+		}
+		if err := w.Write([]string{edition.ID, edition.Label, releaseDate, edition.State, codeCount}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteCSV writes the code lists as rows of a CSV document with an id,label,type header
+func (c *CodeListResults) WriteCSV(w *csv.Writer) error {
+	if err := w.Write(codeListsCSVHeader); err != nil {
+		return err
+	}
+	for _, codeList := range c.Items {
+		if err := w.Write([]string{codeList.ID, codeList.Label, codeList.Type}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteCSV writes the changed codes as rows of a CSV document with a change,code,label,previous_label header
+func (c *CodeChanges) WriteCSV(w *csv.Writer) error {
+	if err := w.Write(codeChangesCSVHeader); err != nil {
+		return err
+	}
+	for _, change := range c.Items {
+		if err := w.Write([]string{change.Type, change.ID, change.Label, change.PreviousLabel}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
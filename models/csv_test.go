@@ -0,0 +1,56 @@
+package models_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/models"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// csvWriter is a resource which can be written as CSV
+type csvWriter interface {
+	WriteCSV(w *csv.Writer) error
+}
+
+// writeCSV returns the CSV document written by a resource
+func writeCSV(resource csvWriter) string {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	So(resource.WriteCSV(w), ShouldBeNil)
+	w.Flush()
+	So(w.Error(), ShouldBeNil)
+	return b.String()
+}
+
+func TestWriteCSV(t *testing.T) {
+
+	Convey("Codes are written with a code,label header", t, func() {
+		codes := &models.CodeResults{Items: []models.Code{{ID: "E06000001", Label: "Hartlepool"}}}
+		So(writeCSV(codes), ShouldEqual, "code,label\nE06000001,Hartlepool\n")
+	})
+
+	Convey("Editions are written with their release date, state and number of codes", t, func() {
+		releaseDate := time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC)
+		editions := &models.Editions{Items: []models.Edition{
+			{ID: "2019", Label: "Local authorities, 2019", ReleaseDate: &releaseDate, State: "published", CodeCount: 2},
+			{ID: "2020", Label: "Local authorities, 2020"},
+		}}
+		So(writeCSV(editions), ShouldEqual, "edition,label,release_date,state,code_count\n"+
+			"2019,\"Local authorities, 2019\",2019-04-01T00:00:00Z,published,2\n"+
+			"2020,\"Local authorities, 2020\",,,\n")
+	})
+
+	Convey("Code lists are written with their type", t, func() {
+		codeLists := &models.CodeListResults{Items: []models.CodeList{{ID: "local-authority", Label: "Local authority", Type: "geography"}}}
+		So(writeCSV(codeLists), ShouldEqual, "id,label,type\nlocal-authority,Local authority,geography\n")
+	})
+
+	Convey("Code changes are written with their previous label", t, func() {
+		changes := &models.CodeChanges{Items: []models.CodeChange{{Type: "relabelled", ID: "E06000001", Label: "Hartlepool", PreviousLabel: "Hartlepool UA"}}}
+		So(writeCSV(changes), ShouldEqual, "change,code,label,previous_label\nrelabelled,E06000001,Hartlepool,Hartlepool UA\n")
+	})
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The types of the changes which are not in one of the editions
+const (
+	changeAdded   = "added"
+	changeRemoved = "removed"
+)
+
+// CodeChanges contains the codes changed between two editions, which can be paginated
+type CodeChanges struct {
+	Items      []CodeChange `json:"items"`
+	Count      int          `json:"count"`
+	Offset     int          `json:"offset"`
+	Limit      int          `json:"limit"`
+	TotalCount int          `json:"total_count"`
+}
+
+// CodeChange is a code added, removed or relabelled between two editions
+type CodeChange struct {
+	Type          string           `json:"type"`
+	ID            string           `json:"code"`
+	Label         string           `json:"label"`
+	PreviousLabel string           `json:"previous_label,omitempty"`
+	Links         *CodeChangeLinks `json:"links"`
+}
+
+// CodeChangeLinks contains the links to a changed code, in the new edition unless it was removed, and in
+// the previous edition unless it was added
+type CodeChangeLinks struct {
+	Code         *Link `json:"code,omitempty"`
+	PreviousCode *Link `json:"previous_code,omitempty"`
+}
+
+// UpdateLinks updates the links for a changed code
+func (c *CodeChange) UpdateLinks(host, codeListID, edition, previousEdition string) error {
+
+	if c.ID == "" {
+		return errors.New("unable to create links - code ID not provided")
+	}
+
+	if c.Links == nil {
+		c.Links = &CodeChangeLinks{}
+	}
+
+	if c.Type != changeRemoved {
+		c.Links.Code = CreateLink(c.ID, fmt.Sprintf(codeURI, codeListID, edition, c.ID), host)
+	}
+	if c.Type != changeAdded {
+		c.Links.PreviousCode = CreateLink(c.ID, fmt.Sprintf(codeURI, codeListID, previousEdition, c.ID), host)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/models"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCodeChangeUpdateLinks(t *testing.T) {
+
+	Convey("A relabelled code links to the code in both editions", t, func() {
+		change := &models.CodeChange{Type: "relabelled", ID: "E06000001"}
+		So(change.UpdateLinks("http://localhost:22400", "local-authority", "2020", "2019"), ShouldBeNil)
+		So(change.Links, ShouldResemble, &models.CodeChangeLinks{
+			Code:         &models.Link{ID: "E06000001", Href: "http://localhost:22400/code-lists/local-authority/editions/2020/codes/E06000001"},
+			PreviousCode: &models.Link{ID: "E06000001", Href: "http://localhost:22400/code-lists/local-authority/editions/2019/codes/E06000001"},
+		})
+	})
+
+	Convey("An added code only links to the code in the new edition", t, func() {
+		change := &models.CodeChange{Type: "added", ID: "E06000060"}
+		So(change.UpdateLinks("http://localhost:22400", "local-authority", "2020", "2019"), ShouldBeNil)
+		So(change.Links.Code, ShouldNotBeNil)
+		So(change.Links.PreviousCode, ShouldBeNil)
+	})
+
+	Convey("A removed code only links to the code in the previous edition", t, func() {
+		change := &models.CodeChange{Type: "removed", ID: "E07000004"}
+		So(change.UpdateLinks("http://localhost:22400", "local-authority", "2020", "2019"), ShouldBeNil)
+		So(change.Links.Code, ShouldBeNil)
+		So(change.Links.PreviousCode, ShouldNotBeNil)
+	})
+
+	Convey("UpdateLinks fails for a change without a code", t, func() {
+		change := &models.CodeChange{Type: "added"}
+		So(change.UpdateLinks("http://localhost:22400", "local-authority", "2020", "2019"), ShouldNotBeNil)
+	})
+}
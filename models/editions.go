@@ -3,6 +3,7 @@ package models
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
 )
@@ -18,16 +19,21 @@ type Editions struct {
 
 // Edition represents a single edition response model
 type Edition struct {
-	ID    string        `json:"edition"`
-	Label string        `json:"label"`
-	Links *EditionLinks `json:"links"`
+	ID          string        `json:"edition"`
+	Label       string        `json:"label"`
+	ReleaseDate *time.Time    `json:"release_date,omitempty"`
+	State       string        `json:"state,omitempty"`
+	CodeCount   int           `json:"code_count,omitempty"`
+	Links       *EditionLinks `json:"links"`
 }
 
 // EditionLinks represents the links returned for a specific edition
 type EditionLinks struct {
-	Self     *Link `json:"self"`
-	Editions *Link `json:"editions"`
-	Codes    *Link `json:"codes"`
+	Self         *Link `json:"self"`
+	Editions     *Link `json:"editions"`
+	Codes        *Link `json:"codes"`
+	Supersedes   *Link `json:"supersedes,omitempty"`
+	SupersededBy *Link `json:"superseded_by,omitempty"`
 }
 
 // UpdateLinks updates the EditionLinks in the Edition struct with the provided codeListID
@@ -48,6 +54,23 @@ func (e *Edition) UpdateLinks(codeListID, url string) error {
 	return nil
 }
 
+// UpdateSupersessionLinks links an edition to the editions it supersedes and is superseded by, if any
+func (e *Edition) UpdateSupersessionLinks(codeListID, url, supersedes, supersededBy string) {
+
+	if e.Links == nil {
+		e.Links = &EditionLinks{}
+	}
+
+	e.Links.Supersedes = nil
+	if supersedes != "" {
+		e.Links.Supersedes = CreateLink(supersedes, fmt.Sprintf(editionURI, codeListID, supersedes), url)
+	}
+	e.Links.SupersededBy = nil
+	if supersededBy != "" {
+		e.Links.SupersededBy = CreateLink(supersededBy, fmt.Sprintf(editionURI, codeListID, supersededBy), url)
+	}
+}
+
 // NewEdition creates an Edition struct from a DB Edition model
 func NewEdition(dbEdition *dbmodels.Edition) *Edition {
 	if dbEdition == nil {
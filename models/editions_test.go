@@ -107,3 +107,20 @@ func TestEditionUpdateLinks(t *testing.T) {
 	})
 
 }
+
+func TestEditionUpdateSupersessionLinks(t *testing.T) {
+
+	Convey("Given an Edition struct of a superseded edition", t, func() {
+		edition := models.Edition{ID: "2019"}
+
+		Convey("UpdateSupersessionLinks sets the superseded by link", func() {
+			edition.UpdateSupersessionLinks("codelistID", "testURL", "", "2020")
+			So(edition, ShouldResemble, models.Edition{
+				ID: "2019",
+				Links: &models.EditionLinks{
+					SupersededBy: &models.Link{ID: "2020", Href: "testURL/code-lists/codelistID/editions/2020"},
+				},
+			})
+		})
+	})
+}
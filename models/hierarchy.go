@@ -0,0 +1,31 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// HierarchyCode is a code of an edition, with its position in the hierarchy of the edition
+type HierarchyCode struct {
+	ID     string     `json:"code"`
+	Label  string     `json:"label"`
+	Parent string     `json:"parent,omitempty"`
+	Depth  int        `json:"depth"`
+	Links  *SelfLinks `json:"links"`
+}
+
+// UpdateLinks updates the links for a code of a hierarchy
+func (h *HierarchyCode) UpdateLinks(host, codeListID, edition string) error {
+
+	if h.ID == "" {
+		return errors.New("unable to create links - code ID not provided")
+	}
+
+	if h.Links == nil {
+		h.Links = &SelfLinks{}
+	}
+
+	h.Links.Self = CreateLink(h.ID, fmt.Sprintf(codeURI, codeListID, edition, h.ID), host)
+
+	return nil
+}
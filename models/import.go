@@ -0,0 +1,44 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ImportReport is the validation report of a CSV file imported as a new edition. The edition is only created
+// if the file is valid, in which case the report links to it.
+type ImportReport struct {
+	Valid        bool            `json:"valid"`
+	CodeCount    int             `json:"code_count"`
+	ProblemCount int             `json:"problem_count"`
+	Problems     []ImportProblem `json:"problems"`
+	Links        *ImportLinks    `json:"links,omitempty"`
+}
+
+// ImportProblem is a problem found on a line of an imported file
+type ImportProblem struct {
+	Line    int    `json:"line"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportLinks contains the links to an imported edition
+type ImportLinks struct {
+	Edition *Link `json:"edition"`
+	Codes   *Link `json:"codes"`
+}
+
+// UpdateLinks updates the links to the edition created from a valid file
+func (r *ImportReport) UpdateLinks(host, codeListID, edition string) error {
+
+	if edition == "" {
+		return errors.New("unable to create links - edition not provided")
+	}
+
+	r.Links = &ImportLinks{
+		Edition: CreateLink(edition, fmt.Sprintf(editionURI, codeListID, edition), host),
+		Codes:   CreateLink("", fmt.Sprintf(codesURI, codeListID, edition), host),
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/models"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestImportReportUpdateLinks(t *testing.T) {
+
+	Convey("A valid report links to the imported edition and its codes", t, func() {
+		report := &models.ImportReport{Valid: true}
+		So(report.UpdateLinks("http://localhost:22400", "local-authority", "2021"), ShouldBeNil)
+		So(report.Links.Edition, ShouldResemble, &models.Link{ID: "2021", Href: "http://localhost:22400/code-lists/local-authority/editions/2021"})
+		So(report.Links.Codes.Href, ShouldEqual, "http://localhost:22400/code-lists/local-authority/editions/2021/codes")
+	})
+}
@@ -5,13 +5,15 @@ import (
 )
 
 const (
-	codeListURI   = "/code-lists/%s"
-	editionsURI   = "/code-lists/%s/editions"
-	editionURI    = "/code-lists/%s/editions/%s"
-	codesURI      = "/code-lists/%s/editions/%s/codes"
-	codeURI       = "/code-lists/%s/editions/%s/codes/%s"
-	datasetsURI   = "/code-lists/%s/editions/%s/codes/%s/datasets"
-	datasetAPIuri = "/datasets/%s"
+	codeListURI      = "/code-lists/%s"
+	editionsURI      = "/code-lists/%s/editions"
+	editionURI       = "/code-lists/%s/editions/%s"
+	latestEditionURI = "/code-lists/%s/editions/latest"
+	codesURI         = "/code-lists/%s/editions/%s/codes"
+	codeURI          = "/code-lists/%s/editions/%s/codes/%s"
+	datasetsURI      = "/code-lists/%s/editions/%s/codes/%s/datasets"
+	childrenURI      = "/code-lists/%s/editions/%s/codes/%s/children"
+	datasetAPIuri    = "/datasets/%s"
 )
 
 // Link contains the id and a link to a resource
@@ -20,6 +22,11 @@ type Link struct {
 	Href string `json:"href"             bson:"href"`
 }
 
+// SelfLinks contains the link to a resource, for resources without other links
+type SelfLinks struct {
+	Self *Link `json:"self"`
+}
+
 func CreateLink(id, href, host string) *Link {
 
 	rel, err := url.Parse(href)
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Mappings contains the mappings from and to a code, which can be paginated
+type Mappings struct {
+	Items      []Mapping `json:"items"`
+	Count      int       `json:"count"`
+	Offset     int       `json:"offset"`
+	Limit      int       `json:"limit"`
+	TotalCount int       `json:"total_count"`
+}
+
+// Mapping is a relationship from a source code to a target code, e.g. a local authority to the local
+// authority replacing it in a later edition
+type Mapping struct {
+	Type   string     `json:"type"`
+	Source MappedCode `json:"source"`
+	Target MappedCode `json:"target"`
+}
+
+// MappedCode identifies a code of an edition of a code list, along with a link to it
+type MappedCode struct {
+	CodeListID string     `json:"code_list"`
+	EditionID  string     `json:"edition"`
+	ID         string     `json:"code"`
+	Links      *SelfLinks `json:"links,omitempty"`
+}
+
+// ResolveRequest holds the codes to resolve to the codes of a target code list, and of a target edition
+// unless it is empty
+type ResolveRequest struct {
+	Codes            []MappedCode `json:"codes"`
+	TargetCodeListID string       `json:"target_code_list"`
+	TargetEditionID  string       `json:"target_edition,omitempty"`
+}
+
+// Resolutions contains the codes resolved for each of the requested codes found, in the requested order,
+// and the requested codes not found
+type Resolutions struct {
+	Items    []Resolution `json:"items"`
+	Count    int          `json:"count"`
+	NotFound []MappedCode `json:"not_found"`
+}
+
+// Resolution contains the codes of the target code list that a code resolves to
+type Resolution struct {
+	Source MappedCode   `json:"source"`
+	Codes  []MappedCode `json:"codes"`
+}
+
+// UpdateLinks updates the links for a mapped code
+func (c *MappedCode) UpdateLinks(host string) error {
+
+	if c.ID == "" {
+		return errors.New("unable to create links - code ID not provided")
+	}
+
+	if c.Links == nil {
+		c.Links = &SelfLinks{}
+	}
+
+	c.Links.Self = CreateLink(c.ID, fmt.Sprintf(codeURI, c.CodeListID, c.EditionID, c.ID), host)
+
+	return nil
+}
+
+// UpdateLinks updates the links for the source and target codes of a mapping
+func (m *Mapping) UpdateLinks(host string) error {
+	if err := m.Source.UpdateLinks(host); err != nil {
+		return err
+	}
+	return m.Target.UpdateLinks(host)
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MatchRequest holds the free-text labels to match against the codes of an edition. Limit is the maximum
+// number of candidates returned for each label, and Threshold the minimum score of a candidate.
+type MatchRequest struct {
+	Labels    []string `json:"labels"`
+	Limit     *int     `json:"limit,omitempty"`
+	Threshold *float64 `json:"threshold,omitempty"`
+}
+
+// Matches contains the candidate codes for each of the requested labels, in the requested order
+type Matches struct {
+	Items []Match `json:"items"`
+	Count int     `json:"count"`
+}
+
+// Match contains the candidate codes for a label, from the best to the worst match
+type Match struct {
+	Label      string           `json:"label"`
+	Candidates []MatchCandidate `json:"candidates"`
+}
+
+// MatchCandidate is a code matching a label, with a score between 0 (no similarity) and 1 (same label)
+type MatchCandidate struct {
+	ID    string     `json:"code"`
+	Label string     `json:"label"`
+	Score float64    `json:"score"`
+	Links *SelfLinks `json:"links"`
+}
+
+// UpdateLinks updates the links for a candidate code
+func (c *MatchCandidate) UpdateLinks(host, codeListID, edition string) error {
+
+	if c.ID == "" {
+		return errors.New("unable to create links - code ID not provided")
+	}
+
+	if c.Links == nil {
+		c.Links = &SelfLinks{}
+	}
+
+	c.Links.Self = CreateLink(c.ID, fmt.Sprintf(codeURI, codeListID, edition, c.ID), host)
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	subscriptionURI         = "/subscriptions/%s"
+	subscriptionFailuresURI = "/subscriptions/%s/failures"
+)
+
+// SubscriptionRequest is the body of a request registering a callback URL for the events of code lists,
+// filtered by code list ID, code list type and event type. Secret is generated if it is not provided.
+type SubscriptionRequest struct {
+	URL           string   `json:"url"`
+	CodeLists     []string `json:"code_lists,omitempty"`
+	CodeListTypes []string `json:"code_list_types,omitempty"`
+	EventTypes    []string `json:"event_types,omitempty"`
+	Secret        string   `json:"secret,omitempty"`
+}
+
+// Subscription is a registered callback URL. The secret signing its payloads is only returned when the
+// subscription is created.
+type Subscription struct {
+	ID            string             `json:"id"`
+	URL           string             `json:"url"`
+	CodeLists     []string           `json:"code_lists,omitempty"`
+	CodeListTypes []string           `json:"code_list_types,omitempty"`
+	EventTypes    []string           `json:"event_types,omitempty"`
+	Secret        string             `json:"secret,omitempty"`
+	Created       time.Time          `json:"created"`
+	Links         *SubscriptionLinks `json:"links,omitempty"`
+}
+
+// SubscriptionLinks contains the links of a subscription
+type SubscriptionLinks struct {
+	Self     *Link `json:"self"`
+	Failures *Link `json:"failures"`
+}
+
+// SubscriptionFailures contains the dead-letter list of a subscription, with the events that could not be
+// delivered to it, oldest first
+type SubscriptionFailures struct {
+	Items []SubscriptionFailure `json:"items"`
+	Count int                   `json:"count"`
+}
+
+// SubscriptionFailure is an event which could not be delivered to a subscription, along with the error of the
+// last attempt
+type SubscriptionFailure struct {
+	Event    ChangeEvent `json:"event"`
+	Attempts int         `json:"attempts"`
+	Error    string      `json:"error"`
+	Time     time.Time   `json:"time"`
+}
+
+// ChangeEvent is a change made to a code list, as delivered to subscriptions
+type ChangeEvent struct {
+	Type       string    `json:"type"`
+	CodeListID string    `json:"code_list_id"`
+	EditionID  string    `json:"edition,omitempty"`
+	Codes      []string  `json:"codes,omitempty"`
+	Time       time.Time `json:"time"`
+}
+
+// Validate checks that a subscription has an absolute http or https URL, and no empty code list ID or type
+func (r *SubscriptionRequest) Validate() error {
+	u, err := url.Parse(r.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("invalid url, it must be an absolute http or https url")
+	}
+	for _, codeListID := range r.CodeLists {
+		if strings.TrimSpace(codeListID) == "" {
+			return errors.New("invalid code list id, it must not be empty")
+		}
+	}
+	for _, codeListType := range r.CodeListTypes {
+		if strings.TrimSpace(codeListType) == "" {
+			return errors.New("invalid code list type, it must not be empty")
+		}
+	}
+	return nil
+}
+
+// UpdateLinks updates the links of a subscription
+func (s *Subscription) UpdateLinks(host string) error {
+
+	if s.ID == "" {
+		return errors.New("unable to create links - subscription id not provided")
+	}
+
+	s.Links = &SubscriptionLinks{
+		Self:     CreateLink(s.ID, fmt.Sprintf(subscriptionURI, s.ID), host),
+		Failures: CreateLink("", fmt.Sprintf(subscriptionFailuresURI, s.ID), host),
+	}
+
+	return nil
+}
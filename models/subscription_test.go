@@ -0,0 +1,46 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/models"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSubscriptionRequest(t *testing.T) {
+
+	Convey("A subscription request with an http url is valid", t, func() {
+		request := &models.SubscriptionRequest{URL: "https://example.com/callback", CodeLists: []string{"local-authority"}, CodeListTypes: []string{"geography"}, EventTypes: []string{"codes-changed"}}
+		So(request.Validate(), ShouldBeNil)
+	})
+
+	Convey("A subscription request with a relative or non-http url is invalid", t, func() {
+		So((&models.SubscriptionRequest{URL: "/callback"}).Validate(), ShouldNotBeNil)
+		So((&models.SubscriptionRequest{URL: "ftp://example.com/callback"}).Validate(), ShouldNotBeNil)
+	})
+
+	Convey("A subscription request with an empty code list id is invalid", t, func() {
+		request := &models.SubscriptionRequest{URL: "https://example.com/callback", CodeLists: []string{" "}}
+		So(request.Validate(), ShouldNotBeNil)
+	})
+
+	Convey("A subscription request with an empty code list type is invalid", t, func() {
+		request := &models.SubscriptionRequest{URL: "https://example.com/callback", CodeListTypes: []string{""}}
+		So(request.Validate(), ShouldNotBeNil)
+	})
+}
+
+func TestSubscription(t *testing.T) {
+
+	Convey("A subscription has links to it and its failures", t, func() {
+		subscription := &models.Subscription{ID: "123", URL: "https://example.com/callback"}
+		So(subscription.UpdateLinks("http://localhost:22400"), ShouldBeNil)
+		So(subscription.Links.Self, ShouldResemble, &models.Link{ID: "123", Href: "http://localhost:22400/subscriptions/123"})
+		So(subscription.Links.Failures, ShouldResemble, &models.Link{Href: "http://localhost:22400/subscriptions/123/failures"})
+	})
+
+	Convey("Links cannot be created for a subscription without an id", t, func() {
+		So((&models.Subscription{}).UpdateLinks("http://localhost:22400"), ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
+)
+
+// Suggestions contains the codes suggested for a prefix
+type Suggestions struct {
+	Items []Suggestion `json:"items"`
+	Count int          `json:"count"`
+	Limit int          `json:"limit"`
+}
+
+// Suggestion is a code suggested for a prefix
+type Suggestion struct {
+	ID    string     `json:"code"`
+	Label string     `json:"label"`
+	Links *SelfLinks `json:"links"`
+}
+
+// UpdateLinks updates the links for a suggested code
+func (s *Suggestion) UpdateLinks(host, codeListID, edition string) error {
+
+	if s.ID == "" {
+		return errors.New("unable to create links - code ID not provided")
+	}
+
+	if s.Links == nil {
+		s.Links = &SelfLinks{}
+	}
+
+	s.Links.Self = CreateLink(s.ID, fmt.Sprintf(codeURI, codeListID, edition, s.ID), host)
+
+	return nil
+}
+
+// NewSuggestions creates a Suggestions struct from the suggested DB Code models
+func NewSuggestions(dbCodes []dbmodels.Code) *Suggestions {
+	items := []Suggestion{}
+	for _, dbCode := range dbCodes {
+		items = append(items, Suggestion{
+			ID:    dbCode.Code,
+			Label: dbCode.Label,
+		})
+	}
+	return &Suggestions{
+		Items: items,
+	}
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	dbmodels "github.com/ONSdigital/dp-graph/v2/models"
+)
+
+// CodeValidation contains which of the requested codes are valid in an edition, and which are unknown
+type CodeValidation struct {
+	Valid        []ValidCode `json:"valid"`
+	Unknown      []string    `json:"unknown"`
+	ValidCount   int         `json:"valid_count"`
+	UnknownCount int         `json:"unknown_count"`
+}
+
+// ValidCode is a requested code found in an edition
+type ValidCode struct {
+	ID    string     `json:"code"`
+	Label string     `json:"label"`
+	Links *SelfLinks `json:"links"`
+}
+
+// UpdateLinks updates the links for a valid code
+func (c *ValidCode) UpdateLinks(host, codeListID, edition string) error {
+
+	if c.ID == "" {
+		return errors.New("unable to create links - code ID not provided")
+	}
+
+	if c.Links == nil {
+		c.Links = &SelfLinks{}
+	}
+
+	c.Links.Self = CreateLink(c.ID, fmt.Sprintf(codeURI, codeListID, edition, c.ID), host)
+
+	return nil
+}
+
+// NewCodeValidation creates a CodeValidation struct from the requested codes and the DB Code models found
+// for them, in the requested order
+func NewCodeValidation(codeIDs []string, dbCodes []dbmodels.Code) *CodeValidation {
+	validation := &CodeValidation{
+		Valid:   []ValidCode{},
+		Unknown: []string{},
+	}
+	for _, dbCode := range dbCodes {
+		validation.Valid = append(validation.Valid, ValidCode{ID: dbCode.Code, Label: dbCode.Label})
+	}
+	validation.Unknown = append(validation.Unknown, notFound(codeIDs, dbCodes)...)
+	validation.ValidCount = len(validation.Valid)
+	validation.UnknownCount = len(validation.Unknown)
+	return validation
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// validID matches the IDs of the code lists and editions created through the API, which are used in URLs
+var validID = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// reservedEditionID is the edition alias resolved to the current edition of a code list
+const reservedEditionID = "latest"
+
+// CodeListRequest is the body of a request creating a code list
+type CodeListRequest struct {
+	ID          string   `json:"id"`
+	Label       string   `json:"label"`
+	Description string   `json:"description,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Licence     string   `json:"licence,omitempty"`
+	Contact     *Contact `json:"contact,omitempty"`
+}
+
+// EditionRequest is the body of a request creating or replacing an edition
+type EditionRequest struct {
+	Label       string     `json:"label"`
+	ReleaseDate *time.Time `json:"release_date,omitempty"`
+	State       string     `json:"state,omitempty"`
+	Supersedes  string     `json:"supersedes,omitempty"`
+}
+
+// CodeRequest is the body of a request creating or replacing a code
+type CodeRequest struct {
+	Label  string `json:"label"`
+	Parent string `json:"parent,omitempty"`
+}
+
+// Validate checks that a code list has a valid ID and a label
+func (r *CodeListRequest) Validate() error {
+	if !validID.MatchString(r.ID) {
+		return errors.New("invalid code list id, it must only contain letters, digits, '.', '_' or '-'")
+	}
+	if strings.TrimSpace(r.Label) == "" {
+		return errors.New("a code list label must be provided")
+	}
+	return nil
+}
+
+// ValidateEditionID checks that an edition ID can be used for a new edition
+func ValidateEditionID(editionID string) error {
+	if !validID.MatchString(editionID) || editionID == reservedEditionID {
+		return errors.New("invalid edition id, it must only contain letters, digits, '.', '_' or '-', and not be latest")
+	}
+	return nil
+}
+
+// Validate checks that an edition has a label
+func (r *EditionRequest) Validate() error {
+	if strings.TrimSpace(r.Label) == "" {
+		return errors.New("an edition label must be provided")
+	}
+	return nil
+}
+
+// Validate checks that a code has a label
+func (r *CodeRequest) Validate() error {
+	if strings.TrimSpace(r.Label) == "" {
+		return errors.New("a code label must be provided")
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/models"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCodeListRequest(t *testing.T) {
+
+	Convey("A code list request with a valid id and a label is valid", t, func() {
+		request := &models.CodeListRequest{ID: "local-authority", Label: " Local authority ", Contact: &models.Contact{Name: "Geography"}}
+		So(request.Validate(), ShouldBeNil)
+	})
+
+	Convey("A code list request with an id unsafe for URLs is invalid", t, func() {
+		request := &models.CodeListRequest{ID: "local/authority", Label: "Local authority"}
+		So(request.Validate(), ShouldNotBeNil)
+	})
+
+	Convey("A code list request without a label is invalid", t, func() {
+		request := &models.CodeListRequest{ID: "local-authority", Label: " "}
+		So(request.Validate(), ShouldNotBeNil)
+	})
+}
+
+func TestValidateEditionID(t *testing.T) {
+
+	Convey("An edition id made of letters, digits and punctuation is valid", t, func() {
+		So(models.ValidateEditionID("2019-v1.2"), ShouldBeNil)
+	})
+
+	Convey("The latest edition alias is not a valid edition id", t, func() {
+		So(models.ValidateEditionID("latest"), ShouldNotBeNil)
+	})
+}
+
+func TestEditionRequest(t *testing.T) {
+
+	Convey("An edition request with a label is valid", t, func() {
+		request := &models.EditionRequest{Label: "2019 ", State: "published", Supersedes: "2018"}
+		So(request.Validate(), ShouldBeNil)
+	})
+
+	Convey("An edition request without a label is invalid", t, func() {
+		request := &models.EditionRequest{Label: " "}
+		So(request.Validate(), ShouldNotBeNil)
+	})
+}
+
+func TestCodeRequest(t *testing.T) {
+
+	Convey("A code request with a label is valid", t, func() {
+		request := &models.CodeRequest{Label: " Hartlepool", Parent: "E92000001"}
+		So(request.Validate(), ShouldBeNil)
+	})
+
+	Convey("A code request without a label is invalid", t, func() {
+		request := &models.CodeRequest{}
+		So(request.Validate(), ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,102 @@
+// Package sdmx writes the codes of an edition as an SDMX codelist, in SDMX-ML 2.1 and SDMX-JSON structure
+// messages, for the international statistical partners exchanging code lists in SDMX.
+package sdmx
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Language is the language of the names of codelists and codes
+const Language = "en"
+
+// EditionAnnotation is the type of the annotation holding the ID of the edition a codelist was built from
+const EditionAnnotation = "EDITION"
+
+// Codelist is an SDMX codelist, maintained by an agency. Each edition of a code list is exported as a codelist
+// of its own, identified by the IDs of the code list and edition, as the versions of a codelist are numbers
+// while editions are not.
+type Codelist struct {
+	ID        string
+	AgencyID  string
+	Version   string
+	Name      string
+	Edition   string
+	URI       string
+	IsFinal   bool
+	ValidFrom *time.Time
+	Codes     []Code
+}
+
+// idPattern matches the identifiers of codelists and codes, of the SDMX IDType
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9_@$\-]+$`)
+
+// InvalidIDError is returned when a codelist cannot be written in SDMX, as its identifier or the identifier of
+// one of its codes is not a valid SDMX identifier
+type InvalidIDError struct {
+	ID string
+}
+
+func (e *InvalidIDError) Error() string {
+	return fmt.Sprintf("%q is not a valid SDMX identifier", e.ID)
+}
+
+// Validate returns an *InvalidIDError if the identifier of the codelist, or of any of its codes and their
+// parent, is not a valid SDMX identifier, as they are written in URNs and references unescaped
+func (c *Codelist) Validate() error {
+	if !idPattern.MatchString(c.ID) {
+		return &InvalidIDError{ID: c.ID}
+	}
+	for _, code := range c.Codes {
+		if !idPattern.MatchString(code.ID) {
+			return &InvalidIDError{ID: code.ID}
+		}
+		if code.Parent != "" && !idPattern.MatchString(code.Parent) {
+			return &InvalidIDError{ID: code.Parent}
+		}
+	}
+	return nil
+}
+
+// Code is a code of a codelist. Parent is the ID of its parent code, for codelists that are hierarchies.
+type Code struct {
+	ID     string
+	Name   string
+	Parent string
+}
+
+// URN returns the URN identifying the codelist
+func (c *Codelist) URN() string {
+	return fmt.Sprintf("urn:sdmx:org.sdmx.infomodel.codelist.Codelist=%s:%s(%s)", c.AgencyID, c.ID, c.Version)
+}
+
+// CodeURN returns the URN identifying a code of the codelist
+func (c *Codelist) CodeURN(code Code) string {
+	return fmt.Sprintf("urn:sdmx:org.sdmx.infomodel.codelist.Code=%s:%s(%s).%s", c.AgencyID, c.ID, c.Version, code.ID)
+}
+
+// Message is a structure message containing a codelist
+type Message struct {
+	Header   Header
+	Codelist *Codelist
+}
+
+// Header is the header of a message, sent by the agency maintaining its codelist
+type Header struct {
+	ID       string
+	Prepared time.Time
+	Sender   string
+}
+
+// NewMessage returns a structure message containing a codelist, prepared at the given time
+func NewMessage(codelist *Codelist, prepared time.Time) *Message {
+	return &Message{
+		Header: Header{
+			ID:       fmt.Sprintf("%s-%s", codelist.AgencyID, codelist.ID),
+			Prepared: prepared.UTC(),
+			Sender:   codelist.AgencyID,
+		},
+		Codelist: codelist,
+	}
+}
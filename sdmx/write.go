@@ -0,0 +1,165 @@
+package sdmx
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Namespaces of SDMX-ML 2.1 structure messages
+const (
+	messageNamespace   = "http://www.sdmx.org/resources/sdmxml/schemas/v2_1/message"
+	structureNamespace = "http://www.sdmx.org/resources/sdmxml/schemas/v2_1/structure"
+	commonNamespace    = "http://www.sdmx.org/resources/sdmxml/schemas/v2_1/common"
+)
+
+// WriteML writes the message as an SDMX-ML 2.1 structure message. Codes are written as they are converted, so
+// that the largest code lists are streamed.
+func (m *Message) WriteML(w io.Writer) error {
+	c := m.Codelist
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprint(bw, xml.Header)
+	fmt.Fprintf(bw, "<message:Structure xmlns:message=%q xmlns:structure=%q xmlns:common=%q>\n", messageNamespace, structureNamespace, commonNamespace)
+	fmt.Fprint(bw, "  <message:Header>\n")
+	fmt.Fprintf(bw, "    <message:ID>%s</message:ID>\n", xmlEscape(m.Header.ID))
+	fmt.Fprint(bw, "    <message:Test>false</message:Test>\n")
+	fmt.Fprintf(bw, "    <message:Prepared>%s</message:Prepared>\n", m.Header.Prepared.Format(time.RFC3339))
+	fmt.Fprintf(bw, "    <message:Sender id=\"%s\"/>\n", xmlEscape(m.Header.Sender))
+	fmt.Fprint(bw, "  </message:Header>\n")
+	fmt.Fprint(bw, "  <message:Structures>\n")
+	fmt.Fprint(bw, "    <structure:Codelists>\n")
+
+	fmt.Fprintf(bw, "      <structure:Codelist id=\"%s\" agencyID=\"%s\" version=\"%s\" isFinal=\"%t\" urn=\"%s\"",
+		xmlEscape(c.ID), xmlEscape(c.AgencyID), xmlEscape(c.Version), c.IsFinal, xmlEscape(c.URN()))
+	if c.URI != "" {
+		fmt.Fprintf(bw, " uri=\"%s\"", xmlEscape(c.URI))
+	}
+	if c.ValidFrom != nil {
+		fmt.Fprintf(bw, " validFrom=\"%s\"", c.ValidFrom.UTC().Format(time.RFC3339))
+	}
+	fmt.Fprint(bw, ">\n")
+	if c.Edition != "" {
+		fmt.Fprint(bw, "        <common:Annotations>\n")
+		fmt.Fprint(bw, "          <common:Annotation>\n")
+		fmt.Fprintf(bw, "            <common:AnnotationTitle>%s</common:AnnotationTitle>\n", xmlEscape(c.Edition))
+		fmt.Fprintf(bw, "            <common:AnnotationType>%s</common:AnnotationType>\n", EditionAnnotation)
+		fmt.Fprint(bw, "          </common:Annotation>\n")
+		fmt.Fprint(bw, "        </common:Annotations>\n")
+	}
+	fmt.Fprintf(bw, "        <common:Name xml:lang=\"%s\">%s</common:Name>\n", Language, xmlEscape(c.Name))
+
+	for _, code := range c.Codes {
+		fmt.Fprintf(bw, "        <structure:Code id=\"%s\" urn=\"%s\">\n", xmlEscape(code.ID), xmlEscape(c.CodeURN(code)))
+		fmt.Fprintf(bw, "          <common:Name xml:lang=\"%s\">%s</common:Name>\n", Language, xmlEscape(code.Name))
+		if code.Parent != "" {
+			fmt.Fprintf(bw, "          <structure:Parent><Ref id=\"%s\"/></structure:Parent>\n", xmlEscape(code.Parent))
+		}
+		fmt.Fprint(bw, "        </structure:Code>\n")
+	}
+
+	fmt.Fprint(bw, "      </structure:Codelist>\n")
+	fmt.Fprint(bw, "    </structure:Codelists>\n")
+	fmt.Fprint(bw, "  </message:Structures>\n")
+	fmt.Fprint(bw, "</message:Structure>\n")
+	return bw.Flush()
+}
+
+// xmlEscape escapes a value for use as character data or in a quoted attribute
+func xmlEscape(value string) string {
+	var b strings.Builder
+	// writes to a strings.Builder do not fail
+	_ = xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
+
+// The SDMX-JSON 1.0 structure message
+type (
+	jsonMessage struct {
+		Meta jsonMeta `json:"meta"`
+		Data jsonData `json:"data"`
+	}
+	jsonMeta struct {
+		ID               string     `json:"id"`
+		Test             bool       `json:"test"`
+		Prepared         string     `json:"prepared"`
+		ContentLanguages []string   `json:"contentLanguages"`
+		Sender           jsonSender `json:"sender"`
+	}
+	jsonSender struct {
+		ID string `json:"id"`
+	}
+	jsonData struct {
+		Codelists []jsonCodelist `json:"codelists"`
+	}
+	jsonCodelist struct {
+		ID                  string            `json:"id"`
+		Version             string            `json:"version"`
+		AgencyID            string            `json:"agencyID"`
+		IsExternalReference bool              `json:"isExternalReference"`
+		IsFinal             bool              `json:"isFinal"`
+		URN                 string            `json:"urn"`
+		URI                 string            `json:"uri,omitempty"`
+		ValidFrom           string            `json:"validFrom,omitempty"`
+		Name                string            `json:"name"`
+		Names               map[string]string `json:"names"`
+		Annotations         []jsonAnnotation  `json:"annotations,omitempty"`
+		Codes               []jsonCode        `json:"codes"`
+	}
+	jsonAnnotation struct {
+		Title string `json:"title"`
+		Type  string `json:"type"`
+	}
+	jsonCode struct {
+		ID     string            `json:"id"`
+		URN    string            `json:"urn"`
+		Name   string            `json:"name"`
+		Names  map[string]string `json:"names"`
+		Parent string            `json:"parent,omitempty"`
+	}
+)
+
+// WriteJSON writes the message as an SDMX-JSON 1.0 structure message
+func (m *Message) WriteJSON(w io.Writer) error {
+	c := m.Codelist
+	codelist := jsonCodelist{
+		ID:       c.ID,
+		Version:  c.Version,
+		AgencyID: c.AgencyID,
+		IsFinal:  c.IsFinal,
+		URN:      c.URN(),
+		URI:      c.URI,
+		Name:     c.Name,
+		Names:    map[string]string{Language: c.Name},
+		Codes:    make([]jsonCode, 0, len(c.Codes)),
+	}
+	if c.ValidFrom != nil {
+		codelist.ValidFrom = c.ValidFrom.UTC().Format(time.RFC3339)
+	}
+	if c.Edition != "" {
+		codelist.Annotations = []jsonAnnotation{{Title: c.Edition, Type: EditionAnnotation}}
+	}
+	for _, code := range c.Codes {
+		codelist.Codes = append(codelist.Codes, jsonCode{
+			ID:     code.ID,
+			URN:    c.CodeURN(code),
+			Name:   code.Name,
+			Names:  map[string]string{Language: code.Name},
+			Parent: code.Parent,
+		})
+	}
+
+	return json.NewEncoder(w).Encode(jsonMessage{
+		Meta: jsonMeta{
+			ID:               m.Header.ID,
+			Prepared:         m.Header.Prepared.Format(time.RFC3339),
+			ContentLanguages: []string{Language},
+			Sender:           jsonSender{ID: m.Header.Sender},
+		},
+		Data: jsonData{Codelists: []jsonCodelist{codelist}},
+	})
+}
@@ -0,0 +1,124 @@
+package sdmx
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func testMessage() *Message {
+	validFrom := time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)
+	codelist := &Codelist{
+		ID:        "local-authority_2020",
+		AgencyID:  "ONS",
+		Version:   "1.0",
+		Name:      "Local authorities & regions, 2020",
+		Edition:   "2020",
+		URI:       "http://localhost:22400/code-lists/local-authority/editions/2020",
+		IsFinal:   true,
+		ValidFrom: &validFrom,
+		Codes: []Code{
+			{ID: "E12000001", Name: "North East"},
+			{ID: "E06000001", Name: "Hartlepool", Parent: "E12000001"},
+		},
+	}
+	return NewMessage(codelist, time.Date(2020, 5, 1, 12, 0, 0, 0, time.FixedZone("BST", 3600)))
+}
+
+func TestValidate(t *testing.T) {
+	Convey("A codelist whose identifiers are SDMX identifiers is valid", t, func() {
+		So(testMessage().Codelist.Validate(), ShouldBeNil)
+	})
+
+	Convey("A codelist is invalid if its identifier or the identifier of a code or parent is not an SDMX identifier", t, func() {
+		codelist := testMessage().Codelist
+		codelist.ID = "local authority_2020"
+		So(codelist.Validate(), ShouldResemble, &InvalidIDError{ID: "local authority_2020"})
+
+		codelist = testMessage().Codelist
+		codelist.Codes[0].ID = "E12000001.1"
+		So(codelist.Validate(), ShouldResemble, &InvalidIDError{ID: "E12000001.1"})
+
+		codelist = testMessage().Codelist
+		codelist.Codes[1].Parent = "E1200000/1"
+		So(codelist.Validate(), ShouldResemble, &InvalidIDError{ID: "E1200000/1"})
+	})
+}
+
+func TestNewMessage(t *testing.T) {
+	Convey("A message is identified by its codelist and edition, and sent by its agency", t, func() {
+		m := testMessage()
+		So(m.Header, ShouldResemble, Header{
+			ID:       "ONS-local-authority_2020",
+			Prepared: time.Date(2020, 5, 1, 11, 0, 0, 0, time.UTC),
+			Sender:   "ONS",
+		})
+		So(m.Codelist.URN(), ShouldEqual, "urn:sdmx:org.sdmx.infomodel.codelist.Codelist=ONS:local-authority_2020(1.0)")
+		So(m.Codelist.CodeURN(m.Codelist.Codes[1]), ShouldEqual, "urn:sdmx:org.sdmx.infomodel.codelist.Code=ONS:local-authority_2020(1.0).E06000001")
+	})
+}
+
+func TestWriteML(t *testing.T) {
+	Convey("A message is written as a well-formed SDMX-ML structure message", t, func() {
+		var b bytes.Buffer
+		So(testMessage().WriteML(&b), ShouldBeNil)
+
+		So(b.String(), ShouldContainSubstring, "<message:Prepared>2020-05-01T11:00:00Z</message:Prepared>")
+		So(b.String(), ShouldContainSubstring, `<structure:Codelist id="local-authority_2020" agencyID="ONS" version="1.0" isFinal="true" `+
+			`urn="urn:sdmx:org.sdmx.infomodel.codelist.Codelist=ONS:local-authority_2020(1.0)" `+
+			`uri="http://localhost:22400/code-lists/local-authority/editions/2020" validFrom="2020-04-01T00:00:00Z">`)
+		So(b.String(), ShouldContainSubstring, "<common:AnnotationTitle>2020</common:AnnotationTitle>")
+		So(b.String(), ShouldContainSubstring, `<common:Name xml:lang="en">Local authorities &amp; regions, 2020</common:Name>`)
+		So(b.String(), ShouldContainSubstring, "        <structure:Code id=\"E06000001\" urn=\"urn:sdmx:org.sdmx.infomodel.codelist.Code=ONS:local-authority_2020(1.0).E06000001\">\n"+
+			"          <common:Name xml:lang=\"en\">Hartlepool</common:Name>\n"+
+			"          <structure:Parent><Ref id=\"E12000001\"/></structure:Parent>\n"+
+			"        </structure:Code>\n")
+
+		var document struct {
+			Codes []struct {
+				ID string `xml:"id,attr"`
+			} `xml:"Structures>Codelists>Codelist>Code"`
+		}
+		So(xml.Unmarshal(b.Bytes(), &document), ShouldBeNil)
+		So(document.Codes, ShouldHaveLength, 2)
+		So(document.Codes[0].ID, ShouldEqual, "E12000001")
+	})
+
+	Convey("A codelist without an edition has no annotations", t, func() {
+		m := testMessage()
+		m.Codelist.Edition = ""
+		var b bytes.Buffer
+		So(m.WriteML(&b), ShouldBeNil)
+		So(b.String(), ShouldNotContainSubstring, "Annotations")
+	})
+}
+
+func TestWriteJSON(t *testing.T) {
+	Convey("A message is written as an SDMX-JSON structure message", t, func() {
+		var b bytes.Buffer
+		So(testMessage().WriteJSON(&b), ShouldBeNil)
+
+		var message jsonMessage
+		So(json.Unmarshal(b.Bytes(), &message), ShouldBeNil)
+		So(message.Meta, ShouldResemble, jsonMeta{
+			ID:               "ONS-local-authority_2020",
+			Prepared:         "2020-05-01T11:00:00Z",
+			ContentLanguages: []string{"en"},
+			Sender:           jsonSender{ID: "ONS"},
+		})
+		So(message.Data.Codelists, ShouldHaveLength, 1)
+		codelist := message.Data.Codelists[0]
+		So(codelist.AgencyID, ShouldEqual, "ONS")
+		So(codelist.ValidFrom, ShouldEqual, "2020-04-01T00:00:00Z")
+		So(codelist.Names, ShouldResemble, map[string]string{"en": "Local authorities & regions, 2020"})
+		So(codelist.Annotations, ShouldResemble, []jsonAnnotation{{Title: "2020", Type: EditionAnnotation}})
+		So(codelist.Codes, ShouldResemble, []jsonCode{
+			{ID: "E12000001", URN: "urn:sdmx:org.sdmx.infomodel.codelist.Code=ONS:local-authority_2020(1.0).E12000001", Name: "North East", Names: map[string]string{"en": "North East"}},
+			{ID: "E06000001", URN: "urn:sdmx:org.sdmx.infomodel.codelist.Code=ONS:local-authority_2020(1.0).E06000001", Name: "Hartlepool", Names: map[string]string{"en": "Hartlepool"}, Parent: "E12000001"},
+		})
+	})
+}
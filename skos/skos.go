@@ -0,0 +1,129 @@
+// Package skos describes code lists as SKOS concept schemes, in RDF graphs written as Turtle, JSON-LD or
+// RDF/XML for linked data consumers. Code lists and editions are concept schemes, and codes are concepts
+// of the scheme of their edition.
+package skos
+
+import (
+	"strings"
+)
+
+// Prefix is the prefix of the names of the terms of a vocabulary
+type Prefix struct {
+	Name      string
+	Namespace string
+}
+
+// Prefixes are the prefixes of the vocabularies used to describe code lists
+var Prefixes = []Prefix{
+	{Name: "rdf", Namespace: "http://www.w3.org/1999/02/22-rdf-syntax-ns#"},
+	{Name: "skos", Namespace: "http://www.w3.org/2004/02/skos/core#"},
+	{Name: "dct", Namespace: "http://purl.org/dc/terms/"},
+	{Name: "owl", Namespace: "http://www.w3.org/2002/07/owl#"},
+	{Name: "xsd", Namespace: "http://www.w3.org/2001/XMLSchema#"},
+}
+
+// Terms of the vocabularies, as prefixed names
+const (
+	Type          = "rdf:type"
+	ConceptScheme = "skos:ConceptScheme"
+	Concept       = "skos:Concept"
+	PrefLabel     = "skos:prefLabel"
+	Notation      = "skos:notation"
+	InScheme      = "skos:inScheme"
+	TopConceptOf  = "skos:topConceptOf"
+	Broader       = "skos:broader"
+	Description   = "dct:description"
+	IsVersionOf   = "dct:isVersionOf"
+	Issued        = "dct:issued"
+	Replaces      = "dct:replaces"
+	IsReplacedBy  = "dct:isReplacedBy"
+	VersionInfo   = "owl:versionInfo"
+	DateTime      = "xsd:dateTime"
+)
+
+// Language is the language of the labels of code lists
+const Language = "en"
+
+// Term is the object of a statement: a resource identified by an IRI, or a literal value with an optional
+// language or datatype
+type Term struct {
+	IRI      string
+	Value    string
+	Language string
+	Datatype string
+}
+
+// IRI returns the term of a resource, identified by an absolute IRI or a prefixed name
+func IRI(iri string) Term {
+	return Term{IRI: iri}
+}
+
+// Literal returns the term of a plain literal value
+func Literal(value string) Term {
+	return Term{Value: value}
+}
+
+// Label returns the term of a label, in the language of code lists
+func Label(value string) Term {
+	return Term{Value: value, Language: Language}
+}
+
+// TypedLiteral returns the term of a literal value of a datatype, given as a prefixed name
+func TypedLiteral(value, datatype string) Term {
+	return Term{Value: value, Datatype: datatype}
+}
+
+// statement is a predicate and object of a subject
+type statement struct {
+	predicate string
+	object    Term
+}
+
+// Graph is a set of statements about resources, which are kept in the order they were first described
+type Graph struct {
+	subjects   []string
+	statements map[string][]statement
+}
+
+// NewGraph returns an empty graph
+func NewGraph() *Graph {
+	return &Graph{statements: map[string][]statement{}}
+}
+
+// Add adds a statement about a subject, identified by an absolute IRI. The predicate is the prefixed name of
+// a term of the vocabularies.
+func (g *Graph) Add(subject, predicate string, object Term) {
+	if _, ok := g.statements[subject]; !ok {
+		g.subjects = append(g.subjects, subject)
+	}
+	g.statements[subject] = append(g.statements[subject], statement{predicate: predicate, object: object})
+}
+
+// Merge adds the statements of another graph
+func (g *Graph) Merge(other *Graph) {
+	for _, subject := range other.subjects {
+		for _, s := range other.statements[subject] {
+			g.Add(subject, s.predicate, s.object)
+		}
+	}
+}
+
+// Len returns the number of subjects described by the graph
+func (g *Graph) Len() int {
+	return len(g.subjects)
+}
+
+// expand returns the absolute IRI of a prefixed name, or the IRI itself if it is not a prefixed name
+func expand(iri string) string {
+	for _, prefix := range Prefixes {
+		if strings.HasPrefix(iri, prefix.Name+":") {
+			return prefix.Namespace + strings.TrimPrefix(iri, prefix.Name+":")
+		}
+	}
+	return iri
+}
+
+// isPrefixed reports whether an IRI is a prefixed name of one of the vocabularies
+func isPrefixed(iri string) bool {
+	return expand(iri) != iri
+}
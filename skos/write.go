@@ -0,0 +1,155 @@
+package skos
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteTurtle writes the graph as Turtle, with the statements about each subject grouped together
+func (g *Graph) WriteTurtle(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, prefix := range Prefixes {
+		fmt.Fprintf(bw, "@prefix %s: <%s> .\n", prefix.Name, prefix.Namespace)
+	}
+	for _, subject := range g.subjects {
+		fmt.Fprintf(bw, "\n%s", turtleIRI(subject))
+		for i, s := range g.statements[subject] {
+			separator := " ;"
+			if i == 0 {
+				separator = ""
+			}
+			predicate := turtleIRI(s.predicate)
+			if s.predicate == Type {
+				predicate = "a"
+			}
+			fmt.Fprintf(bw, "%s\n    %s %s", separator, predicate, turtleTerm(s.object))
+		}
+		fmt.Fprint(bw, " .\n")
+	}
+	return bw.Flush()
+}
+
+// turtleIRI returns an IRI as a prefixed name or as an IRI reference, where the characters that are not
+// allowed in references are percent-encoded
+func turtleIRI(iri string) string {
+	if isPrefixed(iri) {
+		return iri
+	}
+	var b strings.Builder
+	b.WriteByte('<')
+	for _, c := range []byte(iri) {
+		if c <= ' ' || strings.IndexByte("<>\"{}|^`\\", c) >= 0 {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	b.WriteByte('>')
+	return b.String()
+}
+
+var turtleEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+// turtleTerm returns a term as an IRI, or as a quoted literal with its language or datatype
+func turtleTerm(t Term) string {
+	if t.IRI != "" {
+		return turtleIRI(t.IRI)
+	}
+	literal := `"` + turtleEscaper.Replace(t.Value) + `"`
+	switch {
+	case t.Language != "":
+		return literal + "@" + t.Language
+	case t.Datatype != "":
+		return literal + "^^" + turtleIRI(t.Datatype)
+	}
+	return literal
+}
+
+// WriteJSONLD writes the graph as a JSON-LD document, with a node for each subject in its @graph. Prefixed
+// names are kept, and expanded by the prefixes of the @context.
+func (g *Graph) WriteJSONLD(w io.Writer) error {
+	context := make(map[string]string, len(Prefixes))
+	for _, prefix := range Prefixes {
+		context[prefix.Name] = prefix.Namespace
+	}
+
+	nodes := make([]map[string]interface{}, 0, len(g.subjects))
+	for _, subject := range g.subjects {
+		node := map[string]interface{}{"@id": subject}
+		for _, s := range g.statements[subject] {
+			key, value := s.predicate, jsonLDTerm(s.object)
+			if s.predicate == Type {
+				key, value = "@type", s.object.IRI
+			}
+			switch existing := node[key].(type) {
+			case nil:
+				node[key] = value
+			case []interface{}:
+				node[key] = append(existing, value)
+			default:
+				node[key] = []interface{}{existing, value}
+			}
+		}
+		nodes = append(nodes, node)
+	}
+
+	return json.NewEncoder(w).Encode(map[string]interface{}{"@context": context, "@graph": nodes})
+}
+
+// jsonLDTerm returns a term as a node reference, a string for plain literals, or a value object
+func jsonLDTerm(t Term) interface{} {
+	switch {
+	case t.IRI != "":
+		return map[string]string{"@id": t.IRI}
+	case t.Language != "":
+		return map[string]string{"@value": t.Value, "@language": t.Language}
+	case t.Datatype != "":
+		return map[string]string{"@value": t.Value, "@type": t.Datatype}
+	}
+	return t.Value
+}
+
+// WriteRDFXML writes the graph as RDF/XML, with an rdf:Description element for each subject
+func (g *Graph) WriteRDFXML(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, xml.Header+"<rdf:RDF")
+	for _, prefix := range Prefixes {
+		fmt.Fprintf(bw, "\n    xmlns:%s=\"%s\"", prefix.Name, xmlEscape(prefix.Namespace))
+	}
+	fmt.Fprint(bw, ">\n")
+	for _, subject := range g.subjects {
+		fmt.Fprintf(bw, "  <rdf:Description rdf:about=\"%s\">\n", xmlEscape(expand(subject)))
+		for _, s := range g.statements[subject] {
+			fmt.Fprintf(bw, "    %s\n", xmlProperty(s))
+		}
+		fmt.Fprint(bw, "  </rdf:Description>\n")
+	}
+	fmt.Fprint(bw, "</rdf:RDF>\n")
+	return bw.Flush()
+}
+
+// xmlProperty returns the element of a statement, whose name is the prefixed name of its predicate
+func xmlProperty(s statement) string {
+	t := s.object
+	switch {
+	case t.IRI != "":
+		return fmt.Sprintf("<%s rdf:resource=\"%s\"/>", s.predicate, xmlEscape(expand(t.IRI)))
+	case t.Language != "":
+		return fmt.Sprintf("<%s xml:lang=\"%s\">%s</%s>", s.predicate, xmlEscape(t.Language), xmlEscape(t.Value), s.predicate)
+	case t.Datatype != "":
+		return fmt.Sprintf("<%s rdf:datatype=\"%s\">%s</%s>", s.predicate, xmlEscape(expand(t.Datatype)), xmlEscape(t.Value), s.predicate)
+	}
+	return fmt.Sprintf("<%s>%s</%s>", s.predicate, xmlEscape(t.Value), s.predicate)
+}
+
+// xmlEscape escapes a value for use as character data or in a quoted attribute
+func xmlEscape(value string) string {
+	var b strings.Builder
+	// writes to a strings.Builder do not fail
+	_ = xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
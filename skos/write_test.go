@@ -0,0 +1,112 @@
+package skos
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	scheme  = "http://localhost:22400/code-lists/local-authority/editions/2019"
+	concept = "http://localhost:22400/code-lists/local-authority/editions/2019/codes/E06000001"
+)
+
+func testGraph() *Graph {
+	g := NewGraph()
+	g.Add(scheme, Type, IRI(ConceptScheme))
+	g.Add(scheme, Issued, TypedLiteral("2019-05-01T00:00:00Z", DateTime))
+	g.Add(concept, Type, IRI(Concept))
+	g.Add(concept, Notation, Literal("E06000001"))
+	g.Add(concept, PrefLabel, Label("Hartlepool \"Borough\" <& Council>"))
+	g.Add(concept, InScheme, IRI(scheme))
+	g.Add(concept, InScheme, IRI(scheme+"-alt"))
+	return g
+}
+
+func TestGraph(t *testing.T) {
+	Convey("Merged statements are added to the subjects of a graph", t, func() {
+		g := NewGraph()
+		g.Add(scheme, Type, IRI(ConceptScheme))
+		g.Merge(testGraph())
+		So(g.Len(), ShouldEqual, 2)
+		So(g.statements[scheme], ShouldHaveLength, 3)
+		So(g.statements[concept], ShouldHaveLength, 5)
+	})
+}
+
+func TestWriteTurtle(t *testing.T) {
+	Convey("A graph is written as Turtle, grouped by subject", t, func() {
+		var b bytes.Buffer
+		So(testGraph().WriteTurtle(&b), ShouldBeNil)
+		So(b.String(), ShouldStartWith, "@prefix rdf: <http://www.w3.org/1999/02/22-rdf-syntax-ns#> .\n")
+		So(b.String(), ShouldEndWith, "\n"+
+			"<"+scheme+">\n"+
+			"    a skos:ConceptScheme ;\n"+
+			"    dct:issued \"2019-05-01T00:00:00Z\"^^xsd:dateTime .\n"+
+			"\n"+
+			"<"+concept+">\n"+
+			"    a skos:Concept ;\n"+
+			"    skos:notation \"E06000001\" ;\n"+
+			"    skos:prefLabel \"Hartlepool \\\"Borough\\\" <& Council>\"@en ;\n"+
+			"    skos:inScheme <"+scheme+"> ;\n"+
+			"    skos:inScheme <"+scheme+"-alt> .\n")
+	})
+
+	Convey("Characters that are not allowed in IRI references are percent-encoded", t, func() {
+		So(turtleIRI("http://localhost/code-lists/a b{c}"), ShouldEqual, "<http://localhost/code-lists/a%20b%7Bc%7D>")
+	})
+}
+
+func TestWriteJSONLD(t *testing.T) {
+	Convey("A graph is written as a JSON-LD document with a node for each subject", t, func() {
+		var b bytes.Buffer
+		So(testGraph().WriteJSONLD(&b), ShouldBeNil)
+
+		var document struct {
+			Context map[string]string        `json:"@context"`
+			Graph   []map[string]interface{} `json:"@graph"`
+		}
+		So(json.Unmarshal(b.Bytes(), &document), ShouldBeNil)
+		So(document.Context["skos"], ShouldEqual, "http://www.w3.org/2004/02/skos/core#")
+		So(document.Graph, ShouldResemble, []map[string]interface{}{
+			{
+				"@id":        scheme,
+				"@type":      "skos:ConceptScheme",
+				"dct:issued": map[string]interface{}{"@value": "2019-05-01T00:00:00Z", "@type": "xsd:dateTime"},
+			},
+			{
+				"@id":            concept,
+				"@type":          "skos:Concept",
+				"skos:notation":  "E06000001",
+				"skos:prefLabel": map[string]interface{}{"@value": "Hartlepool \"Borough\" <& Council>", "@language": "en"},
+				"skos:inScheme": []interface{}{
+					map[string]interface{}{"@id": scheme},
+					map[string]interface{}{"@id": scheme + "-alt"},
+				},
+			},
+		})
+	})
+}
+
+func TestWriteRDFXML(t *testing.T) {
+	Convey("A graph is written as well-formed RDF/XML", t, func() {
+		var b bytes.Buffer
+		So(testGraph().WriteRDFXML(&b), ShouldBeNil)
+		So(b.String(), ShouldContainSubstring, `xmlns:skos="http://www.w3.org/2004/02/skos/core#"`)
+		So(b.String(), ShouldContainSubstring, "  <rdf:Description rdf:about=\""+concept+"\">\n"+
+			"    <rdf:type rdf:resource=\"http://www.w3.org/2004/02/skos/core#Concept\"/>\n"+
+			"    <skos:notation>E06000001</skos:notation>\n"+
+			"    <skos:prefLabel xml:lang=\"en\">Hartlepool &#34;Borough&#34; &lt;&amp; Council&gt;</skos:prefLabel>\n")
+		So(b.String(), ShouldContainSubstring, `<dct:issued rdf:datatype="http://www.w3.org/2001/XMLSchema#dateTime">2019-05-01T00:00:00Z</dct:issued>`)
+
+		decoder := xml.NewDecoder(&b)
+		var err error
+		for err == nil {
+			_, err = decoder.Token()
+		}
+		So(err.Error(), ShouldEqual, "EOF")
+	})
+}
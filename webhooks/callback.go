@@ -0,0 +1,107 @@
+package webhooks
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	dphttp "github.com/ONSdigital/dp-net/http"
+	"github.com/pkg/errors"
+)
+
+// ErrForbiddenAddress is returned for a callback on a loopback, link-local or private address, which could
+// be used to reach the services of the network the API runs in
+var ErrForbiddenAddress = errors.New("callback address must not be loopback, link-local or private")
+
+// forbiddenNetworks are the unspecified, loopback, link-local and private networks, and the IPv6 unique local
+// addresses. IPv4-mapped IPv6 addresses are matched by the IPv4 networks.
+var forbiddenNetworks = parseNetworks(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// forbidden reports whether events must not be delivered to an IP address
+func forbidden(ip net.IP) bool {
+	for _, network := range forbiddenNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckURL checks that the host of a callback URL is neither localhost nor a loopback, link-local or private
+// IP address. Host names are not resolved, as the addresses they resolve to may change: clients created with
+// NewClient check the address of each connection instead.
+func CheckURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrForbiddenAddress
+	}
+	if ip := net.ParseIP(host); ip != nil && forbidden(ip) {
+		return ErrForbiddenAddress
+	}
+	return nil
+}
+
+// NewClient returns a client delivering events, which refuses to connect to loopback, link-local or private
+// addresses whatever the host name they were resolved from, including when following redirects. Requests are
+// not retried, as deliveries are retried by the dispatcher with a longer backoff.
+func NewClient() dphttp.Clienter {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: checkAddress,
+	}
+	return &dphttp.Client{
+		MaxRetries: 0,
+		HTTPClient: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: 5 * time.Second,
+				MaxIdleConns:        10,
+				IdleConnTimeout:     30 * time.Second,
+			},
+		},
+	}
+}
+
+// checkAddress is called before each connection of the clients created with NewClient, once the address to
+// connect to is resolved
+func checkAddress(network, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || forbidden(ip) {
+		return ErrForbiddenAddress
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCheckURL(t *testing.T) {
+	Convey("Callbacks on public hosts and addresses are allowed", t, func() {
+		So(CheckURL("https://example.com/callback"), ShouldBeNil)
+		So(CheckURL("http://8.8.8.8/callback"), ShouldBeNil)
+		So(CheckURL("http://[2001:4860:4860::8888]/callback"), ShouldBeNil)
+	})
+
+	Convey("Callbacks on localhost, loopback, link-local and private addresses are rejected", t, func() {
+		for _, callback := range []string{
+			"http://localhost/callback",
+			"http://api.localhost./callback",
+			"http://127.0.0.1:8080/callback",
+			"http://0.0.0.0/callback",
+			"http://10.1.2.3/callback",
+			"http://172.16.0.1/callback",
+			"http://192.168.1.1/callback",
+			"http://169.254.169.254/latest/meta-data",
+			"http://[::1]/callback",
+			"http://[::ffff:127.0.0.1]/callback",
+			"http://[fd00::1]/callback",
+			"http://[fe80::1]/callback",
+		} {
+			So(CheckURL(callback), ShouldEqual, ErrForbiddenAddress)
+		}
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	Convey("A client created with NewClient does not connect to a loopback address", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+
+		_, err := NewClient().Get(context.Background(), server.URL)
+		So(err, ShouldNotBeNil)
+		urlErr, ok := err.(*url.Error)
+		So(ok, ShouldBeTrue)
+		So(urlErr.Err.Error(), ShouldContainSubstring, ErrForbiddenAddress.Error())
+	})
+}
@@ -0,0 +1,273 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore"
+	"github.com/ONSdigital/dp-code-list-api/events"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	dphttp "github.com/ONSdigital/dp-net/http"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/pkg/errors"
+)
+
+// Headers of the requests delivering an event to a subscription
+const (
+	// SignatureHeader holds the signature of the payload, prefixed with sha256=
+	SignatureHeader = "X-Code-List-Signature"
+	// EventTypeHeader holds the type of the event
+	EventTypeHeader = "X-Code-List-Event"
+	// AttemptHeader holds the number of the delivery attempt, starting from 1
+	AttemptHeader = "X-Code-List-Delivery-Attempt"
+)
+
+// maxQueuedDeliveries is the number of events waiting to be delivered to a subscription, past which events
+// are added to its dead-letter list without being delivered
+const maxQueuedDeliveries = 100
+
+// errClosed is returned when an event is published after the dispatcher is closed
+var errClosed = errors.New("webhook dispatcher is closed")
+
+// Type check to ensure that Dispatcher implements the events.Publisher interface
+var _ events.Publisher = (*Dispatcher)(nil)
+
+// Dispatcher is an events.Publisher delivering each event to the subscriptions it matches, in the background.
+// Events are queued per subscription and delivered one at a time, in the order they were published, by a
+// worker which stops once the queue is empty. A delivery is retried after a failure, waiting twice as long as
+// before each time, and added to the dead-letter list of the subscription once every attempt has failed, or
+// straight away if the queue of the subscription is full.
+type Dispatcher struct {
+	client        dphttp.Clienter
+	store         *Store
+	codeLists     datastore.DataStore
+	maxAttempts   int
+	retryInterval time.Duration
+
+	mutex      sync.RWMutex
+	closed     bool
+	closing    chan struct{}
+	deliveries sync.WaitGroup
+
+	queuesMutex sync.Mutex
+	queues      map[string]chan delivery
+}
+
+// delivery is an event waiting to be delivered to a subscription
+type delivery struct {
+	subscription Subscription
+	event        events.Event
+}
+
+// NewDispatcher returns a dispatcher delivering events to the subscriptions of a store, making at most
+// maxAttempts attempts per delivery, the first retry being made after retryInterval. The types of code lists
+// are taken from the metadata of the code lists store.
+func NewDispatcher(client dphttp.Clienter, store *Store, codeLists datastore.DataStore, maxAttempts int, retryInterval time.Duration) *Dispatcher {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &Dispatcher{
+		client:        client,
+		store:         store,
+		codeLists:     codeLists,
+		maxAttempts:   maxAttempts,
+		retryInterval: retryInterval,
+		closing:       make(chan struct{}),
+		queues:        make(map[string]chan delivery),
+	}
+}
+
+// Publish queues an event for delivery to the subscriptions it matches, without waiting for the deliveries
+func (d *Dispatcher) Publish(ctx context.Context, event events.Event) error {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	if d.closed {
+		return errClosed
+	}
+
+	// the type of the code list is only looked up for the subscriptions filtered by code list type
+	codeListType, typeFound := "", false
+	for _, subscription := range d.store.Matching(event) {
+		if len(subscription.CodeListTypes) > 0 && !typeFound {
+			codeListType, typeFound = d.codeListType(ctx, event.CodeListID), true
+		}
+		if !subscription.MatchesCodeListType(codeListType) {
+			continue
+		}
+		if !d.enqueue(delivery{subscription: subscription, event: event}) {
+			d.addFailure(ctx, subscription, event, 0, errors.New("delivery queue of the subscription is full"))
+		}
+	}
+	return nil
+}
+
+// enqueue adds a delivery to the queue of its subscription, starting a worker for the queue if there is none,
+// and reports whether the queue had room for it
+func (d *Dispatcher) enqueue(next delivery) bool {
+	d.queuesMutex.Lock()
+	defer d.queuesMutex.Unlock()
+
+	id := next.subscription.ID
+	queue, ok := d.queues[id]
+	if !ok {
+		queue = make(chan delivery, maxQueuedDeliveries)
+		d.queues[id] = queue
+		d.deliveries.Add(1)
+		go d.work(id, queue)
+	}
+
+	select {
+	case queue <- next:
+		return true
+	default:
+		return false
+	}
+}
+
+// work makes the deliveries of the queue of a subscription until it is empty. Deliveries still queued once
+// the dispatcher is closed are added to the dead-letter list without being attempted.
+func (d *Dispatcher) work(subscriptionID string, queue chan delivery) {
+	defer d.deliveries.Done()
+	for {
+		// the queue is removed under the lock enqueue holds, so that no delivery is added to a queue left behind
+		d.queuesMutex.Lock()
+		var next delivery
+		select {
+		case next = <-queue:
+			d.queuesMutex.Unlock()
+		default:
+			delete(d.queues, subscriptionID)
+			d.queuesMutex.Unlock()
+			return
+		}
+
+		select {
+		case <-d.closing:
+			d.addFailure(context.Background(), next.subscription, next.event, 0, errors.New("delivery interrupted by shutdown"))
+		default:
+			d.deliver(next.subscription, next.event)
+		}
+	}
+}
+
+// codeListType returns the type of a code list, which is empty if it is not known or cannot be found
+func (d *Dispatcher) codeListType(ctx context.Context, codeListID string) string {
+	metadata, err := datastore.GetCodeListMetadata(ctx, d.codeLists, codeListID)
+	if err != nil {
+		log.Event(ctx, "failed to get the type of the code list of an event", log.WARN, log.Error(err), log.Data{"codelist_id": codeListID})
+		return ""
+	}
+	return metadata.Type
+}
+
+// Close stops retrying deliveries, which are added to the dead-letter lists along with the queued ones, and
+// waits for the deliveries in progress to end
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.mutex.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.closing)
+	}
+	d.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.deliveries.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// deliver delivers an event to a subscription, retrying until it succeeds or every attempt has failed
+func (d *Dispatcher) deliver(subscription Subscription, event events.Event) {
+	ctx := context.Background()
+	data := log.Data{"subscription_id": subscription.ID, "type": event.Type, "codelist_id": event.CodeListID, "edition": event.EditionID}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Event(ctx, "failed to marshal event delivered to subscription", log.ERROR, log.Error(err), data)
+		return
+	}
+
+	attempt, wait := 1, d.retryInterval
+	for ; ; attempt, wait = attempt+1, wait*2 {
+		if err = d.send(ctx, subscription, event.Type, payload, attempt); err == nil {
+			log.Event(ctx, "event delivered to subscription", log.INFO, data)
+			return
+		}
+		data["attempt"] = attempt
+		log.Event(ctx, "failed to deliver event to subscription", log.WARN, log.Error(err), data)
+
+		if attempt == d.maxAttempts {
+			break
+		}
+		if !d.sleep(wait) {
+			err = errors.WithMessage(err, "retries interrupted by shutdown")
+			break
+		}
+		if _, err := d.store.Get(subscription.ID); err == driver.ErrNotFound {
+			log.Event(ctx, "subscription removed, delivery stopped", log.INFO, data)
+			return
+		}
+	}
+
+	d.addFailure(ctx, subscription, event, attempt, err)
+}
+
+// addFailure adds an event which could not be delivered to the dead-letter list of a subscription
+func (d *Dispatcher) addFailure(ctx context.Context, subscription Subscription, event events.Event, attempts int, cause error) {
+	failure := Failure{Event: event, Attempts: attempts, Error: cause.Error(), Time: time.Now().UTC()}
+	if err := d.store.AddFailure(subscription.ID, failure); err != nil {
+		data := log.Data{"subscription_id": subscription.ID, "type": event.Type, "codelist_id": event.CodeListID, "edition": event.EditionID}
+		log.Event(ctx, "failed to add event to dead-letter list of subscription", log.ERROR, log.Error(err), data)
+	}
+}
+
+// sleep waits before retrying a delivery, and reports whether the dispatcher was not closed meanwhile
+func (d *Dispatcher) sleep(wait time.Duration) bool {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-d.closing:
+		return false
+	}
+}
+
+// send makes an attempt to deliver an event to a subscription, which succeeds if the callback returns a
+// 2xx status
+func (d *Dispatcher) send(ctx context.Context, subscription Subscription, eventType events.Type, payload []byte, attempt int) error {
+	req, err := http.NewRequest(http.MethodPost, subscription.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventTypeHeader, string(eventType))
+	req.Header.Set(AttemptHeader, strconv.Itoa(attempt))
+	req.Header.Set(SignatureHeader, "sha256="+subscription.Sign(payload))
+
+	resp, err := d.client.Do(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("callback returned %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/datastore/memory"
+	"github.com/ONSdigital/dp-code-list-api/events"
+	dphttp "github.com/ONSdigital/dp-net/http"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// callback is a subscriber's endpoint failing the first requests it receives
+type callback struct {
+	mutex    sync.Mutex
+	failures int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (c *callback) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	body, _ := ioutil.ReadAll(r.Body)
+	c.requests = append(c.requests, r)
+	c.bodies = append(c.bodies, body)
+	if len(c.requests) <= c.failures {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// received returns the number of requests received so far
+func (c *callback) received() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.requests)
+}
+
+func TestDispatcher(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a dispatcher delivering events to a subscription", t, func() {
+		handler := &callback{}
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		store, err := NewStore("")
+		So(err, ShouldBeNil)
+		subscription, err := store.Add(Subscription{URL: server.URL, Secret: "secret"})
+		So(err, ShouldBeNil)
+
+		codeLists := memory.New(&memory.Fixtures{CodeLists: []memory.CodeList{{ID: "local-authority", Type: "geography"}}})
+		client := dphttp.NewClient()
+		client.SetMaxRetries(0)
+		dispatcher := NewDispatcher(client, store, codeLists, 3, time.Millisecond)
+		event := events.New(events.EditionPublished, "local-authority", "2020")
+
+		Convey("An event is delivered with a signed payload", func() {
+			So(dispatcher.Publish(ctx, event), ShouldBeNil)
+			dispatcher.deliveries.Wait()
+
+			So(handler.requests, ShouldHaveLength, 1)
+			So(handler.requests[0].Header.Get(EventTypeHeader), ShouldEqual, string(events.EditionPublished))
+			So(handler.requests[0].Header.Get(AttemptHeader), ShouldEqual, "1")
+			So(handler.requests[0].Header.Get(SignatureHeader), ShouldEqual, "sha256="+subscription.Sign(handler.bodies[0]))
+
+			var delivered events.Event
+			So(json.Unmarshal(handler.bodies[0], &delivered), ShouldBeNil)
+			So(delivered.EditionID, ShouldEqual, "2020")
+		})
+
+		Convey("A failed delivery is retried", func() {
+			handler.failures = 2
+			So(dispatcher.Publish(ctx, event), ShouldBeNil)
+			dispatcher.deliveries.Wait()
+
+			So(handler.requests, ShouldHaveLength, 3)
+			So(handler.requests[2].Header.Get(AttemptHeader), ShouldEqual, "3")
+			failures, _ := store.Failures(subscription.ID)
+			So(failures, ShouldBeEmpty)
+		})
+
+		Convey("A delivery failing every attempt is added to the dead-letter list", func() {
+			handler.failures = 3
+			So(dispatcher.Publish(ctx, event), ShouldBeNil)
+			dispatcher.deliveries.Wait()
+
+			failures, _ := store.Failures(subscription.ID)
+			So(failures, ShouldHaveLength, 1)
+			So(failures[0].Attempts, ShouldEqual, 3)
+			So(failures[0].Error, ShouldEqual, "callback returned 503")
+			So(failures[0].Event.EditionID, ShouldEqual, "2020")
+		})
+
+		Convey("Retries are stopped when the dispatcher is closed", func() {
+			handler.failures = 3
+			dispatcher := NewDispatcher(client, store, codeLists, 3, time.Hour)
+			So(dispatcher.Publish(ctx, event), ShouldBeNil)
+			for i := 0; i < 1000 && handler.received() == 0; i++ {
+				time.Sleep(time.Millisecond)
+			}
+			So(dispatcher.Close(ctx), ShouldBeNil)
+
+			failures, _ := store.Failures(subscription.ID)
+			So(failures, ShouldHaveLength, 1)
+			So(failures[0].Attempts, ShouldEqual, 1)
+			So(failures[0].Error, ShouldContainSubstring, "interrupted by shutdown")
+
+			Convey("And no more events are published", func() {
+				So(dispatcher.Publish(ctx, event), ShouldEqual, errClosed)
+			})
+		})
+
+		Convey("Events are delivered to a subscription in the order they were published", func() {
+			for _, edition := range []string{"2019", "2020", "2021"} {
+				So(dispatcher.Publish(ctx, events.New(events.EditionPublished, "local-authority", edition)), ShouldBeNil)
+			}
+			dispatcher.deliveries.Wait()
+
+			So(handler.bodies, ShouldHaveLength, 3)
+			for i, edition := range []string{"2019", "2020", "2021"} {
+				var delivered events.Event
+				So(json.Unmarshal(handler.bodies[i], &delivered), ShouldBeNil)
+				So(delivered.EditionID, ShouldEqual, edition)
+			}
+			So(dispatcher.queues, ShouldBeEmpty)
+		})
+
+		Convey("Events published to a subscription whose queue is full are added to its dead-letter list", func() {
+			handler.failures = 3
+			dispatcher := NewDispatcher(client, store, codeLists, 3, time.Hour)
+			So(dispatcher.Publish(ctx, event), ShouldBeNil)
+			for i := 0; i < 1000 && handler.received() == 0; i++ {
+				time.Sleep(time.Millisecond)
+			}
+			for i := 0; i < maxQueuedDeliveries; i++ {
+				So(dispatcher.Publish(ctx, event), ShouldBeNil)
+			}
+			So(dispatcher.Publish(ctx, event), ShouldBeNil)
+
+			failures, _ := store.Failures(subscription.ID)
+			So(failures, ShouldHaveLength, 1)
+			So(failures[0].Attempts, ShouldEqual, 0)
+			So(failures[0].Error, ShouldContainSubstring, "queue of the subscription is full")
+
+			Convey("And the queued events are added to it without being attempted when the dispatcher is closed", func() {
+				So(dispatcher.Close(ctx), ShouldBeNil)
+
+				So(handler.received(), ShouldEqual, 1)
+				failures, _ := store.Failures(subscription.ID)
+				So(failures[len(failures)-1].Error, ShouldEqual, "delivery interrupted by shutdown")
+			})
+		})
+
+		Convey("Retries are stopped when the subscription is removed", func() {
+			handler.failures = 3
+			dispatcher := NewDispatcher(client, store, codeLists, 3, 10*time.Millisecond)
+			So(dispatcher.Publish(ctx, event), ShouldBeNil)
+			So(store.Remove(subscription.ID), ShouldBeNil)
+			dispatcher.deliveries.Wait()
+
+			So(handler.requests, ShouldHaveLength, 1)
+		})
+
+		Convey("Events not matching the subscription are not delivered", func() {
+			_, err := store.Add(Subscription{URL: server.URL, CodeLists: []string{"country"}})
+			So(err, ShouldBeNil)
+			So(dispatcher.Publish(ctx, event), ShouldBeNil)
+			dispatcher.deliveries.Wait()
+
+			So(handler.requests, ShouldHaveLength, 1)
+		})
+
+		Convey("Events are delivered to the subscriptions filtered by the type of their code list", func() {
+			_, err := store.Add(Subscription{URL: server.URL, CodeListTypes: []string{"geography"}})
+			So(err, ShouldBeNil)
+			_, err = store.Add(Subscription{URL: server.URL, CodeListTypes: []string{"product"}})
+			So(err, ShouldBeNil)
+			So(dispatcher.Publish(ctx, event), ShouldBeNil)
+			dispatcher.deliveries.Wait()
+
+			So(handler.requests, ShouldHaveLength, 2)
+		})
+	})
+}
@@ -0,0 +1,234 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/events"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	"github.com/pkg/errors"
+)
+
+// maxFailures is the number of failures kept in the dead-letter list of a subscription, the oldest failures
+// being dropped first
+const maxFailures = 100
+
+// entry is a subscription along with its dead-letter list, as saved in the file of a store
+type entry struct {
+	Subscription
+	Failures []Failure `json:"failures"`
+}
+
+// Store holds the subscriptions and their dead-letter lists, saving them to a JSON file after each change so
+// that they are kept across restarts. The file is only read when the store is created, so it is owned by a
+// single instance of the service: subscriptions added to another instance would neither be delivered nor
+// kept.
+type Store struct {
+	path    string
+	lock    *os.File
+	mutex   sync.RWMutex
+	entries []*entry
+}
+
+// NewStore returns a store saving subscriptions to the file at path, and loads the subscriptions already
+// saved to it if it exists. If path is empty, subscriptions are only kept in memory. The file is locked
+// until the store is closed, and an error is returned if it is locked by another store, e.g. of another
+// instance sharing the same volume.
+func NewStore(path string) (*Store, error) {
+	s := &Store{path: path, entries: []*entry{}}
+	if path == "" {
+		return s, nil
+	}
+
+	lock, err := lockFile(path + ".lock")
+	if err != nil {
+		return nil, errors.Wrapf(err, "subscriptions file %q is used by another instance", path)
+	}
+	s.lock = lock
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		lock.Close()
+		return nil, errors.Wrapf(err, "failed to read subscriptions file %q", path)
+	}
+	if err := json.Unmarshal(b, &s.entries); err != nil {
+		lock.Close()
+		return nil, errors.Wrapf(err, "invalid subscriptions file %q", path)
+	}
+	return s, nil
+}
+
+// Close releases the lock of the subscriptions file, which can then be used by another store
+func (s *Store) Close(ctx context.Context) error {
+	if s.lock == nil {
+		return nil
+	}
+	return s.lock.Close()
+}
+
+// Add adds a subscription with a new ID, and a new secret unless it has one
+func (s *Store) Add(subscription Subscription) (Subscription, error) {
+	id, err := randomHex(16)
+	if err != nil {
+		return Subscription{}, err
+	}
+	subscription.ID = id
+	if subscription.Secret == "" {
+		if subscription.Secret, err = randomHex(32); err != nil {
+			return Subscription{}, err
+		}
+	}
+	subscription.Created = time.Now().UTC()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.entries = append(s.entries, &entry{Subscription: subscription, Failures: []Failure{}})
+	if err := s.save(); err != nil {
+		s.entries = s.entries[:len(s.entries)-1]
+		return Subscription{}, err
+	}
+	return subscription, nil
+}
+
+// Get returns a subscription, or driver.ErrNotFound if it does not exist
+func (s *Store) Get(id string) (Subscription, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	e := s.find(id)
+	if e == nil {
+		return Subscription{}, driver.ErrNotFound
+	}
+	return e.Subscription, nil
+}
+
+// Remove removes a subscription along with its dead-letter list, or returns driver.ErrNotFound if it does not
+// exist
+func (s *Store) Remove(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for i, e := range s.entries {
+		if e.ID != id {
+			continue
+		}
+		entries := append(append(make([]*entry, 0, len(s.entries)-1), s.entries[:i]...), s.entries[i+1:]...)
+		previous := s.entries
+		s.entries = entries
+		if err := s.save(); err != nil {
+			s.entries = previous
+			return err
+		}
+		return nil
+	}
+	return driver.ErrNotFound
+}
+
+// Matching returns the subscriptions an event is delivered to
+func (s *Store) Matching(event events.Event) []Subscription {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	subscriptions := []Subscription{}
+	for _, e := range s.entries {
+		if e.Matches(event) {
+			subscriptions = append(subscriptions, e.Subscription)
+		}
+	}
+	return subscriptions
+}
+
+// AddFailure adds an event which could not be delivered to the dead-letter list of a subscription
+func (s *Store) AddFailure(id string, failure Failure) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	e := s.find(id)
+	if e == nil {
+		return driver.ErrNotFound
+	}
+	e.Failures = append(e.Failures, failure)
+	if len(e.Failures) > maxFailures {
+		e.Failures = e.Failures[len(e.Failures)-maxFailures:]
+	}
+	return s.save()
+}
+
+// Failures returns the dead-letter list of a subscription, oldest first, or driver.ErrNotFound if the
+// subscription does not exist
+func (s *Store) Failures(id string) ([]Failure, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	e := s.find(id)
+	if e == nil {
+		return nil, driver.ErrNotFound
+	}
+	return append([]Failure{}, e.Failures...), nil
+}
+
+// find returns the entry of a subscription, or nil if it does not exist
+func (s *Store) find(id string) *entry {
+	for _, e := range s.entries {
+		if e.ID == id {
+			return e
+		}
+	}
+	return nil
+}
+
+// save writes the subscriptions to a temporary file renamed to the file of the store, so that the file is
+// never left partly written
+func (s *Store) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	b, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return errors.Wrap(err, "failed to save subscriptions")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "failed to save subscriptions")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to save subscriptions")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), s.path), "failed to save subscriptions")
+}
+
+// lockFile takes an exclusive lock of the file at path, created if it does not exist. The lock is released when
+// the file is closed, or when the process exits.
+func lockFile(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// randomHex returns n random bytes, hex encoded
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed to generate random bytes")
+	}
+	return hex.EncodeToString(b), nil
+}
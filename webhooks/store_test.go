@@ -0,0 +1,132 @@
+package webhooks
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ONSdigital/dp-code-list-api/events"
+	"github.com/ONSdigital/dp-graph/v2/graph/driver"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStore(t *testing.T) {
+	Convey("Given a store saving subscriptions to a file", t, func() {
+		dir, err := ioutil.TempDir("", "webhooks")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "subscriptions.json")
+
+		store, err := NewStore(path)
+		So(err, ShouldBeNil)
+		defer store.Close(context.Background())
+
+		Convey("The file cannot be used by another store until the store is closed", func() {
+			_, err := NewStore(path)
+			So(err, ShouldNotBeNil)
+
+			So(store.Close(context.Background()), ShouldBeNil)
+			other, err := NewStore(path)
+			So(err, ShouldBeNil)
+			So(other.Close(context.Background()), ShouldBeNil)
+		})
+
+		Convey("An added subscription is given an ID and a secret", func() {
+			subscription, err := store.Add(Subscription{URL: "http://localhost/callback"})
+			So(err, ShouldBeNil)
+			So(subscription.ID, ShouldNotBeEmpty)
+			So(subscription.Secret, ShouldHaveLength, 64)
+			So(subscription.Created.IsZero(), ShouldBeFalse)
+
+			Convey("And it is kept, with its failures, when the store is created again", func() {
+				failure := Failure{Event: events.New(events.CodeListCreated, "local-authority", ""), Attempts: 5, Error: "callback returned 500"}
+				So(store.AddFailure(subscription.ID, failure), ShouldBeNil)
+				So(store.Close(context.Background()), ShouldBeNil)
+
+				reloaded, err := NewStore(path)
+				So(err, ShouldBeNil)
+				defer reloaded.Close(context.Background())
+				got, err := reloaded.Get(subscription.ID)
+				So(err, ShouldBeNil)
+				So(got.URL, ShouldEqual, subscription.URL)
+				So(got.Secret, ShouldEqual, subscription.Secret)
+
+				failures, err := reloaded.Failures(subscription.ID)
+				So(err, ShouldBeNil)
+				So(failures, ShouldHaveLength, 1)
+				So(failures[0].Error, ShouldEqual, "callback returned 500")
+			})
+		})
+
+		Convey("A provided secret is kept", func() {
+			subscription, err := store.Add(Subscription{URL: "http://localhost/callback", Secret: "secret"})
+			So(err, ShouldBeNil)
+			So(subscription.Secret, ShouldEqual, "secret")
+		})
+
+		Convey("Only the latest failures are kept", func() {
+			subscription, err := store.Add(Subscription{URL: "http://localhost/callback"})
+			So(err, ShouldBeNil)
+			for i := 0; i <= maxFailures; i++ {
+				So(store.AddFailure(subscription.ID, Failure{Attempts: i}), ShouldBeNil)
+			}
+
+			failures, err := store.Failures(subscription.ID)
+			So(err, ShouldBeNil)
+			So(failures, ShouldHaveLength, maxFailures)
+			So(failures[0].Attempts, ShouldEqual, 1)
+		})
+
+		Convey("The subscriptions matching an event are returned", func() {
+			all, err := store.Add(Subscription{URL: "http://localhost/all"})
+			So(err, ShouldBeNil)
+			codeList, err := store.Add(Subscription{URL: "http://localhost/code-list", CodeLists: []string{"local-authority"}})
+			So(err, ShouldBeNil)
+			_, err = store.Add(Subscription{URL: "http://localhost/type", EventTypes: []events.Type{events.CodeListCreated}})
+			So(err, ShouldBeNil)
+
+			matching := store.Matching(events.New(events.CodesChanged, "local-authority", "2019"))
+			So(matching, ShouldResemble, []Subscription{all, codeList})
+		})
+
+		Convey("A removed subscription is no longer kept, nor matched", func() {
+			subscription, err := store.Add(Subscription{URL: "http://localhost/callback"})
+			So(err, ShouldBeNil)
+			So(store.Remove(subscription.ID), ShouldBeNil)
+
+			_, err = store.Get(subscription.ID)
+			So(err, ShouldEqual, driver.ErrNotFound)
+			So(store.Matching(events.New(events.CodeListCreated, "local-authority", "")), ShouldBeEmpty)
+
+			So(store.Close(context.Background()), ShouldBeNil)
+			reloaded, err := NewStore(path)
+			So(err, ShouldBeNil)
+			defer reloaded.Close(context.Background())
+			_, err = reloaded.Get(subscription.ID)
+			So(err, ShouldEqual, driver.ErrNotFound)
+		})
+
+		Convey("driver.ErrNotFound is returned for an unknown subscription", func() {
+			_, err := store.Get("unknown")
+			So(err, ShouldEqual, driver.ErrNotFound)
+			_, err = store.Failures("unknown")
+			So(err, ShouldEqual, driver.ErrNotFound)
+			So(store.AddFailure("unknown", Failure{}), ShouldEqual, driver.ErrNotFound)
+			So(store.Remove("unknown"), ShouldEqual, driver.ErrNotFound)
+		})
+	})
+
+	Convey("NewStore fails for an invalid subscriptions file", t, func() {
+		f, err := ioutil.TempFile("", "subscriptions")
+		So(err, ShouldBeNil)
+		defer os.Remove(f.Name())
+		defer os.Remove(f.Name() + ".lock")
+		f.WriteString("{")
+		f.Close()
+
+		_, err = NewStore(f.Name())
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,65 @@
+// Package webhooks delivers change events to the HTTP callbacks registered by subscribers, for the services
+// that do not consume events from Kafka. Payloads are signed with the secret of each subscription, failed
+// deliveries are retried with an exponential backoff, and the deliveries that still fail are kept in a
+// dead-letter list of the subscription.
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/ONSdigital/dp-code-list-api/events"
+)
+
+// Subscription is a callback URL receiving the events of the code lists, code list types and event types it is
+// filtered by. An empty filter matches every code list, every code list type, or every type of event.
+type Subscription struct {
+	ID            string        `json:"id"`
+	URL           string        `json:"url"`
+	CodeLists     []string      `json:"code_lists,omitempty"`
+	CodeListTypes []string      `json:"code_list_types,omitempty"`
+	EventTypes    []events.Type `json:"event_types,omitempty"`
+	Secret        string        `json:"secret"`
+	Created       time.Time     `json:"created"`
+}
+
+// Failure is an event which could not be delivered to a subscription
+type Failure struct {
+	Event    events.Event `json:"event"`
+	Attempts int          `json:"attempts"`
+	Error    string       `json:"error"`
+	Time     time.Time    `json:"time"`
+}
+
+// Matches reports whether an event is of a code list and event type the subscription is filtered by. The type
+// of the code list is checked by MatchesCodeListType.
+func (s *Subscription) Matches(event events.Event) bool {
+	codeListMatches := len(s.CodeLists) == 0
+	for _, codeListID := range s.CodeLists {
+		codeListMatches = codeListMatches || codeListID == event.CodeListID
+	}
+	typeMatches := len(s.EventTypes) == 0
+	for _, eventType := range s.EventTypes {
+		typeMatches = typeMatches || eventType == event.Type
+	}
+	return codeListMatches && typeMatches
+}
+
+// MatchesCodeListType reports whether a code list type is one the subscription is filtered by
+func (s *Subscription) MatchesCodeListType(codeListType string) bool {
+	matches := len(s.CodeListTypes) == 0
+	for _, t := range s.CodeListTypes {
+		matches = matches || t == codeListType
+	}
+	return matches
+}
+
+// Sign returns the signature of a payload sent to the subscription, the hex encoded HMAC-SHA256 of the
+// payload keyed with the secret of the subscription
+func (s *Subscription) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(s.Secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}